@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/oasysgames/oasys-optimism-verifier/cmd/ipccmd"
+	"github.com/oasysgames/oasys-optimism-verifier/ipc"
+	"github.com/oasysgames/oasys-optimism-verifier/p2p"
+	"github.com/oasysgames/oasys-optimism-verifier/version"
+	"github.com/spf13/cobra"
+)
+
+var bootnodeCmd = &cobra.Command{
+	Use:   "bootnode",
+	Short: "Start the bootnode",
+	Long: "Start the bootnode that runs only the libp2p host, the DHT server and the optional circuit relay service.\n" +
+		"The Hub-Layer, the wallets and the database are not required.",
+	Run: runBootnodeCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(bootnodeCmd)
+}
+
+func runBootnodeCmd(cmd *cobra.Command, args []string) {
+	log.Info(fmt.Sprintf("Start %s bootnode", commandName), "version", version.SemVer())
+
+	sigC := make(chan os.Signal, 1)
+	signal.Notify(sigC, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		defer cancel()
+		sig := <-sigC
+		log.Info("Received signal, stopping...", "signal", sig)
+	}()
+
+	conf, err := globalConfigLoader.loadBootnode()
+	if err != nil {
+		log.Crit("Failed to load configuration", "err", err)
+	}
+	log.Info("Loaded configuration", "conf", conf)
+
+	s := &server{conf: conf}
+
+	// start metrics server
+	s.mustStartMetrics(ctx)
+
+	// start pprof server
+	s.mustStartPprof(ctx)
+
+	// start the ipc server and the bootnode
+	s.mustStartIPC(ctx, []func(context.Context, *ipc.Server){
+		s.mustStartBootnode,
+	})
+
+	// wait for signal
+	<-ctx.Done()
+	log.Info("Shutting down bootnode")
+
+	// Shutdown metrics server
+	if s.msvr != nil {
+		c, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		s.msvr.Shutdown(c)
+	}
+	// Shutdown pprof server
+	if s.psvr != nil {
+		c, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		s.psvr.Shutdown(c)
+	}
+	// Shutdown ipc server
+	s.closeIPC()
+
+	s.wg.Wait()
+	log.Info("Bootnode stopped")
+}
+
+func (s *server) mustStartBootnode(ctx context.Context, ipc *ipc.Server) {
+	// get p2p private key
+	p2pKey, err := getOrCreateP2PKey(s.conf.P2PKeyPath())
+	if err != nil {
+		log.Crit("Failed to get(or create) p2p key", "err", err)
+	}
+
+	// open the datastore to persist the peerstore and the DHT
+	var p2pStore ds.Batching
+	if s.conf.P2P.PeerStore.Enable {
+		if p2pStore, err = p2p.OpenDatastore(s.conf.P2PDatastorePath()); err != nil {
+			log.Crit("Failed to open p2p datastore", "err", err)
+		}
+	}
+
+	// construct libp2p host running the DHT server
+	host, err := p2p.NewBootnodeHost(ctx, &s.conf.P2P, p2pKey, p2pStore)
+	if err != nil {
+		log.Crit("Failed to construct libp2p host", "err", err)
+	}
+
+	// the bootnode does not verify the validators, exchange the node info nor score the peers
+	p2pAPI := ipccmd.NewP2PAPI(host, nil,
+		func() []*p2p.VerifiedPeer { return nil },
+		func() *p2p.NodeInfo { return nil },
+		func() []*p2p.BlockedPeer { return nil })
+	mustRegisterAPI(ipc, ipccmd.AdminNamespace, ipccmd.NewAdminAPI(p2pAPI,
+		func(context.Context) ([]string, error) {
+			return nil, errors.New("reload is not supported by the bootnode")
+		}))
+	mustRegisterAPI(ipc, ipccmd.P2PNamespace, p2pAPI)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		<-ctx.Done()
+		if err := host.Close(); err != nil {
+			log.Error("Failed to close libp2p host", "err", err)
+		}
+		if p2pStore != nil {
+			if err := p2pStore.Close(); err != nil {
+				log.Error("Failed to close p2p datastore", "err", err)
+			}
+		}
+	}()
+}
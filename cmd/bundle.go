@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/oasysgames/oasys-optimism-verifier/cmd/ipccmd"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outFlag  = "out"
+	fileFlag = "file"
+)
+
+var bundleExportCmd = &cobra.Command{
+	Use:   "bundle:export",
+	Short: "Export the signature bundle of the rollup",
+	Long: "Export the signatures and calldata required to verify the rollup as JSON, " +
+		"which can be submitted by anyone without running the submitter",
+	Run: func(cmd *cobra.Command, args []string) {
+		conf, err := globalConfigLoader.load(true)
+		if err != nil {
+			util.Exit(1, "Failed to load configuration: %s\n", err)
+		}
+
+		contract, err := cmd.Flags().GetString(contractFlag)
+		if err != nil {
+			util.Exit(1, "Failed to read '%s' argument: %s\n", contractFlag, err)
+		}
+		rollupIndex, err := cmd.Flags().GetUint64(indexFlag)
+		if err != nil {
+			util.Exit(1, "Failed to read '%s' argument: %s\n", indexFlag, err)
+		}
+		out, err := cmd.Flags().GetString(outFlag)
+		if err != nil {
+			util.Exit(1, "Failed to read '%s' argument: %s\n", outFlag, err)
+		}
+
+		bundle := ipccmd.BundleExportCmd.Run(conf.IPCPath(), contract, rollupIndex)
+		if out == "" {
+			os.Stdout.Write(append(bundle, '\n'))
+		} else if err := os.WriteFile(out, bundle, 0644); err != nil {
+			util.Exit(1, "Failed to write bundle: %s\n", err)
+		}
+	},
+}
+
+var bundleImportCmd = &cobra.Command{
+	Use:   "bundle:import",
+	Short: "Import the signature bundle of the rollup",
+	Long:  "Verify the signatures in the bundle exported by another node, and save them to the database",
+	Run: func(cmd *cobra.Command, args []string) {
+		conf, err := globalConfigLoader.load(true)
+		if err != nil {
+			util.Exit(1, "Failed to load configuration: %s\n", err)
+		}
+
+		file, err := cmd.Flags().GetString(fileFlag)
+		if err != nil {
+			util.Exit(1, "Failed to read '%s' argument: %s\n", fileFlag, err)
+		}
+
+		bundle, err := os.ReadFile(file)
+		if err != nil {
+			util.Exit(1, "Failed to read bundle: %s\n", err)
+		}
+
+		ipccmd.BundleImportCmd.Run(conf.IPCPath(), bundle)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(bundleExportCmd)
+	rootCmd.AddCommand(bundleImportCmd)
+
+	bundleExportCmd.Flags().String(contractFlag, "", "Address of the rollup contract")
+	bundleExportCmd.MarkFlagRequired(contractFlag)
+	bundleExportCmd.Flags().Uint64(indexFlag, 0, "Rollup index")
+	bundleExportCmd.MarkFlagRequired(indexFlag)
+	bundleExportCmd.Flags().String(outFlag, "", "Output file path (default: stdout)")
+
+	bundleImportCmd.Flags().String(fileFlag, "", "Bundle file path")
+	bundleImportCmd.MarkFlagRequired(fileFlag)
+}
@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+	"github.com/oasysgames/oasys-optimism-verifier/wallet"
+	"github.com/spf13/cobra"
+)
+
+const (
+	timeoutFlag = "timeout"
+	strictFlag  = "strict"
+)
+
+const (
+	checkPass = "PASS"
+	checkWarn = "WARN"
+	checkFail = "FAIL"
+)
+
+var configCheckCmd = &cobra.Command{
+	Use:   "config:check",
+	Short: "Check the configuration before starting the node",
+	Long: "Load the configuration and check the connectivity to the Hub-Layer and the Verse-Layers, " +
+		"the wallets and the stake of the verifier. Exits with 1 if any check failed",
+	Run: runConfigCheckCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(configCheckCmd)
+
+	configCheckCmd.Flags().Duration(timeoutFlag, 10*time.Second, "Timeout of each connectivity check")
+	configCheckCmd.Flags().Bool(strictFlag, false, "Treat the warnings as failures")
+}
+
+type checkResult struct {
+	status, name, detail string
+}
+
+// Runs the preflight checks of the configuration and collects the results.
+type configChecker struct {
+	conf    *config.Config
+	timeout time.Duration
+	results []*checkResult
+
+	// verses from the static settings and the discovery, nil if the discovery failed
+	verses     []*config.Verse
+	discovered map[uint64]bool
+}
+
+func runConfigCheckCmd(cmd *cobra.Command, args []string) {
+	timeout, err := cmd.Flags().GetDuration(timeoutFlag)
+	if err != nil {
+		util.Exit(1, "Failed to read '%s' argument: %s\n", timeoutFlag, err)
+	}
+	strict, err := cmd.Flags().GetBool(strictFlag)
+	if err != nil {
+		util.Exit(1, "Failed to read '%s' argument: %s\n", strictFlag, err)
+	}
+
+	conf, err := globalConfigLoader.load(true)
+	if err != nil {
+		util.Exit(1, "[%s] config: %s\n", checkFail, err)
+	}
+
+	c := &configChecker{conf: conf, timeout: timeout}
+	c.run(context.Background())
+
+	counts := map[string]int{}
+	for _, r := range c.results {
+		counts[r.status]++
+		fmt.Printf("[%s] %s: %s\n", r.status, r.name, r.detail)
+	}
+	fmt.Printf("\n%d passed, %d warnings, %d failed\n", counts[checkPass], counts[checkWarn], counts[checkFail])
+
+	if counts[checkFail] > 0 || (strict && counts[checkWarn] > 0) {
+		os.Exit(1)
+	}
+}
+
+func (c *configChecker) run(ctx context.Context) {
+	c.add(checkPass, "config", "loaded and validated")
+	c.checkHubLayer(ctx)
+	c.checkVerseLayer(ctx)
+	c.checkWallets()
+	c.checkVerifier(ctx)
+	c.checkSubmitter()
+}
+
+func (c *configChecker) add(status, name, format string, args ...any) {
+	c.results = append(c.results, &checkResult{status, name, fmt.Sprintf(format, args...)})
+}
+
+// Returns the chain ID responded by the RPC.
+func (c *configChecker) chainID(ctx context.Context, url string) (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	cl, err := ethclient.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer cl.Close()
+	return cl.ChainID(ctx)
+}
+
+func (c *configChecker) checkHubLayer(ctx context.Context) {
+	name := "hub_layer.rpc"
+	chainID, err := c.chainID(ctx, c.conf.HubLayer.RPC)
+	if err != nil {
+		c.add(checkFail, name, "failed to fetch chain id from %s: %s", c.conf.HubLayer.RPC, err)
+	} else if chainID.Uint64() != c.conf.HubLayer.ChainID {
+		c.add(checkFail, name, "chain id mismatch, config: %d, rpc: %s", c.conf.HubLayer.ChainID, chainID)
+	} else {
+		c.add(checkPass, name, "chain id %s", chainID)
+	}
+}
+
+func (c *configChecker) checkVerseLayer(ctx context.Context) {
+	c.verses = slices.Clone(c.conf.VerseLayer.Directs)
+	c.discovered = map[uint64]bool{}
+
+	if endpoint := c.conf.VerseLayer.Discovery.Endpoint; endpoint != "" {
+		name := "verse_layer.discovery"
+		ctx, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+
+		discovered, err := discoverVerseConfigs(ctx, c.conf)
+		if err != nil {
+			c.verses = nil
+			c.add(checkFail, name, "failed to fetch %s: %s", endpoint, err)
+		} else {
+			c.add(checkPass, name, "%d verses", len(discovered))
+			for _, cfg := range discovered {
+				// the static verses take precedence over the discovered ones
+				if !slices.ContainsFunc(c.verses, func(v *config.Verse) bool { return v.ChainID == cfg.ChainID }) {
+					c.verses = append(c.verses, cfg)
+					c.discovered[cfg.ChainID] = true
+				}
+			}
+		}
+	}
+
+	for _, cfg := range c.verses {
+		name := fmt.Sprintf("verse_layer[%d]", cfg.ChainID)
+
+		// the operator is not responsible for the discovered verses
+		failure := checkFail
+		if c.discovered[cfg.ChainID] {
+			failure = checkWarn
+		}
+
+		if !slices.ContainsFunc([]string{SCCName, L2OOName}, func(n string) bool {
+			_, ok := cfg.L1Contracts[n]
+			return ok
+		}) {
+			c.add(failure, name, "neither %s nor %s is in l1_contracts", SCCName, L2OOName)
+			continue
+		}
+
+		chainID, err := c.chainID(ctx, cfg.RPC)
+		if err != nil {
+			c.add(failure, name, "failed to fetch chain id from %s: %s", cfg.RPC, err)
+		} else if chainID.Uint64() != cfg.ChainID {
+			c.add(failure, name, "chain id mismatch, config: %d, rpc: %s", cfg.ChainID, chainID)
+		} else {
+			c.add(checkPass, name, "chain id %s", chainID)
+		}
+	}
+}
+
+func (c *configChecker) checkWallets() {
+	var ks *wallet.KeyStore
+	if c.conf.Keystore != "" {
+		ks = wallet.NewKeyStore(c.conf.Keystore)
+	}
+
+	names := make([]string, 0, len(c.conf.Wallets))
+	for name := range c.conf.Wallets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		w := c.conf.Wallets[name]
+		address := common.HexToAddress(w.Address)
+		checkName := fmt.Sprintf("wallets.%s", name)
+
+		// Plain text private key.
+		if w.Plain != "" {
+			priv, err := ethcrypto.HexToECDSA(strings.TrimPrefix(w.Plain, "0x"))
+			if err != nil {
+				c.add(checkFail, checkName, "failed to decode private key: %s", err)
+			} else if from := ethcrypto.PubkeyToAddress(priv.PublicKey); from != address {
+				c.add(checkFail, checkName, "private key address mismatch, config: %s, key: %s", address, from)
+			} else {
+				c.add(checkPass, checkName, "plaintext private key of %s", address)
+			}
+			continue
+		}
+
+		// go-ethereum's private key.
+		if ks == nil {
+			c.add(checkFail, checkName, "keystore directory is not specified")
+			continue
+		}
+		_, account, err := ks.FindWallet(address)
+		if err != nil {
+			c.add(checkFail, checkName, "%s is not found in the keystore", address)
+			continue
+		}
+
+		if w.Password == "" {
+			if ks.Unlock(*account, "") == nil {
+				c.add(checkPass, checkName, "%s is unlocked using empty password", address)
+			} else {
+				c.add(checkWarn, checkName, "%s will wait for unlock via IPC", address)
+			}
+			continue
+		}
+		pw, err := os.ReadFile(w.Password)
+		if err != nil {
+			c.add(checkFail, checkName, "failed to read password file: %s", err)
+		} else if err := ks.Unlock(*account, strings.Trim(string(pw), "\r\n\t ")); err != nil {
+			c.add(checkFail, checkName, "failed to unlock %s using password file: %s", address, err)
+		} else {
+			c.add(checkPass, checkName, "%s is unlocked using password file", address)
+		}
+	}
+}
+
+func (c *configChecker) checkVerifier(ctx context.Context) {
+	if !c.conf.Verifier.Enable {
+		return
+	}
+
+	name := "verifier.wallet"
+	w, ok := c.conf.Wallets[c.conf.Verifier.Wallet]
+	if !ok {
+		c.add(checkFail, name, "wallet %q is not found in wallets", c.conf.Verifier.Wallet)
+		return
+	}
+	signer := common.HexToAddress(w.Address)
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	hub, err := ethutil.NewClient(c.conf.HubLayer.RPC, c.conf.HubLayer.BlockTime)
+	if err != nil {
+		c.add(checkFail, name, "failed to construct hub-layer client: %s", err)
+		return
+	}
+	defer hub.Close()
+
+	sm, err := stakemanager.NewStakemanagerCaller(common.HexToAddress(StakeManagerAddress), hub)
+	if err != nil {
+		c.add(checkFail, name, "failed to construct StakeManager: %s", err)
+		return
+	}
+	stake, err := sm.GetOperatorStakes(&bind.CallOpts{Context: ctx}, signer, common.Big0)
+	c.checkStake(name, signer, stake, err)
+}
+
+func (c *configChecker) checkStake(name string, signer common.Address, stake *big.Int, err error) {
+	if err != nil {
+		c.add(checkFail, name, "failed to fetch stake of %s: %s", signer, err)
+	} else if stake.Sign() == 0 {
+		c.add(checkFail, name, "%s has no stake in StakeManager", signer)
+	} else if stake.Cmp(ethutil.TenMillionOAS) < 0 {
+		c.add(checkWarn, name, "stake of %s is %s OAS, signatures below %s OAS are ignored by submitters",
+			signer, toOAS(stake), toOAS(ethutil.TenMillionOAS))
+	} else {
+		c.add(checkPass, name, "%s has %s OAS stake", signer, toOAS(stake))
+	}
+}
+
+func (c *configChecker) checkSubmitter() {
+	if !c.conf.Submitter.Enable {
+		return
+	}
+
+	if len(c.conf.Submitter.Targets) == 0 {
+		c.add(checkWarn, "submitter.targets", "no targets")
+	}
+	for _, target := range c.conf.Submitter.Targets {
+		name := fmt.Sprintf("submitter.targets[%d]", target.ChainID)
+		if _, ok := c.conf.Wallets[target.Wallet]; !ok {
+			c.add(checkFail, name, "wallet %q is not found in wallets", target.Wallet)
+		} else if c.verses != nil &&
+			!slices.ContainsFunc(c.verses, func(v *config.Verse) bool { return v.ChainID == target.ChainID }) {
+			c.add(checkWarn, name, "chain id %d is not found in the verses", target.ChainID)
+		} else {
+			c.add(checkPass, name, "wallet %q", target.Wallet)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigCheckTestSuite struct {
+	suite.Suite
+
+	hub, verse *httptest.Server
+}
+
+type testEthAPI struct {
+	chainID uint64
+}
+
+func (api *testEthAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).SetUint64(api.chainID))
+}
+
+func TestConfigCheck(t *testing.T) {
+	suite.Run(t, new(ConfigCheckTestSuite))
+}
+
+func (s *ConfigCheckTestSuite) SetupTest() {
+	newServer := func(chainID uint64) *httptest.Server {
+		svr := rpc.NewServer()
+		s.Require().NoError(svr.RegisterName("eth", &testEthAPI{chainID: chainID}))
+		hsvr := httptest.NewServer(svr)
+		s.T().Cleanup(func() {
+			hsvr.Close()
+			svr.Stop()
+		})
+		return hsvr
+	}
+	s.hub = newServer(248)
+	s.verse = newServer(420)
+}
+
+func (s *ConfigCheckTestSuite) newChecker(conf *config.Config) *configChecker {
+	return &configChecker{conf: conf, timeout: time.Second}
+}
+
+func (s *ConfigCheckTestSuite) assertResults(c *configChecker, wants [][2]string) {
+	s.Len(c.results, len(wants))
+	for i, want := range wants {
+		if i < len(c.results) {
+			s.Equal(want[0], c.results[i].status, c.results[i].detail)
+			s.Equal(want[1], c.results[i].name)
+		}
+	}
+}
+
+func (s *ConfigCheckTestSuite) TestCheckHubLayer() {
+	ctx := context.Background()
+	cases := []struct {
+		rpc     string
+		chainID uint64
+		want    string
+	}{
+		{s.hub.URL, 248, checkPass},
+		{s.hub.URL, 249, checkFail},
+		{"http://127.0.0.1:1", 248, checkFail},
+	}
+	for _, tc := range cases {
+		c := s.newChecker(&config.Config{HubLayer: config.HubLayer{ChainID: tc.chainID, RPC: tc.rpc}})
+		c.checkHubLayer(ctx)
+		s.assertResults(c, [][2]string{{tc.want, "hub_layer.rpc"}})
+	}
+}
+
+func (s *ConfigCheckTestSuite) TestCheckVerseLayer() {
+	contracts := map[string]string{SCCName: "0x01"}
+	c := s.newChecker(&config.Config{VerseLayer: config.VerseLayer{Directs: []*config.Verse{
+		{ChainID: 420, RPC: s.verse.URL, L1Contracts: contracts},
+		{ChainID: 421, RPC: s.verse.URL, L1Contracts: contracts},
+		{ChainID: 422, RPC: s.verse.URL, L1Contracts: map[string]string{"Unknown": "0x01"}},
+	}}})
+	c.checkVerseLayer(context.Background())
+	s.assertResults(c, [][2]string{
+		{checkPass, "verse_layer[420]"},
+		{checkFail, "verse_layer[421]"},
+		{checkFail, "verse_layer[422]"},
+	})
+	s.Len(c.verses, 3)
+}
+
+func (s *ConfigCheckTestSuite) TestCheckWallets() {
+	priv, _ := ethcrypto.GenerateKey()
+	address := ethcrypto.PubkeyToAddress(priv.PublicKey)
+	plain := hexutil.Encode(ethcrypto.FromECDSA(priv))
+
+	c := s.newChecker(&config.Config{Wallets: map[string]*config.Wallet{
+		"a": {Address: address.Hex(), Plain: plain},
+		"b": {Address: common.HexToAddress("0x01").Hex(), Plain: plain},
+		"c": {Address: address.Hex()},
+	}})
+	c.checkWallets()
+	s.assertResults(c, [][2]string{
+		{checkPass, "wallets.a"},
+		{checkFail, "wallets.b"},
+		{checkFail, "wallets.c"},
+	})
+
+	// not found in the keystore
+	c.conf.Keystore = s.T().TempDir()
+	c.results = nil
+	c.checkWallets()
+	s.assertResults(c, [][2]string{
+		{checkPass, "wallets.a"},
+		{checkFail, "wallets.b"},
+		{checkFail, "wallets.c"},
+	})
+	s.Contains(c.results[2].detail, "not found in the keystore")
+}
+
+func (s *ConfigCheckTestSuite) TestCheckStake() {
+	signer := common.HexToAddress("0x01")
+	c := s.newChecker(&config.Config{})
+	c.checkStake("verifier.wallet", signer, nil, context.DeadlineExceeded)
+	c.checkStake("verifier.wallet", signer, big.NewInt(0), nil)
+	c.checkStake("verifier.wallet", signer, ethutil.OAS, nil)
+	c.checkStake("verifier.wallet", signer, ethutil.TenMillionOAS, nil)
+	s.assertResults(c, [][2]string{
+		{checkFail, "verifier.wallet"},
+		{checkFail, "verifier.wallet"},
+		{checkWarn, "verifier.wallet"},
+		{checkPass, "verifier.wallet"},
+	})
+}
+
+func (s *ConfigCheckTestSuite) TestCheckSubmitter() {
+	conf := &config.Config{Wallets: map[string]*config.Wallet{"submitter": {}}}
+	conf.Submitter.Enable = true
+	conf.Submitter.Targets = []*config.SubmitterTarget{
+		{ChainID: 420, Wallet: "submitter"},
+		{ChainID: 421, Wallet: "unknown"},
+		{ChainID: 422, Wallet: "submitter"},
+	}
+
+	c := s.newChecker(conf)
+	c.verses = []*config.Verse{{ChainID: 420}, {ChainID: 421}}
+	c.checkSubmitter()
+	s.assertResults(c, [][2]string{
+		{checkPass, "submitter.targets[420]"},
+		{checkFail, "submitter.targets[421]"},
+		{checkWarn, "submitter.targets[422]"},
+	})
+}
@@ -147,12 +147,7 @@ func mustNewConfigLoader(cmd *cobra.Command) *configLoader {
 func (opts *configLoader) load(enableStrictValidation bool) (*config.Config, error) {
 	// load config from the file
 	if !opts.fromCli {
-		path, err := opts.cmd.Flags().GetString(fileConfigFlag)
-		if err != nil {
-			return nil, err
-		}
-
-		input, err := os.ReadFile(path)
+		input, err := opts.readFile()
 		if err != nil {
 			return nil, err
 		}
@@ -205,3 +200,30 @@ func (opts *configLoader) load(enableStrictValidation bool) (*config.Config, err
 
 	return opts.cfg, nil
 }
+
+// Load the configuration for the bootnode, which does not
+// require the Hub-Layer, the Verse-Layer and the wallets.
+func (opts *configLoader) loadBootnode() (*config.Config, error) {
+	// load config from the file
+	if !opts.fromCli {
+		input, err := opts.readFile()
+		if err != nil {
+			return nil, err
+		}
+		return config.NewBootnodeConfig(input)
+	}
+
+	// load config from command line arguments
+	if err := config.ValidateBootnode(opts.cfg); err != nil {
+		return nil, err
+	}
+	return opts.cfg, nil
+}
+
+func (opts *configLoader) readFile() ([]byte, error) {
+	path, err := opts.cmd.Flags().GetString(fileConfigFlag)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
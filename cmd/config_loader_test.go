@@ -129,6 +129,42 @@ func (s *ConfigLoaderTestSuite) TestLoadConfigFromYAML() {
 	s.Equal(want, got)
 }
 
+func (s *ConfigLoaderTestSuite) TestLoadBootnodeConfigFromYAML() {
+	yaml := fmt.Sprintf(`
+	datastore: %s
+
+	p2p:
+		listens:
+			- /ip4/0.0.0.0/tcp/4101
+		relay_service:
+			enable: true
+	`, s.datastoreDir)
+
+	// write yaml to tempfile
+	s.confFile.WriteString(strings.ReplaceAll(yaml, "\t", "  "))
+
+	// add dummy command
+	subCmd := &cobra.Command{Use: "test:load-bootnode-config"}
+	rootCmd.AddCommand(subCmd)
+
+	// run dummy command
+	rootCmd.SetArgs([]string{
+		subCmd.Use,
+		"--config", s.confFile.Name(),
+	})
+	rootCmd.Execute()
+
+	// the hub_layer is required by the verifier
+	_, err := globalConfigLoader.load(false)
+	s.ErrorContains(err, "Config.hub_layer.chain_id")
+
+	got, err := globalConfigLoader.loadBootnode()
+	s.NoError(err)
+	s.Equal(s.datastoreDir, got.Datastore)
+	s.Equal([]string{"/ip4/0.0.0.0/tcp/4101"}, got.P2P.Listens)
+	s.True(got.P2P.RelayService.Enable)
+}
+
 func (s *ConfigLoaderTestSuite) TestLoadConfigWithMinCliArgs() {
 	want := s.configWithMinCliArgs()
 	got := s.executeWithCliArgs(nil)
@@ -263,11 +299,25 @@ func (s *ConfigLoaderTestSuite) configWithMinCliArgs() *config.Config {
 			NoAnnounce:       defaults["p2p.no_announce"].([]string),
 			ConnectionFilter: defaults["p2p.connection_filter"].([]string),
 			Transports: struct {
-				TCP  bool
-				QUIC bool
+				TCP       bool
+				QUIC      bool
+				WebSocket struct {
+					Enable   bool
+					CertFile string `koanf:"cert_file" validate:"required_with=KeyFile,omitempty,file"`
+					KeyFile  string `koanf:"key_file" validate:"required_with=CertFile,omitempty,file"`
+				} `koanf:"websocket"`
+				WebTransport bool `koanf:"webtransport"`
 			}{
 				TCP:  defaults["p2p.transports.tcp"].(bool),
 				QUIC: defaults["p2p.transports.quic"].(bool),
+				WebSocket: struct {
+					Enable   bool
+					CertFile string `koanf:"cert_file" validate:"required_with=KeyFile,omitempty,file"`
+					KeyFile  string `koanf:"key_file" validate:"required_with=CertFile,omitempty,file"`
+				}{
+					Enable: defaults["p2p.transports.websocket.enable"].(bool),
+				},
+				WebTransport: defaults["p2p.transports.webtransport"].(bool),
 			},
 			Listen:    "",
 			Bootnodes: nil,
@@ -311,6 +361,7 @@ func (s *ConfigLoaderTestSuite) configWithMinCliArgs() *config.Config {
 				RelayNodes: nil,
 			},
 			PublishInterval: defaults["p2p.publish_interval"].(time.Duration),
+			LegacyTopic:     defaults["p2p.legacy_topic"].(bool),
 			StreamTimeout:   defaults["p2p.stream_timeout"].(time.Duration),
 			OutboundLimits: struct {
 				Concurrency int
@@ -320,13 +371,82 @@ func (s *ConfigLoaderTestSuite) configWithMinCliArgs() *config.Config {
 				Throttling:  defaults["p2p.outbound_limits.throttling"].(int),
 			},
 			InboundLimits: struct {
-				Concurrency int
-				Throttling  int
-				MaxSendTime time.Duration "koanf:\"max_send_time\""
+				Concurrency    int
+				Throttling     int
+				MaxSendTime    time.Duration "koanf:\"max_send_time\""
+				PeerThrottling int           "koanf:\"peer_throttling\""
+			}{
+				Concurrency:    defaults["p2p.inbound_limits.concurrency"].(int),
+				Throttling:     defaults["p2p.inbound_limits.throttling"].(int),
+				MaxSendTime:    defaults["p2p.inbound_limits.max_send_time"].(time.Duration),
+				PeerThrottling: defaults["p2p.inbound_limits.peer_throttling"].(int),
+			},
+			Misbehaviour: struct {
+				Threshold     float64
+				BlockDuration time.Duration "koanf:\"block_duration\""
+				ScoreDecay    time.Duration "koanf:\"score_decay\""
+			}{
+				Threshold:     defaults["p2p.misbehaviour.threshold"].(float64),
+				BlockDuration: defaults["p2p.misbehaviour.block_duration"].(time.Duration),
+				ScoreDecay:    defaults["p2p.misbehaviour.score_decay"].(time.Duration),
+			},
+			PeerScore: struct {
+				Enable                 bool
+				InvalidMessageWeight   float64       "koanf:\"invalid_message_weight\""
+				InvalidMessageDecay    time.Duration "koanf:\"invalid_message_decay\""
+				IPColocationWeight     float64       "koanf:\"ip_colocation_weight\""
+				IPColocationThreshold  int           "koanf:\"ip_colocation_threshold\""
+				BehaviourPenaltyWeight float64       "koanf:\"behaviour_penalty_weight\""
+				BehaviourPenaltyDecay  time.Duration "koanf:\"behaviour_penalty_decay\""
+				GossipThreshold        float64       "koanf:\"gossip_threshold\""
+				PublishThreshold       float64       "koanf:\"publish_threshold\""
+				GraylistThreshold      float64       "koanf:\"graylist_threshold\""
+			}{
+				Enable:                 defaults["p2p.peer_score.enable"].(bool),
+				InvalidMessageWeight:   defaults["p2p.peer_score.invalid_message_weight"].(float64),
+				InvalidMessageDecay:    defaults["p2p.peer_score.invalid_message_decay"].(time.Duration),
+				IPColocationWeight:     defaults["p2p.peer_score.ip_colocation_weight"].(float64),
+				IPColocationThreshold:  defaults["p2p.peer_score.ip_colocation_threshold"].(int),
+				BehaviourPenaltyWeight: defaults["p2p.peer_score.behaviour_penalty_weight"].(float64),
+				BehaviourPenaltyDecay:  defaults["p2p.peer_score.behaviour_penalty_decay"].(time.Duration),
+				GossipThreshold:        defaults["p2p.peer_score.gossip_threshold"].(float64),
+				PublishThreshold:       defaults["p2p.peer_score.publish_threshold"].(float64),
+				GraylistThreshold:      defaults["p2p.peer_score.graylist_threshold"].(float64),
+			},
+			CatchUp: struct {
+				Enable        bool
+				Interval      time.Duration
+				Peers         int
+				MaxSignatures int "koanf:\"max_signatures\""
+			}{
+				Enable:        defaults["p2p.catch_up.enable"].(bool),
+				Interval:      defaults["p2p.catch_up.interval"].(time.Duration),
+				Peers:         defaults["p2p.catch_up.peers"].(int),
+				MaxSignatures: defaults["p2p.catch_up.max_signatures"].(int),
+			},
+			PeerStore: struct {
+				Enable         bool
+				ReconnectPeers int "koanf:\"reconnect_peers\""
 			}{
-				Concurrency: defaults["p2p.inbound_limits.concurrency"].(int),
-				Throttling:  defaults["p2p.inbound_limits.throttling"].(int),
-				MaxSendTime: defaults["p2p.inbound_limits.max_send_time"].(time.Duration),
+				Enable:         defaults["p2p.peer_store.enable"].(bool),
+				ReconnectPeers: defaults["p2p.peer_store.reconnect_peers"].(int),
+			},
+			ConnManager: struct {
+				LowWater       int           "koanf:\"low_water\""
+				HighWater      int           "koanf:\"high_water\""
+				GracePeriod    time.Duration "koanf:\"grace_period\""
+				ProtectedPeers []string      "koanf:\"protected_peers\""
+			}{
+				LowWater:    defaults["p2p.conn_manager.low_water"].(int),
+				HighWater:   defaults["p2p.conn_manager.high_water"].(int),
+				GracePeriod: defaults["p2p.conn_manager.grace_period"].(time.Duration),
+			},
+			MDNS: struct {
+				Enable      bool
+				ServiceName string "koanf:\"service_name\""
+			}{
+				Enable:      defaults["p2p.mdns.enable"].(bool),
+				ServiceName: defaults["p2p.mdns.service_name"].(string),
 			},
 		},
 		IPC: config.IPC{
@@ -375,6 +495,10 @@ func (s *ConfigLoaderTestSuite) configWithMinCliArgs() *config.Config {
 			Listen:   defaults["metrics.listen"].(string),
 			Endpoint: defaults["metrics.endpoint"].(string),
 		},
+		StatusAPI: config.StatusAPI{
+			Enable: false,
+			Listen: defaults["status_api.listen"].(string),
+		},
 		Debug: config.Debug{
 			Pprof: config.Pprof{
 				Enable: false,
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/oasysgames/oasys-optimism-verifier/cmd/ipccmd"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	contractFlag = "contract"
+	limitFlag    = "limit"
+)
+
+var conflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "Show detected equivocations and conflicting votes",
+	Long:  "Show signers who signed conflicting messages for the same rollup and rollups on which the validators are split",
+	Run: func(cmd *cobra.Command, args []string) {
+		conf, err := globalConfigLoader.load(true)
+		if err != nil {
+			util.Exit(1, "Failed to load configuration: %s\n", err)
+		}
+
+		contract, err := cmd.Flags().GetString(contractFlag)
+		if err != nil {
+			util.Exit(1, "Failed to read '%s' argument: %s\n", contractFlag, err)
+		}
+
+		limit, err := cmd.Flags().GetInt(limitFlag)
+		if err != nil {
+			util.Exit(1, "Failed to read '%s' argument: %s\n", limitFlag, err)
+		}
+
+		ipccmd.ConflictsCmd.Run(conf.IPCPath(), contract, limit)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(conflictsCmd)
+
+	conflictsCmd.Flags().String(contractFlag, "", "Filter by the address of the rollup contract")
+	conflictsCmd.Flags().Int(limitFlag, 100, "Maximum number of records")
+}
@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	"github.com/oasysgames/oasys-optimism-verifier/ipc"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	retentionFlag = "retention"
+
+	// number of signatures verified at once by `db:check`
+	checkBatchSize = 1000
+)
+
+var dbBackupCmd = &cobra.Command{
+	Use:   "db:backup <path>",
+	Short: "Back up the database",
+	Long:  "Copy the database to the file using the SQLite online backup API, it is safe while the node is running",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		_, db := mustOpenDatabase()
+
+		start := time.Now()
+		if err := db.Backup(context.Background(), args[0]); err != nil {
+			util.Exit(1, "Failed to back up database: %s\n", err)
+		}
+		fmt.Printf("Backed up to %s (elapsed: %s)\n", args[0], time.Since(start).Round(time.Millisecond))
+	},
+}
+
+var dbVacuumCmd = &cobra.Command{
+	Use:   "db:vacuum",
+	Short: "Compact the database",
+	Long:  "Rebuild the database file to reclaim the space of the deleted records, the node must be stopped",
+	Run: func(cmd *cobra.Command, args []string) {
+		conf, db := mustOpenDatabase()
+
+		// VACUUM requires the exclusive lock
+		if cl, err := ipc.Dial(context.Background(), conf.IPCPath()); err == nil {
+			cl.Close()
+			util.Exit(1, "The node is running, stop it before compacting the database\n")
+		}
+
+		start := time.Now()
+		if err := db.Vacuum(); err != nil {
+			util.Exit(1, "Failed to compact database: %s\n", err)
+		}
+		fmt.Printf("Compacted %s (elapsed: %s)\n", conf.Database.Path, time.Since(start).Round(time.Millisecond))
+	},
+}
+
+var dbPruneCmd = &cobra.Command{
+	Use:   "db:prune",
+	Short: "Delete the signatures and the events of the verified rollups",
+	Long: "Delete the signatures and the events whose rollup index is below the next index " +
+		"of each rollup contract on the Hub-Layer, keeping the specified number of verified indexes",
+	Run: runDBPruneCmd,
+}
+
+var dbCheckCmd = &cobra.Command{
+	Use:   "db:check",
+	Short: "Check the integrity of the signatures",
+	Long: "Verify the stored signatures, repair the previous ids of the signatures " +
+		"and report the signatures whose previous signature does not exist",
+	Run: runDBCheckCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(dbBackupCmd)
+	rootCmd.AddCommand(dbVacuumCmd)
+	rootCmd.AddCommand(dbPruneCmd)
+	rootCmd.AddCommand(dbCheckCmd)
+
+	dbPruneCmd.Flags().Uint64(retentionFlag, 1, "Number of the verified rollup indexes to keep")
+}
+
+func mustOpenDatabase() (*config.Config, *database.Database) {
+	conf, err := globalConfigLoader.load(true)
+	if err != nil {
+		util.Exit(1, "Failed to load configuration: %s\n", err)
+	}
+
+	if conf.Database.Path == "" {
+		conf.Database.Path = conf.DatabasePath()
+	}
+	db, err := database.NewDatabase(&conf.Database)
+	if err != nil {
+		util.Exit(1, "Failed to open database: %s\n", err)
+	}
+	return conf, db
+}
+
+func runDBPruneCmd(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	conf, db := mustOpenDatabase()
+
+	retention, err := cmd.Flags().GetUint64(retentionFlag)
+	if err != nil {
+		util.Exit(1, "Failed to read '%s' argument: %s\n", retentionFlag, err)
+	}
+
+	hub, err := ethutil.NewClient(conf.HubLayer.RPC, conf.HubLayer.BlockTime)
+	if err != nil {
+		util.Exit(1, "Failed to construct hub-layer client: %s\n", err)
+	}
+
+	// the static verses take precedence over the discovered ones
+	verseCfgs := slices.Clone(conf.VerseLayer.Directs)
+	if conf.VerseLayer.Discovery.Endpoint != "" {
+		discovered, err := discoverVerseConfigs(ctx, conf)
+		if err != nil {
+			util.Exit(1, "Failed to discover verses: %s\n", err)
+		}
+		for _, cfg := range discovered {
+			if !slices.ContainsFunc(verseCfgs, func(c *config.Verse) bool { return c.ChainID == cfg.ChainID }) {
+				verseCfgs = append(verseCfgs, cfg)
+			}
+		}
+	}
+
+	for _, cfg := range verseCfgs {
+		for _, verse := range newVerses(&conf.Submitter, db, hub, cfg) {
+			contract := verse.RollupContract()
+
+			nextIndex, err := verse.NextIndex(&bind.CallOpts{Context: ctx})
+			if err != nil {
+				util.Exit(1, "Failed to fetch next index, chain-id: %d, contract: %s: %s\n",
+					cfg.ChainID, contract.Hex(), err)
+			}
+			if nextIndex <= retention {
+				fmt.Printf("Skipped chain-id: %d, contract: %s, next-index: %d\n",
+					cfg.ChainID, contract.Hex(), nextIndex)
+				continue
+			}
+			deleteIndex := nextIndex - retention - 1
+
+			sigs, err := deleteRepeatedly(func() (int64, error) {
+				return db.OPSignature.DeleteOlds(contract, deleteIndex, database.DeleteOldsLimit)
+			})
+			if err != nil {
+				util.Exit(1, "Failed to delete signatures, contract: %s: %s\n", contract.Hex(), err)
+			}
+			events, err := deleteRepeatedly(func() (int64, error) {
+				return verse.EventDB().DeleteOlds(contract, deleteIndex, database.DeleteOldsLimit)
+			})
+			if err != nil {
+				util.Exit(1, "Failed to delete events, contract: %s: %s\n", contract.Hex(), err)
+			}
+
+			fmt.Printf("Pruned chain-id: %d, contract: %s, next-index: %d, "+
+				"deleted-index: %d, signatures: %d, events: %d\n",
+				cfg.ChainID, contract.Hex(), nextIndex, deleteIndex, sigs, events)
+		}
+	}
+}
+
+// Call the delete function until the number of deleted records falls below the limit.
+func deleteRepeatedly(fn func() (int64, error)) (total int64, err error) {
+	for {
+		affected, err := fn()
+		if err != nil {
+			return total, err
+		}
+		total += affected
+		if affected < database.DeleteOldsLimit {
+			return total, nil
+		}
+	}
+}
+
+func runDBCheckCmd(cmd *cobra.Command, args []string) {
+	conf, db := mustOpenDatabase()
+	hubChainID := new(big.Int).SetUint64(conf.HubLayer.ChainID)
+
+	// verify the signatures
+	var checked, invalids int
+	for offset := 0; ; offset += checkBatchSize {
+		rows, err := db.OPSignature.Find(nil, nil, nil, nil, checkBatchSize, offset)
+		if err != nil {
+			util.Exit(1, "Failed to find signatures: %s\n", err)
+		}
+
+		for _, row := range rows {
+			err := ethutil.VerifySignature(hubChainID, row.Contract.Address,
+				new(big.Int).SetUint64(row.RollupIndex), row.RollupHash, row.Approved,
+				row.Signature[:], row.Signer.Address)
+			if err != nil {
+				invalids++
+				fmt.Printf("Invalid signature id: %s, signer: %s, contract: %s, index: %d: %s\n",
+					row.ID, row.Signer.Address.Hex(), row.Contract.Address.Hex(), row.RollupIndex, err)
+			}
+		}
+		checked += len(rows)
+
+		if len(rows) < checkBatchSize {
+			break
+		}
+	}
+	fmt.Printf("Verified %d signatures, invalid: %d\n", checked, invalids)
+
+	// repair the previous ids
+	orphans := mustFindOrphans(db)
+	fmt.Printf("Found %d signatures whose previous signature does not exist\n", len(orphans))
+
+	signers, err := db.Signer.FindAll()
+	if err != nil {
+		util.Exit(1, "Failed to find signers: %s\n", err)
+	}
+	for _, signer := range signers {
+		db.OPSignature.RepairPreviousID(signer.Address)
+	}
+
+	// report the orphans could not be repaired
+	orphans = mustFindOrphans(db)
+	for _, row := range orphans {
+		fmt.Printf("Orphaned signature id: %s, previous-id: %s, signer: %s, contract: %s, index: %d\n",
+			row.ID, row.PreviousID, row.Signer.Address.Hex(), row.Contract.Address.Hex(), row.RollupIndex)
+	}
+	fmt.Printf("Repaired previous ids, remaining orphans: %d\n", len(orphans))
+
+	if invalids > 0 || len(orphans) > 0 {
+		util.Exit(1, "")
+	}
+}
+
+func mustFindOrphans(db *database.Database) []*database.OptimismSignature {
+	rows, err := db.OPSignature.FindOrphans(checkBatchSize)
+	if err != nil {
+		util.Exit(1, "Failed to find orphaned signatures: %s\n", err)
+	}
+	return rows
+}
@@ -0,0 +1,102 @@
+package ipccmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/submitter"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+	"github.com/oasysgames/oasys-optimism-verifier/verse"
+)
+
+var (
+	BundleExportCmd = &bundleExport{}
+	BundleImportCmd = &bundleImport{}
+)
+
+type bundleExport struct{}
+
+type bundleImport struct{}
+
+// Methods of the `submitter` namespace.
+type SubmitterAPI struct {
+	cfg             func() *config.Submitter // returns the current configuration
+	db              *database.Database
+	smcache         *stakemanager.Cache
+	hubLayerChainID *big.Int
+	versepool       verse.VersePool
+}
+
+func NewSubmitterAPI(
+	cfg func() *config.Submitter,
+	db *database.Database,
+	smcache *stakemanager.Cache,
+	hubLayerChainID uint64,
+	versepool verse.VersePool,
+) *SubmitterAPI {
+	return &SubmitterAPI{
+		cfg:             cfg,
+		db:              db,
+		smcache:         smcache,
+		hubLayerChainID: new(big.Int).SetUint64(hubLayerChainID),
+		versepool:       versepool,
+	}
+}
+
+// Returns the signature bundle of the rollup built from the local signatures.
+func (api *SubmitterAPI) ExportBundle(
+	ctx context.Context,
+	contract common.Address,
+	rollupIndex uint64,
+) (*submitter.Bundle, error) {
+	item, ok := api.versepool.Get(contract)
+	if !ok {
+		return nil, fmt.Errorf("verse not found: %s", contract)
+	}
+
+	bundle, err := submitter.NewBundle(ctx, api.cfg(), api.db, api.smcache,
+		api.hubLayerChainID, item.Verse(), rollupIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build bundle: %w", err)
+	}
+	return bundle, nil
+}
+
+// Saves the signatures in the bundle, and returns the number of newly saved signatures.
+func (api *SubmitterAPI) ImportBundle(ctx context.Context, bundle *submitter.Bundle) (int, error) {
+	imported, err := submitter.ImportBundle(ctx, api.db, api.smcache, api.hubLayerChainID, bundle)
+	if err != nil {
+		return 0, fmt.Errorf("failed to import bundle: %w", err)
+	}
+	return imported, nil
+}
+
+// Returns the indented bundle JSON.
+func (c *bundleExport) Run(path, contract string, rollupIndex uint64) []byte {
+	res := callRaw(path, SubmitterNamespace+"_exportBundle",
+		common.HexToAddress(contract), rollupIndex)
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, res, "", "  "); err != nil {
+		util.Exit(1, "failed to format bundle: %s\n", err)
+	}
+	return buf.Bytes()
+}
+
+func (c *bundleImport) Run(path string, data []byte) {
+	var bundle submitter.Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		util.Exit(1, "failed to unmarshal bundle: %s\n", err)
+	}
+
+	var imported int
+	call(path, &imported, SubmitterNamespace+"_importBundle", &bundle)
+	fmt.Printf("imported %d of %d signatures\n", imported, len(bundle.Signatures))
+}
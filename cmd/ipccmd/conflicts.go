@@ -0,0 +1,111 @@
+package ipccmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+)
+
+var ConflictsCmd = &conflicts{}
+
+type conflicts struct{}
+
+type equivocationJSON struct {
+	Signer      common.Address `json:"signer"`
+	Contract    common.Address `json:"contract"`
+	RollupIndex uint64         `json:"rollup_index"`
+	First       voteJSON       `json:"first"`
+	Second      voteJSON       `json:"second"`
+	Confirmed   bool           `json:"confirmed"`
+	DetectedAt  time.Time      `json:"detected_at"`
+}
+
+type voteJSON struct {
+	RollupHash common.Hash `json:"rollup_hash"`
+	Approved   bool        `json:"approved"`
+	Signature  string      `json:"signature"`
+}
+
+type voteConflictJSON struct {
+	Contract    common.Address `json:"contract"`
+	RollupIndex uint64         `json:"rollup_index"`
+	RollupHash  common.Hash    `json:"rollup_hash"`
+	Approved    bool           `json:"approved"`
+	Stake       string         `json:"stake"`
+	Signers     uint64         `json:"signers"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// Methods of the `verifier` namespace.
+type VerifierAPI struct {
+	db *database.Database
+}
+
+func NewVerifierAPI(db *database.Database) *VerifierAPI {
+	return &VerifierAPI{db: db}
+}
+
+type Conflicts struct {
+	Equivocations []*equivocationJSON `json:"equivocations"`
+	VoteConflicts []*voteConflictJSON `json:"vote_conflicts"`
+}
+
+// Returns the detected equivocations and the conflicting votes,
+// filtered by the rollup contract if specified.
+func (api *VerifierAPI) Conflicts(contract *common.Address, limit int) (*Conflicts, error) {
+	equivocations, err := api.db.Conflict.FindEquivocations(nil, contract, limit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find equivocations: %w", err)
+	}
+	voteConflicts, err := api.db.Conflict.FindVoteConflicts(contract, nil, limit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find vote conflicts: %w", err)
+	}
+
+	res := &Conflicts{
+		Equivocations: make([]*equivocationJSON, len(equivocations)),
+		VoteConflicts: make([]*voteConflictJSON, len(voteConflicts)),
+	}
+	for i, row := range equivocations {
+		res.Equivocations[i] = &equivocationJSON{
+			Signer:      row.Signer.Address,
+			Contract:    row.Contract.Address,
+			RollupIndex: row.RollupIndex,
+			First: voteJSON{
+				RollupHash: row.FirstRollupHash,
+				Approved:   row.FirstApproved,
+				Signature:  row.FirstSignature.Hex(),
+			},
+			Second: voteJSON{
+				RollupHash: row.SecondRollupHash,
+				Approved:   row.SecondApproved,
+				Signature:  row.SecondSignature.Hex(),
+			},
+			Confirmed:  row.Confirmed,
+			DetectedAt: row.CreatedAt,
+		}
+	}
+	for i, row := range voteConflicts {
+		res.VoteConflicts[i] = &voteConflictJSON{
+			Contract:    row.Contract.Address,
+			RollupIndex: row.RollupIndex,
+			RollupHash:  row.RollupHash,
+			Approved:    row.Approved,
+			Stake:       row.Stake,
+			Signers:     row.Signers,
+			UpdatedAt:   row.UpdatedAt,
+		}
+	}
+	return res, nil
+}
+
+func (c *conflicts) Run(path, contract string, limit int) {
+	var addr *common.Address
+	if contract != "" {
+		a := common.HexToAddress(contract)
+		addr = &a
+	}
+	fmt.Println(string(callRaw(path, VerifierNamespace+"_conflicts", addr, limit)))
+}
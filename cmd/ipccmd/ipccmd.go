@@ -1,8 +1,41 @@
 package ipccmd
 
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/oasysgames/oasys-optimism-verifier/ipc"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+)
+
+// Namespaces of the admin API, the methods are called as `{namespace}_{method}`.
 const (
-	NOOP = iota + 10
-	WALLET_UNLOCK
-	PING
-	STATUS
+	AdminNamespace     = "admin"
+	P2PNamespace       = "p2p"
+	VerifierNamespace  = "verifier"
+	SubmitterNamespace = "submitter"
+	WalletNamespace    = "wallet"
 )
+
+// Call the method of the admin API served by the running node,
+// exits if the node is not reachable or responds with an error.
+func call(path string, result interface{}, method string, args ...interface{}) {
+	ctx := context.Background()
+
+	cl, err := ipc.Dial(ctx, path)
+	if err != nil {
+		util.Exit(1, "connection failure: %s\n", err)
+	}
+	defer cl.Close()
+
+	if err := cl.CallContext(ctx, result, method, args...); err != nil {
+		util.Exit(1, "%s\n", err)
+	}
+}
+
+// Same as `call`, but returns the result as raw JSON.
+func callRaw(path string, method string, args ...interface{}) json.RawMessage {
+	var res json.RawMessage
+	call(path, &res, method, args...)
+	return res
+}
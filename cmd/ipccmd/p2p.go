@@ -0,0 +1,51 @@
+package ipccmd
+
+import (
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/oasysgames/oasys-optimism-verifier/p2p"
+)
+
+// Methods of the `p2p` namespace.
+type P2PAPI struct {
+	h             host.Host
+	hpHelper      p2p.HolePunchHelper // nil if hole punching is not supported
+	verifiedPeers func() []*p2p.VerifiedPeer
+	nodeInfo      func() *p2p.NodeInfo
+	blockedPeers  func() []*p2p.BlockedPeer
+}
+
+func NewP2PAPI(
+	h host.Host,
+	hpHelper p2p.HolePunchHelper,
+	verifiedPeers func() []*p2p.VerifiedPeer,
+	nodeInfo func() *p2p.NodeInfo,
+	blockedPeers func() []*p2p.BlockedPeer,
+) *P2PAPI {
+	return &P2PAPI{
+		h:             h,
+		hpHelper:      hpHelper,
+		verifiedPeers: verifiedPeers,
+		nodeInfo:      nodeInfo,
+		blockedPeers:  blockedPeers,
+	}
+}
+
+// Returns the status of the libp2p host.
+func (api *P2PAPI) Status() (*p2p.HostStatus, error) {
+	return p2p.NewHostStatus(api.h)
+}
+
+// Returns the node info of this node.
+func (api *P2PAPI) NodeInfo() *p2p.NodeInfo {
+	return api.nodeInfo()
+}
+
+// Returns the peers verified as validators.
+func (api *P2PAPI) VerifiedPeers() []*p2p.VerifiedPeer {
+	return api.verifiedPeers()
+}
+
+// Returns the peers blocked due to misbehaviour.
+func (api *P2PAPI) BlockedPeers() []*p2p.BlockedPeer {
+	return api.blockedPeers()
+}
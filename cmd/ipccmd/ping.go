@@ -2,119 +2,73 @@ package ipccmd
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"time"
 
-	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	p2pping "github.com/libp2p/go-libp2p/p2p/protocol/ping"
-	"github.com/oasysgames/oasys-optimism-verifier/ipc"
 	"github.com/oasysgames/oasys-optimism-verifier/p2p"
-	"github.com/oasysgames/oasys-optimism-verifier/util"
 )
 
-var PingCmd = &ping{handlerID: PING, attempts: 10}
+const pingAttempts = 10
 
-type ping struct {
-	handlerID int
-	attempts  int
-}
+var PingCmd = &ping{}
 
-type pingMsg struct {
-	Remote         string
-	ForceHolePunch bool
-}
+type ping struct{}
 
-func (c *ping) Run(ctx context.Context, sockname, remote string, forceHolePunch bool) {
-	// attach to ipc
-	cl, err := ipc.NewClient(sockname, c.handlerID)
+// Send pings to the peer every second, and returns the results.
+func (api *P2PAPI) Ping(ctx context.Context, remote string, forceHolePunch bool) ([]string, error) {
+	peerID, err := peer.Decode(remote)
 	if err != nil {
-		util.Exit(1, "connection failure: %s\n", err)
+		return nil, fmt.Errorf("failed to decode peer id: %w", err)
 	}
-	defer cl.Close()
 
-	// send ipc message
-	msg, err := json.Marshal(&pingMsg{remote, forceHolePunch})
-	if err == nil {
-		err = cl.Write(msg)
-	}
-	if err != nil {
-		util.Exit(1, "failed to write ipc message: %s\n", err)
-	}
-
-	// start read loop
-	for {
-		data, err := cl.Read()
-		if errors.Is(err, io.EOF) {
-			return
-		} else if err != nil {
-			util.Exit(1, "failed to read ipc message: %s\n", err)
-		} else {
-			fmt.Println(string(data))
+	var results []string
+	if forceHolePunch {
+		if api.hpHelper == nil || !api.hpHelper.Available(api.h) {
+			return nil, errors.New("hole punch is not available on this node")
+		}
+		if err := <-api.hpHelper.HolePunch(ctx, api.h, peerID, p2p.DefaultHolePunchTimeout); err != nil {
+			return nil, fmt.Errorf("hole punch failed: %w", err)
 		}
+		results = append(results, "hole punch successful")
 	}
-}
 
-func (c *ping) NewHandler(ctx context.Context, h host.Host, hpHelper p2p.HolePunchHelper) (handlerID int, handler ipc.Handler) {
-	return c.handlerID, func(s *ipc.IPCServer, data []byte) {
-		defer s.Write(ipc.EOM, nil)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-		var msg pingMsg
-		if err := json.Unmarshal(data, &msg); err != nil {
-			s.Write(c.handlerID, []byte(fmt.Sprintf("failed to unmarshal ipc message: %s", err)))
-			return
-		}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
 
-		peerID, err := peer.Decode(msg.Remote)
-		if err != nil {
-			s.Write(c.handlerID, []byte(fmt.Sprintf("failed to decode peer id: %s", err)))
-			return
+	pings := p2pping.Ping(ctx, api.h, peerID)
+	for i := 0; i < pingAttempts; i++ {
+		r, ok := <-pings
+		if !ok {
+			break
+		} else if r.Error != nil {
+			results = append(results, fmt.Sprintf("error: %s", r.Error))
+			break
 		}
+		results = append(results, fmt.Sprintf("pong received: time=%s", r.RTT))
 
-		ctx, cancel := context.WithCancel(ctx)
-		defer cancel()
-
-		if msg.ForceHolePunch {
-			if !hpHelper.Available(h) {
-				s.Write(c.handlerID, []byte("hole punch is not available on this node"))
-				return
-			}
-
-			s.Write(c.handlerID, []byte("waiting for hole punch"))
-			err := <-hpHelper.HolePunch(ctx, h, peerID, p2p.DefaultHolePunchTimeout)
-			if err != nil {
-				s.Write(c.handlerID, []byte(fmt.Sprintf("hole punch failed: %s", err)))
-				return
-			}
-			s.Write(c.handlerID, []byte("hole punch successful"))
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return results, ctx.Err()
 		}
+	}
+	return results, nil
+}
 
-		ticker := time.NewTicker(time.Second)
-		defer ticker.Stop()
-
-		pings := p2pping.Ping(ctx, h, peerID)
-		for i := 0; i < c.attempts; i++ {
-			r, ok := <-pings
-			if !ok {
-				return
-			} else if r.Error != nil {
-				s.Write(c.handlerID, []byte(fmt.Sprintf("error: %s", r.Error)))
-				return
-			}
-
-			data = []byte(fmt.Sprintf("pong received: time=%s", r.RTT))
-			if err = s.Write(c.handlerID, data); err != nil {
-				return
-			}
+func (c *ping) Run(path, remote string, forceHolePunch bool) {
+	if forceHolePunch {
+		fmt.Println("waiting for hole punch")
+	}
 
-			select {
-			case <-ticker.C:
-			case <-ctx.Done():
-				return
-			}
-		}
+	var results []string
+	call(path, &results, P2PNamespace+"_ping", remote, forceHolePunch)
+	for _, r := range results {
+		fmt.Println(r)
 	}
 }
@@ -0,0 +1,35 @@
+package ipccmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+var ReloadCmd = &reload{}
+
+type reload struct{}
+
+// Reloads the configuration file and applies the changes to the running node.
+// Returns an error without applying anything if any change requires a restart.
+func (api *AdminAPI) Reload(ctx context.Context) ([]string, error) {
+	changed, err := api.reload(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if changed == nil {
+		changed = []string{}
+	}
+	return changed, nil
+}
+
+func (c *reload) Run(path string) {
+	var changed []string
+	call(path, &changed, AdminNamespace+"_reload")
+
+	if len(changed) == 0 {
+		fmt.Println("No changes")
+		return
+	}
+	fmt.Printf("Reloaded: %s\n", strings.Join(changed, ", "))
+}
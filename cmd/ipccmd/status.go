@@ -1,72 +1,50 @@
 package ipccmd
 
 import (
-	"bytes"
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
-	"io"
 
-	"github.com/libp2p/go-libp2p/core/host"
-	"github.com/oasysgames/oasys-optimism-verifier/ipc"
 	"github.com/oasysgames/oasys-optimism-verifier/p2p"
-	"github.com/oasysgames/oasys-optimism-verifier/util"
 )
 
-var StatusCmd = &status{handlerID: STATUS}
+var StatusCmd = &status{}
 
-type status struct {
-	handlerID int
-}
+type status struct{}
 
-func (c *status) NewHandler(h host.Host) (handlerID int, handler ipc.Handler) {
-	type status struct {
-		P2P *p2p.HostStatus `json:"p2p"`
-	}
+// Methods of the `admin` namespace.
+type AdminAPI struct {
+	p2p    *P2PAPI
+	reload ReloadFn
+}
 
-	return c.handlerID, func(s *ipc.IPCServer, _ []byte) {
-		defer s.Write(ipc.EOM, nil)
+// Reloads the configuration file, and returns the changed fields.
+type ReloadFn func(ctx context.Context) ([]string, error)
 
-		p2pStatus, err := p2p.NewHostStatus(h)
-		if err != nil {
-			s.Write(c.handlerID, []byte(fmt.Sprintf("failed to get p2p status: %s", err)))
-			return
-		}
+func NewAdminAPI(p2p *P2PAPI, reload ReloadFn) *AdminAPI {
+	return &AdminAPI{p2p: p2p, reload: reload}
+}
 
-		st := &status{P2P: p2pStatus}
-		if data, err := json.Marshal(st); err != nil {
-			s.Write(c.handlerID, []byte(fmt.Sprintf("failed to marshal status: %s", err)))
-		} else {
-			s.ChunkedWrite(c.handlerID, data)
-		}
-	}
+type Status struct {
+	P2P           *p2p.HostStatus     `json:"p2p"`
+	NodeInfo      *p2p.NodeInfo       `json:"node_info,omitempty"`
+	VerifiedPeers []*p2p.VerifiedPeer `json:"verified_peers"`
+	BlockedPeers  []*p2p.BlockedPeer  `json:"blocked_peers"`
 }
 
-func (c *status) Run(sockname string) {
-	// attach to ipc
-	cl, err := ipc.NewClient(sockname, c.handlerID)
+// Returns the status of the node.
+func (api *AdminAPI) Status() (*Status, error) {
+	p2pStatus, err := api.p2p.Status()
 	if err != nil {
-		util.Exit(1, "connection failure: %s\n", err)
-	}
-	defer cl.Close()
-
-	// send message
-	if err = cl.Write(nil); err != nil {
-		util.Exit(1, "failed to write ipc message: %s\n", err)
-	}
-
-	// read message
-	var chunks [][]byte
-	for {
-		data, err := cl.Read()
-		if errors.Is(err, io.EOF) {
-			break
-		} else if err != nil {
-			util.Exit(1, "failed to read ipc message: %s\n", err)
-		} else {
-			chunks = append(chunks, data)
-		}
+		return nil, err
 	}
+	return &Status{
+		P2P:           p2pStatus,
+		NodeInfo:      api.p2p.NodeInfo(),
+		VerifiedPeers: api.p2p.VerifiedPeers(),
+		BlockedPeers:  api.p2p.BlockedPeers(),
+	}, nil
+}
 
-	fmt.Println(string(bytes.Join(chunks, nil)))
+func (c *status) Run(path string) {
+	fmt.Println(string(callRaw(path, AdminNamespace+"_status")))
 }
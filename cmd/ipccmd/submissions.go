@@ -0,0 +1,121 @@
+package ipccmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+)
+
+var SubmissionsCmd = &submissions{}
+
+type submissions struct{}
+
+type submissionJSON struct {
+	TxHash         common.Hash      `json:"tx_hash"`
+	Nonce          uint64           `json:"nonce"`
+	ChainID        uint64           `json:"chain_id"`
+	Contract       common.Address   `json:"contract"`
+	FirstIndex     uint64           `json:"first_index"`
+	LastIndex      uint64           `json:"last_index"`
+	Signers        []common.Address `json:"signers"`
+	GasLimit       uint64           `json:"gas_limit"`
+	GasUsed        uint64           `json:"gas_used"`
+	EffectivePrice string           `json:"effective_price"`
+	Status         uint64           `json:"status"`
+	RevertReason   string           `json:"revert_reason"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+// Returns the verify transactions sent since the time, filtered by the chain ID if specified.
+func (api *SubmitterAPI) Submissions(chainID *uint64, since time.Time, limit int) ([]*submissionJSON, error) {
+	rows, err := api.db.Submission.Find(chainID, since, limit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find submissions: %w", err)
+	}
+
+	res := make([]*submissionJSON, len(rows))
+	for i, row := range rows {
+		res[i] = &submissionJSON{
+			TxHash:         row.TxHash,
+			Nonce:          row.Nonce,
+			ChainID:        row.ChainID,
+			Contract:       row.Contract.Address,
+			FirstIndex:     row.FirstIndex,
+			LastIndex:      row.LastIndex,
+			Signers:        []common.Address{},
+			GasLimit:       row.GasLimit,
+			GasUsed:        row.GasUsed,
+			EffectivePrice: row.EffectivePrice,
+			Status:         row.Status,
+			RevertReason:   row.RevertReason,
+			CreatedAt:      row.CreatedAt,
+		}
+		if row.Signers != "" {
+			for _, signer := range strings.Split(row.Signers, ",") {
+				res[i].Signers = append(res[i].Signers, common.HexToAddress(signer))
+			}
+		}
+	}
+	return res, nil
+}
+
+func (c *submissions) Run(path string, chainID uint64, since time.Time, limit int, asCSV bool) {
+	var filter *uint64
+	if chainID != 0 {
+		filter = &chainID
+	}
+
+	data := callRaw(path, SubmitterNamespace+"_submissions", filter, since, limit)
+	if !asCSV {
+		fmt.Println(string(data))
+		return
+	}
+
+	var rows []*submissionJSON
+	if err := json.Unmarshal(data, &rows); err != nil {
+		util.Exit(1, "failed to unmarshal submissions: %s\n", err)
+	}
+
+	if err := writeSubmissionsCSV(os.Stdout, rows); err != nil {
+		util.Exit(1, "failed to write csv: %s\n", err)
+	}
+}
+
+func writeSubmissionsCSV(out io.Writer, rows []*submissionJSON) error {
+	w := csv.NewWriter(out)
+	w.Write([]string{
+		"tx_hash", "nonce", "chain_id", "contract", "first_index", "last_index", "signers",
+		"gas_limit", "gas_used", "effective_price", "status", "revert_reason", "created_at",
+	})
+	for _, row := range rows {
+		signers := make([]string, len(row.Signers))
+		for i, signer := range row.Signers {
+			signers[i] = signer.Hex()
+		}
+		w.Write([]string{
+			row.TxHash.Hex(),
+			strconv.FormatUint(row.Nonce, 10),
+			strconv.FormatUint(row.ChainID, 10),
+			row.Contract.Hex(),
+			strconv.FormatUint(row.FirstIndex, 10),
+			strconv.FormatUint(row.LastIndex, 10),
+			strings.Join(signers, " "),
+			strconv.FormatUint(row.GasLimit, 10),
+			strconv.FormatUint(row.GasUsed, 10),
+			row.EffectivePrice,
+			strconv.FormatUint(row.Status, 10),
+			row.RevertReason,
+			row.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
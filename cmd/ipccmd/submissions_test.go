@@ -0,0 +1,74 @@
+package ipccmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type SubmissionsTestSuite struct {
+	suite.Suite
+}
+
+func TestSubmissions(t *testing.T) {
+	suite.Run(t, new(SubmissionsTestSuite))
+}
+
+func (s *SubmissionsTestSuite) TestWriteSubmissionsCSV() {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := []*submissionJSON{
+		{
+			TxHash:         common.HexToHash("0x01"),
+			Nonce:          1,
+			ChainID:        12345,
+			Contract:       common.HexToAddress("0x02"),
+			FirstIndex:     3,
+			LastIndex:      5,
+			Signers:        []common.Address{common.HexToAddress("0x03"), common.HexToAddress("0x04")},
+			GasLimit:       200000,
+			GasUsed:        150000,
+			EffectivePrice: "1000000000",
+			Status:         0,
+			RevertReason:   `Invalid "batch", index.`,
+			CreatedAt:      createdAt,
+		},
+		{
+			TxHash:   common.HexToHash("0x05"),
+			Contract: common.HexToAddress("0x06"),
+			Signers:  []common.Address{},
+			Status:   2,
+		},
+	}
+
+	var buf bytes.Buffer
+	s.NoError(writeSubmissionsCSV(&buf, rows))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	s.NoError(err)
+	s.Len(records, 3)
+	s.Equal([]string{
+		"tx_hash", "nonce", "chain_id", "contract", "first_index", "last_index", "signers",
+		"gas_limit", "gas_used", "effective_price", "status", "revert_reason", "created_at",
+	}, records[0])
+	s.Equal([]string{
+		common.HexToHash("0x01").Hex(),
+		"1",
+		"12345",
+		common.HexToAddress("0x02").Hex(),
+		"3",
+		"5",
+		common.HexToAddress("0x03").Hex() + " " + common.HexToAddress("0x04").Hex(),
+		"200000",
+		"150000",
+		"1000000000",
+		"0",
+		`Invalid "batch", index.`,
+		"2024-01-02T03:04:05Z",
+	}, records[1])
+	s.Equal("", records[2][6])
+	s.Equal("2", records[2][10])
+}
@@ -1,85 +1,35 @@
 package ipccmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/oasysgames/oasys-optimism-verifier/ipc"
-	"github.com/oasysgames/oasys-optimism-verifier/util"
 	"github.com/oasysgames/oasys-optimism-verifier/wallet"
 )
 
-var WalletUnlockCmd = &walletUnlock{handlerID: WALLET_UNLOCK}
+var WalletUnlockCmd = &walletUnlock{}
 
-type walletUnlock struct {
-	handlerID int
-}
+type walletUnlock struct{}
 
-type walletUnlockMsg struct {
-	Address  string
-	Password string
+// Methods of the `wallet` namespace.
+type WalletAPI struct {
+	ks *wallet.KeyStore
 }
 
-func (c *walletUnlock) Run(sockname, address, password string) {
-	// attach to ipc
-	s, err := ipc.NewClient(sockname, c.handlerID)
-	if err != nil {
-		util.Exit(1, "connection failure: %s\n", err)
-	}
-
-	// start read loop
-	wg := &sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		c.read(s)
-	}()
-
-	// send message
-	data, err := json.Marshal(&walletUnlockMsg{address, password})
-	if err != nil {
-		util.Exit(1, "failed to create message: %s\n", err)
-	}
-	err = s.Write(data)
-	if err != nil {
-		util.Exit(1, "failed to write message: %s\n", err)
-	}
-
-	wg.Wait()
+func NewWalletAPI(ks *wallet.KeyStore) *WalletAPI {
+	return &WalletAPI{ks: ks}
 }
 
-func (c *walletUnlock) read(s *ipc.Client) {
-	data, err := s.Read()
+// Unlock the wallet of the keystore.
+func (api *WalletAPI) Unlock(address common.Address, password string) error {
+	_, account, err := api.ks.FindWallet(address)
 	if err != nil {
-		util.Exit(1, "failed to read message: %s\n", err)
+		return err
 	}
-
-	fmt.Println(string(data))
+	return api.ks.Unlock(*account, password)
 }
 
-func (c *walletUnlock) NewHandler(ks *wallet.KeyStore) (int, ipc.Handler) {
-	return c.handlerID, func(s *ipc.IPCServer, data []byte) {
-		var m walletUnlockMsg
-		err := json.Unmarshal(data, &m)
-		if err != nil {
-			s.Write(c.handlerID, []byte(err.Error()))
-			return
-		}
-
-		_, account, err := ks.FindWallet(common.HexToAddress(m.Address))
-		if err != nil {
-			s.Write(c.handlerID, []byte(err.Error()))
-			return
-		}
-
-		err = ks.Unlock(*account, m.Password)
-		if err != nil {
-			s.Write(c.handlerID, []byte(err.Error()))
-			return
-		}
-
-		s.Write(c.handlerID, []byte("success!"))
-	}
+func (c *walletUnlock) Run(path, address, password string) {
+	call(path, nil, WalletNamespace+"_unlock", common.HexToAddress(address), password)
+	fmt.Println("success!")
 }
@@ -31,7 +31,7 @@ var pingCmd = &cobra.Command{
 			util.Exit(1, "Failed to read '%s' argument: %s\n", forceHolePunchFlag, err)
 		}
 
-		ipccmd.PingCmd.Run(cmd.Context(), conf.IPC.Sockname, peerID, holePunch)
+		ipccmd.PingCmd.Run(conf.IPCPath(), peerID, holePunch)
 	},
 }
 
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/oasysgames/oasys-optimism-verifier/cmd/ipccmd"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	"github.com/oasysgames/oasys-optimism-verifier/logger"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+	"github.com/oasysgames/oasys-optimism-verifier/verse"
+	"github.com/spf13/cobra"
+)
+
+var reloadCmd = &cobra.Command{
+	Use:   "reload",
+	Short: "Reload the configuration of the running node",
+	Long: "Reload the configuration file and apply the changes of the verse_layer.directs, " +
+		"the submitter targets and wallets, the verifier and submitter tuning and the log level " +
+		"without restarting. Same as sending SIGHUP to the node",
+	Run: func(cmd *cobra.Command, args []string) {
+		// only the ipc path is used, so the bootnode configuration is also accepted
+		conf, err := globalConfigLoader.loadBootnode()
+		if err != nil {
+			util.Exit(1, "Failed to load configuration: %s\n", err)
+		}
+		ipccmd.ReloadCmd.Run(conf.IPCPath())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reloadCmd)
+}
+
+// Reload the configuration on SIGHUP.
+func (s *server) startReloadHandler(ctx context.Context, hupC <-chan os.Signal) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info("Reload handler stopped")
+				return
+			case sig := <-hupC:
+				log.Info("Received signal, reloading configuration...", "signal", sig)
+				if _, err := s.reload(ctx); err != nil {
+					log.Error("Failed to reload configuration", "err", err)
+				}
+			}
+		}
+	}()
+}
+
+// Reload the configuration file and apply the changes to the running node.
+// Returns the changed fields, or an error without applying anything
+// if any of the changed fields requires a restart.
+func (s *server) reload(ctx context.Context) ([]string, error) {
+	if !s.ready.Load() {
+		return nil, errors.New("node is starting")
+	}
+	if globalConfigLoader.fromCli {
+		return nil, errors.New("configuration from command line arguments cannot be reloaded")
+	}
+
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	conf, err := globalConfigLoader.load(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if conf.Database.Path == "" {
+		conf.Database.Path = conf.DatabasePath()
+	}
+
+	s.mu.Lock()
+	prev, prevSigners := s.conf, s.signers
+	s.mu.Unlock()
+
+	changed, err := config.CheckReloadable(prev, conf)
+	if err != nil {
+		return nil, err
+	} else if len(changed) == 0 {
+		log.Info("Configuration has not changed")
+		return nil, nil
+	}
+
+	lvl, err := logger.ParseLevel(conf.Log.Level)
+	if err != nil {
+		return nil, err
+	}
+	signers, err := s.reloadSigners(ctx, conf, prevSigners)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.conf, s.signers = conf, signers
+	verses := s.verses
+	s.mu.Unlock()
+
+	logger.SetLevel(lvl)
+	if s.verifier != nil {
+		s.verifier.SetConfig(&conf.Verifier)
+	}
+	if s.submitter != nil {
+		s.submitter.SetConfig(&conf.Submitter)
+
+		// The tasks hold the wallet, so drop them to use the new wallet.
+		chainIDs := changedTargets(&prev.Submitter, &conf.Submitter)
+		s.versepool.Range(func(item *verse.VersePoolItem) bool {
+			if slices.Contains(chainIDs, item.Verse().ChainID()) {
+				s.submitter.RemoveTask(item.Verse().RollupContract())
+			}
+			return true
+		})
+	}
+
+	// Apply the verses again for the changes of the submitter targets,
+	// the discovered verses take precedence if the discovery is enabled.
+	if conf.VerseLayer.Discovery.Endpoint == "" {
+		verses = conf.VerseLayer.Directs
+	}
+	s.verseDiscoveryHandler(ctx, verses)
+
+	log.Info("Reloaded configuration", "changed", changed)
+	return changed, nil
+}
+
+// Returns the signers of the reloaded configuration. The added wallets
+// must be unlockable without waiting, as the node is already running.
+func (s *server) reloadSigners(
+	ctx context.Context,
+	conf *config.Config,
+	prev map[string]ethutil.Signer,
+) (map[string]ethutil.Signer, error) {
+	// the wallets in use must exist
+	if _, ok := conf.Wallets[conf.Verifier.Wallet]; conf.Verifier.Enable && !ok {
+		return nil, fmt.Errorf("wallet %q of the verifier is not found", conf.Verifier.Wallet)
+	}
+	for _, target := range conf.Submitter.Targets {
+		if _, ok := conf.Wallets[target.Wallet]; conf.Submitter.Enable && !ok {
+			return nil, fmt.Errorf("wallet %q of the submitter target %d is not found",
+				target.Wallet, target.ChainID)
+		}
+	}
+
+	signers := map[string]ethutil.Signer{}
+	for name, wallet := range conf.Wallets {
+		if signer, ok := prev[name]; ok {
+			signers[name] = signer
+			continue
+		}
+
+		signer, err := s.newSigner(ctx, name, wallet, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load wallet %q: %w", name, err)
+		}
+		signers[name] = signer
+	}
+	return signers, nil
+}
+
+// Returns the chain ids of the submitter targets whose wallet is changed, added or removed.
+func changedTargets(prev, curr *config.Submitter) (chainIDs []uint64) {
+	wallets := func(cfg *config.Submitter) map[uint64]string {
+		m := map[uint64]string{}
+		for _, target := range cfg.Targets {
+			m[target.ChainID] = target.Wallet
+		}
+		return m
+	}
+
+	prevs, currs := wallets(prev), wallets(curr)
+	for chainID, wallet := range prevs {
+		if currs[chainID] != wallet {
+			chainIDs = append(chainIDs, chainID)
+		}
+	}
+	for chainID := range currs {
+		if _, ok := prevs[chainID]; !ok {
+			chainIDs = append(chainIDs, chainID)
+		}
+	}
+	return chainIDs
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReloadTestSuite struct {
+	suite.Suite
+}
+
+func TestReload(t *testing.T) {
+	suite.Run(t, new(ReloadTestSuite))
+}
+
+func (s *ReloadTestSuite) newWallet() *config.Wallet {
+	priv, _ := ethcrypto.GenerateKey()
+	return &config.Wallet{
+		Address: ethcrypto.PubkeyToAddress(priv.PublicKey).Hex(),
+		Plain:   hexutil.Encode(ethcrypto.FromECDSA(priv)),
+	}
+}
+
+func (s *ReloadTestSuite) TestReloadSigners() {
+	ctx := context.Background()
+	srv := &server{}
+
+	wallet1, wallet2 := s.newWallet(), s.newWallet()
+	prevSigner, err := srv.newSigner(ctx, "wallet1", wallet1, false)
+	s.Require().NoError(err)
+	prev := map[string]ethutil.Signer{"wallet1": prevSigner}
+
+	conf := &config.Config{Wallets: map[string]*config.Wallet{"wallet1": wallet1, "wallet2": wallet2}}
+	conf.Verifier.Enable = true
+	conf.Verifier.Wallet = "wallet1"
+	conf.Submitter.Enable = true
+	conf.Submitter.Targets = []*config.SubmitterTarget{{ChainID: 1, Wallet: "wallet2"}}
+
+	// the loaded signer is kept and the added wallet is loaded
+	got, err := srv.reloadSigners(ctx, conf, prev)
+	s.NoError(err)
+	s.Len(got, 2)
+	s.Same(prevSigner, got["wallet1"])
+	s.Equal(wallet2.Address, got["wallet2"].From().Hex())
+
+	// the wallet in use is removed
+	conf.Submitter.Targets = append(conf.Submitter.Targets, &config.SubmitterTarget{ChainID: 2, Wallet: "wallet3"})
+	_, err = srv.reloadSigners(ctx, conf, prev)
+	s.EqualError(err, `wallet "wallet3" of the submitter target 2 is not found`)
+
+	// the keystore is required for the wallet without the private key
+	conf.Wallets["wallet3"] = &config.Wallet{Address: wallet2.Address}
+	_, err = srv.reloadSigners(ctx, conf, prev)
+	s.EqualError(err, `failed to load wallet "wallet3": keystore directory is not specified`)
+}
+
+func (s *ReloadTestSuite) TestChangedTargets() {
+	prev := &config.Submitter{Targets: []*config.SubmitterTarget{
+		{ChainID: 1, Wallet: "wallet1"},
+		{ChainID: 2, Wallet: "wallet1"},
+		{ChainID: 3, Wallet: "wallet1"},
+	}}
+	curr := &config.Submitter{Targets: []*config.SubmitterTarget{
+		{ChainID: 1, Wallet: "wallet1"},
+		{ChainID: 2, Wallet: "wallet2"},
+		{ChainID: 4, Wallet: "wallet1"},
+	}}
+
+	got := changedTargets(prev, curr)
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+	s.Equal([]uint64{2, 3, 4}, got)
+}
+
+func (s *ReloadTestSuite) TestConfig() {
+	prev := &config.Config{}
+	prev.Submitter.MulticallAddress = "0x0000000000000000000000000000000000000001"
+	srv := &server{conf: prev}
+	submitterConf := func() *config.Submitter { return &srv.config().Submitter }
+
+	// the getter follows the reloaded configuration
+	conf := &config.Config{}
+	conf.Submitter.MulticallAddress = "0x0000000000000000000000000000000000000002"
+	srv.mu.Lock()
+	srv.conf = conf
+	srv.mu.Unlock()
+	s.Equal(conf.Submitter.MulticallAddress, submitterConf().MulticallAddress)
+}
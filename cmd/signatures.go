@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/equivocation"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	"github.com/oasysgames/oasys-optimism-verifier/submitter"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	signerFlag    = "signer"
+	fromIndexFlag = "from-index"
+	toIndexFlag   = "to-index"
+	approvedFlag  = "approved"
+	fromIDFlag    = "from-id"
+	toIDFlag      = "to-id"
+	offsetFlag    = "offset"
+	summaryFlag   = "summary"
+)
+
+var signaturesCmd = &cobra.Command{
+	Use:   "signatures",
+	Short: "Query the signatures in the local database",
+	Long: "Query the signatures in the local database, or show the total stake " +
+		"per rollup hash and approval of each rollup index with the '--summary'",
+	Run: runSignaturesCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(signaturesCmd)
+
+	signaturesCmd.Flags().String(signerFlag, "", "Filter by the signer address")
+	signaturesCmd.Flags().String(contractFlag, "", "Filter by the address of the rollup contract")
+	signaturesCmd.Flags().Uint64(chainIDFlag, 0, "Filter by the rollup contracts of the Verse-Layer")
+	signaturesCmd.Flags().Uint64(fromIndexFlag, 0, "Filter by the rollup index greater than or equal to")
+	signaturesCmd.Flags().Uint64(toIndexFlag, 0, "Filter by the rollup index less than or equal to")
+	signaturesCmd.Flags().Bool(approvedFlag, false, "Filter by the approval (e.g. --approved=false)")
+	signaturesCmd.Flags().String(fromIDFlag, "", "Filter by the signature ID greater than or equal to")
+	signaturesCmd.Flags().String(toIDFlag, "", "Filter by the signature ID less than or equal to")
+	signaturesCmd.Flags().Int(limitFlag, 100, "Maximum number of records (rollup indexes with the '--summary')")
+	signaturesCmd.Flags().Int(offsetFlag, 0, "Number of records to skip (rollup indexes with the '--summary')")
+	signaturesCmd.Flags().String(formatFlag, "table", "Output format (table, json or csv)")
+	signaturesCmd.Flags().Bool(summaryFlag, false,
+		"Show the total stake per rollup hash and approval instead of the signatures (requires the hub-layer RPC)")
+}
+
+type signatureRow struct {
+	ID          string         `json:"id"`
+	Signer      common.Address `json:"signer"`
+	Contract    common.Address `json:"contract"`
+	RollupIndex uint64         `json:"rollup_index"`
+	RollupHash  common.Hash    `json:"rollup_hash"`
+	Approved    bool           `json:"approved"`
+}
+
+type signatureSummaryRow struct {
+	Contract    common.Address `json:"contract"`
+	RollupIndex uint64         `json:"rollup_index"`
+	RollupHash  common.Hash    `json:"rollup_hash"`
+	Approved    bool           `json:"approved"`
+	Signers     int            `json:"signers"`
+	Stake       string         `json:"stake"` // in OAS
+	Coverage    float64        `json:"coverage"`
+	Sufficient  bool           `json:"sufficient"`
+}
+
+func runSignaturesCmd(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	conf, err := globalConfigLoader.load(true)
+	if err != nil {
+		util.Exit(1, "Failed to load configuration: %s\n", err)
+	}
+
+	filter, err := newSignatureFilter(ctx, cmd, conf)
+	if err != nil {
+		util.Exit(1, "%s\n", err)
+	}
+
+	limit, err := cmd.Flags().GetInt(limitFlag)
+	if err != nil {
+		util.Exit(1, "Failed to read '%s' argument: %s\n", limitFlag, err)
+	}
+	offset, err := cmd.Flags().GetInt(offsetFlag)
+	if err != nil {
+		util.Exit(1, "Failed to read '%s' argument: %s\n", offsetFlag, err)
+	}
+	summary, err := cmd.Flags().GetBool(summaryFlag)
+	if err != nil {
+		util.Exit(1, "Failed to read '%s' argument: %s\n", summaryFlag, err)
+	}
+	format, err := cmd.Flags().GetString(formatFlag)
+	if err != nil {
+		util.Exit(1, "Failed to read '%s' argument: %s\n", formatFlag, err)
+	} else if format != "table" && format != "json" && format != "csv" {
+		util.Exit(1, "Unsupported format: %s\n", format)
+	}
+
+	if conf.Database.Path == "" {
+		conf.Database.Path = conf.DatabasePath()
+	}
+	db, err := database.NewDatabase(&conf.Database)
+	if err != nil {
+		util.Exit(1, "Failed to open database: %s\n", err)
+	}
+
+	// the summary pages by the rollups so that each rollup is summarized as a whole
+	findByFilter := db.OPSignature.FindByFilter
+	if summary {
+		findByFilter = db.OPSignature.FindByFilterPerRollup
+	}
+	rows, err := findByFilter(filter, limit, offset)
+	if err != nil {
+		util.Exit(1, "Failed to find signatures: %s\n", err)
+	}
+
+	if !summary {
+		sigs := make([]*signatureRow, len(rows))
+		for i, row := range rows {
+			sigs[i] = &signatureRow{
+				ID:          row.ID,
+				Signer:      row.Signer.Address,
+				Contract:    row.Contract.Address,
+				RollupIndex: row.RollupIndex,
+				RollupHash:  row.RollupHash,
+				Approved:    row.Approved,
+			}
+		}
+		printSignatures(format, sigs)
+		return
+	}
+
+	hub, err := ethutil.NewClient(conf.HubLayer.RPC, conf.HubLayer.BlockTime)
+	if err != nil {
+		util.Exit(1, "Failed to construct hub-layer client: %s\n", err)
+	}
+	sm, err := stakemanager.NewStakemanagerCaller(common.HexToAddress(StakeManagerAddress), hub)
+	if err != nil {
+		util.Exit(1, "Failed to construct StakeManager: %s\n", err)
+	}
+	smcache := stakemanager.NewCache(sm, time.Hour)
+	totalStake, err := smcache.TotalStakeWithError(ctx)
+	if err != nil {
+		util.Exit(1, "Failed to fetch total stake: %s\n", err)
+	}
+
+	printSignatureSummaries(format, summarizeSignatures(rows, totalStake,
+		func(signer common.Address) *big.Int { return smcache.StakeBySigner(ctx, signer) }))
+}
+
+// Build the filter from the arguments, the rollup contracts of the
+// Verse-Layer are used if the chain ID is specified.
+func newSignatureFilter(
+	ctx context.Context,
+	cmd *cobra.Command,
+	conf *config.Config,
+) (*database.OptimismSignatureFilter, error) {
+	var filter database.OptimismSignatureFilter
+	flags := cmd.Flags()
+
+	if flags.Changed(signerFlag) {
+		signer, _ := flags.GetString(signerFlag)
+		if !common.IsHexAddress(signer) {
+			return nil, fmt.Errorf("invalid '%s' argument: %s", signerFlag, signer)
+		}
+		addr := common.HexToAddress(signer)
+		filter.Signer = &addr
+	}
+	if flags.Changed(contractFlag) {
+		contract, _ := flags.GetString(contractFlag)
+		if !common.IsHexAddress(contract) {
+			return nil, fmt.Errorf("invalid '%s' argument: %s", contractFlag, contract)
+		}
+		filter.Contracts = []common.Address{common.HexToAddress(contract)}
+	} else if flags.Changed(chainIDFlag) {
+		chainID, _ := flags.GetUint64(chainIDFlag)
+		verseCfg, err := findVerseConfig(ctx, conf, chainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the Verse-Layer: %w", err)
+		}
+		filter.Contracts = []common.Address{}
+		for _, name := range []string{SCCName, L2OOName} {
+			if addr, ok := verseCfg.L1Contracts[name]; ok {
+				filter.Contracts = append(filter.Contracts, common.HexToAddress(addr))
+			}
+		}
+	}
+	if flags.Changed(fromIndexFlag) {
+		fromIndex, _ := flags.GetUint64(fromIndexFlag)
+		filter.FromIndex = &fromIndex
+	}
+	if flags.Changed(toIndexFlag) {
+		toIndex, _ := flags.GetUint64(toIndexFlag)
+		filter.ToIndex = &toIndex
+	}
+	if flags.Changed(approvedFlag) {
+		approved, _ := flags.GetBool(approvedFlag)
+		filter.Approved = &approved
+	}
+	if flags.Changed(fromIDFlag) {
+		fromID, _ := flags.GetString(fromIDFlag)
+		filter.FromID = &fromID
+	}
+	if flags.Changed(toIDFlag) {
+		toID, _ := flags.GetString(toIDFlag)
+		filter.ToID = &toID
+	}
+	return &filter, nil
+}
+
+// Group the signatures of each rollup index in the same way as the submitter,
+// the signers whose stake amount is less than the minimum are ignored.
+func summarizeSignatures(
+	rows []*database.OptimismSignature,
+	totalStake *big.Int,
+	stakeBySigner func(signer common.Address) *big.Int,
+) (summaries []*signatureSummaryRow) {
+	required := submitter.RequiredStake(totalStake)
+	summaries = []*signatureSummaryRow{}
+
+	// the rows are ordered by the contract and the rollup index
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && rows[end].ContractID == rows[start].ContractID &&
+			rows[end].RollupIndex == rows[start].RollupIndex {
+			end++
+		}
+
+		groups, _ := equivocation.GroupSignatures(rows[start:end], ethutil.TenMillionOAS, stakeBySigner)
+		for _, group := range groups {
+			coverage, _ := new(big.Float).Quo(
+				new(big.Float).SetInt(new(big.Int).Mul(group.Stake, big.NewInt(100))),
+				new(big.Float).SetInt(totalStake)).Float64()
+			summaries = append(summaries, &signatureSummaryRow{
+				Contract:    rows[start].Contract.Address,
+				RollupIndex: rows[start].RollupIndex,
+				RollupHash:  group.RollupHash,
+				Approved:    group.Approved,
+				Signers:     len(group.Rows),
+				Stake:       toOAS(group.Stake),
+				Coverage:    coverage,
+				Sufficient:  group.Stake.Cmp(required) >= 0,
+			})
+		}
+		start = end
+	}
+	return summaries
+}
+
+func printSignatures(format string, rows []*signatureRow) {
+	header := []string{"id", "signer", "contract", "rollup_index", "rollup_hash", "approved"}
+	records := make([][]string, len(rows))
+	for i, row := range rows {
+		records[i] = []string{
+			row.ID,
+			row.Signer.Hex(),
+			row.Contract.Hex(),
+			strconv.FormatUint(row.RollupIndex, 10),
+			row.RollupHash.Hex(),
+			strconv.FormatBool(row.Approved),
+		}
+	}
+	printRecords(format, rows, header, records)
+}
+
+func printSignatureSummaries(format string, rows []*signatureSummaryRow) {
+	header := []string{"contract", "rollup_index", "rollup_hash", "approved",
+		"signers", "stake", "coverage", "sufficient"}
+	records := make([][]string, len(rows))
+	for i, row := range rows {
+		records[i] = []string{
+			row.Contract.Hex(),
+			strconv.FormatUint(row.RollupIndex, 10),
+			row.RollupHash.Hex(),
+			strconv.FormatBool(row.Approved),
+			strconv.Itoa(row.Signers),
+			row.Stake,
+			strconv.FormatFloat(row.Coverage, 'f', 2, 64),
+			strconv.FormatBool(row.Sufficient),
+		}
+	}
+	printRecords(format, rows, header, records)
+}
+
+// Print the rows as JSON, or the records as CSV or the table.
+func printRecords(format string, rows any, header []string, records [][]string) {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			util.Exit(1, "failed to marshal json: %s\n", err)
+		}
+		fmt.Println(string(data))
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		w.Write(header)
+		w.WriteAll(records)
+		if err := w.Error(); err != nil {
+			util.Exit(1, "failed to write csv: %s\n", err)
+		}
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for i, col := range header {
+			if i > 0 {
+				fmt.Fprint(w, "\t")
+			}
+			fmt.Fprint(w, strings.ToUpper(col))
+		}
+		fmt.Fprintln(w)
+		for _, record := range records {
+			fmt.Fprintln(w, strings.Join(record, "\t"))
+		}
+		w.Flush()
+	}
+}
@@ -10,12 +10,14 @@ import (
 	"os/signal"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	ds "github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/oasysgames/oasys-optimism-verifier/beacon"
 	"github.com/oasysgames/oasys-optimism-verifier/cmd/ipccmd"
@@ -23,10 +25,13 @@ import (
 	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
 	"github.com/oasysgames/oasys-optimism-verifier/database"
 	"github.com/oasysgames/oasys-optimism-verifier/debug"
+	"github.com/oasysgames/oasys-optimism-verifier/equivocation"
 	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
 	"github.com/oasysgames/oasys-optimism-verifier/ipc"
+	"github.com/oasysgames/oasys-optimism-verifier/logger"
 	"github.com/oasysgames/oasys-optimism-verifier/metrics"
 	"github.com/oasysgames/oasys-optimism-verifier/p2p"
+	"github.com/oasysgames/oasys-optimism-verifier/statusapi"
 	"github.com/oasysgames/oasys-optimism-verifier/submitter"
 	"github.com/oasysgames/oasys-optimism-verifier/verifier"
 	"github.com/oasysgames/oasys-optimism-verifier/verse"
@@ -56,7 +61,7 @@ func runStartCmd(cmd *cobra.Command, args []string) {
 	log.Info(fmt.Sprintf("Start %s", commandName), "version", version.SemVer())
 
 	sigC := make(chan os.Signal, 1)
-	signal.Notify(sigC, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigC, syscall.SIGINT, syscall.SIGTERM)
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
 		defer cancel()
@@ -64,6 +69,10 @@ func runStartCmd(cmd *cobra.Command, args []string) {
 		log.Info("Received signal, stopping...", "signal", sig)
 	}()
 
+	// SIGHUP reloads the configuration, handled after all workers started
+	hupC := make(chan os.Signal, 1)
+	signal.Notify(hupC, syscall.SIGHUP)
+
 	s := mustNewServer(ctx)
 
 	// start metrics server
@@ -73,7 +82,7 @@ func runStartCmd(cmd *cobra.Command, args []string) {
 	s.mustStartPprof(ctx)
 
 	// start the ipc server and services dependent on ipc
-	s.mustStartIPC(ctx, []func(context.Context, *ipc.IPCServer){
+	s.mustStartIPC(ctx, []func(context.Context, *ipc.Server){
 		// unlock walelts(wait forever)
 		s.mustLoadSigners,
 		// start p2p (Note: must start the P2P before setup beacon worker)
@@ -97,6 +106,13 @@ func runStartCmd(cmd *cobra.Command, args []string) {
 	s.startSubmitter(ctx)
 	log.Info("All workers started")
 
+	// start status api server
+	s.mustStartStatusAPI(ctx)
+
+	// accept the reload via SIGHUP and ipc
+	s.ready.Store(true)
+	s.startReloadHandler(ctx, hupC)
+
 	// wait for signal
 	<-ctx.Done()
 	log.Info("Shutting down all workers")
@@ -113,10 +129,14 @@ func runStartCmd(cmd *cobra.Command, args []string) {
 		defer cancel()
 		s.psvr.Shutdown(c)
 	}
-	// Shutdown ipc server
-	if s.ipc != nil {
-		s.ipc.Close()
+	// Shutdown status api server
+	if s.ssvr != nil {
+		c, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		s.ssvr.Shutdown(c)
 	}
+	// Shutdown ipc server
+	s.closeIPC()
 
 	var (
 		// time limit until all worker stop
@@ -142,9 +162,11 @@ type server struct {
 	wg        sync.WaitGroup
 	conf      *config.Config
 	db        *database.Database
+	keystore  *wallet.KeyStore
 	signers   map[string]ethutil.Signer
 	hub       ethutil.Client
 	smcache   *stakemanager.Cache
+	detector  *equivocation.Detector
 	p2p       *p2p.Node
 	versepool verse.VersePool
 	verifier  *verifier.Verifier
@@ -152,7 +174,22 @@ type server struct {
 	bw        *beacon.BeaconWorker
 	msvr      *http.Server
 	psvr      *http.Server
-	ipc       *ipc.IPCServer
+	ssvr      *http.Server
+	ipc       *ipc.Server
+	legacyIPC *ipc.LegacyServer
+
+	// `conf` and `signers` are replaced by the reload after all workers started
+	mu       sync.Mutex
+	reloadMu sync.Mutex
+	ready    atomic.Bool
+	verses   []*config.Verse // verses last applied to the pool
+}
+
+// Returns the current configuration, which may be replaced by the reload.
+func (s *server) config() *config.Config {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conf
 }
 
 func mustNewServer(ctx context.Context) *server {
@@ -168,6 +205,12 @@ func mustNewServer(ctx context.Context) *server {
 
 	log.Info("Loaded configuration", "conf", s.conf)
 
+	lvl, err := logger.ParseLevel(s.conf.Log.Level)
+	if err != nil {
+		log.Crit("Failed to parse log level", "err", err)
+	}
+	logger.SetLevel(lvl)
+
 	// setup database
 	if s.conf.Database.Path == "" {
 		s.conf.Database.Path = s.conf.DatabasePath()
@@ -208,6 +251,7 @@ func (s *server) mustStartMetrics(ctx context.Context) {
 	}
 
 	s.msvr = metrics.Initialize(&s.conf.Metrics)
+	listen := s.conf.Metrics.Listen
 	go func() {
 		// NOTE: Don't add wait group, as no need to guarantee the completion
 		if err := metrics.ListenAndServe(ctx, s.msvr); err != nil {
@@ -216,7 +260,7 @@ func (s *server) mustStartMetrics(ctx context.Context) {
 				log.Crit("Failed to start metrics server", "err", err)
 			}
 		}
-		log.Info("Metrics server have exited listening", "addr", s.conf.Metrics.Listen)
+		log.Info("Metrics server have exited listening", "addr", listen)
 	}()
 }
 
@@ -227,6 +271,7 @@ func (s *server) mustStartPprof(ctx context.Context) {
 
 	var ps *debug.PprofServer
 	ps, s.psvr = debug.NewPprofServer(&s.conf.Debug.Pprof)
+	listen := s.conf.Debug.Pprof.Listen
 
 	go func() {
 		// NOTE: Don't add wait group, as no need to guarantee the completion
@@ -236,17 +281,51 @@ func (s *server) mustStartPprof(ctx context.Context) {
 				log.Crit("Failed to start pprof server", "err", err)
 			}
 		}
-		log.Info("pprof server have exited listening", "addr", s.conf.Debug.Pprof.Listen)
+		log.Info("pprof server have exited listening", "addr", listen)
 	}()
 }
 
-func (s *server) mustStartIPC(ctx context.Context, depends []func(context.Context, *ipc.IPCServer)) {
-	if s.conf.IPC.Sockname == "" {
-		log.Crit("IPC socket name is required")
+func (s *server) mustStartStatusAPI(ctx context.Context) {
+	if !s.conf.StatusAPI.Enable {
+		return
 	}
 
+	var verifier *common.Address
+	if s.conf.Verifier.Enable {
+		if signer, ok := s.signers[s.conf.Verifier.Wallet]; ok {
+			addr := signer.From()
+			verifier = &addr
+		}
+	}
+	var targets func() []*submitter.TargetStatus
+	if s.submitter != nil {
+		targets = s.submitter.TargetStatuses
+	}
+	peers := func() []*p2p.PeerSummary {
+		return p2p.NewPeerSummaries(s.p2p.Host(), s.p2p.VerifiedPeers())
+	}
+
+	var ss *statusapi.StatusServer
+	confirmations := func() int { return s.config().Verifier.Confirmations }
+	ss, s.ssvr = statusapi.NewStatusServer(&s.conf.StatusAPI, s.db, s.versepool,
+		confirmations, verifier, targets, peers, s.p2p.BlockedPeers)
+	listen := s.conf.StatusAPI.Listen
+
+	go func() {
+		// NOTE: Don't add wait group, as no need to guarantee the completion
+		if err := ss.ListenAndServe(ctx, s.ssvr); err != nil {
+			// `ErrServerClosed` is thrown when `Shutdown` is intentionally called
+			if !errors.Is(err, http.ErrServerClosed) {
+				log.Crit("Failed to start status api server", "err", err)
+			}
+		}
+		log.Info("status api server have exited listening", "addr", listen)
+	}()
+}
+
+func (s *server) mustStartIPC(ctx context.Context, depends []func(context.Context, *ipc.Server)) {
 	var err error
-	if s.ipc, err = ipc.NewIPCServer(s.conf.IPC.Sockname); err != nil {
+	if s.ipc, err = ipc.NewServer(s.conf.IPCPath()); err != nil {
 		log.Crit("Failed to start ipc server", "err", err)
 	}
 
@@ -263,40 +342,109 @@ func (s *server) mustStartIPC(ctx context.Context, depends []func(context.Contex
 	}
 }
 
-func (s *server) mustStartP2P(ctx context.Context, ipc *ipc.IPCServer) {
+// Serve the wallet unlock of the older versions during the transition.
+func (s *server) mustStartLegacyIPC(walletAPI *ipccmd.WalletAPI) {
+	if s.conf.IPC.Sockname == "" {
+		return
+	}
+
+	var err error
+	s.legacyIPC, err = ipc.NewLegacyServer(s.conf.IPC.Sockname, func(address, password string) error {
+		return walletAPI.Unlock(common.HexToAddress(address), password)
+	})
+	if err != nil {
+		log.Crit("Failed to start legacy ipc server", "err", err)
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		s.legacyIPC.Start()
+		log.Info("Legacy IPC server has stopped, decrement wait group")
+	}()
+}
+
+func (s *server) closeIPC() {
+	if s.ipc != nil {
+		if err := s.ipc.Close(); err != nil {
+			log.Error("Failed to close ipc server", "err", err)
+		}
+	}
+	if s.legacyIPC != nil {
+		if err := s.legacyIPC.Close(); err != nil {
+			log.Error("Failed to close legacy ipc server", "err", err)
+		}
+	}
+}
+
+func mustRegisterAPI(ipc *ipc.Server, namespace string, api interface{}) {
+	if err := ipc.RegisterName(namespace, api); err != nil {
+		log.Crit("Failed to register ipc api", "namespace", namespace, "err", err)
+	}
+}
+
+func (s *server) mustStartP2P(ctx context.Context, ipc *ipc.Server) {
 	// get p2p private key
 	p2pKey, err := getOrCreateP2PKey(s.conf.P2PKeyPath())
 	if err != nil {
 		log.Crit("Failed to get(or create) p2p key", "err", err)
 	}
 
-	// construct libp2p host
-	host, dht, bwm, hpHelper, err := p2p.NewHost(ctx, &s.conf.P2P, p2pKey)
+	// open the datastore to persist the peerstore and the DHT
+	var p2pStore ds.Batching
+	if s.conf.P2P.PeerStore.Enable {
+		if p2pStore, err = p2p.OpenDatastore(s.conf.P2PDatastorePath()); err != nil {
+			log.Crit("Failed to open p2p datastore", "err", err)
+		}
+	}
+
+	// construct libp2p host refusing the misbehaving peers
+	blocklist := p2p.NewBlocklist()
+	host, dht, bwm, hpHelper, err := p2p.NewHost(ctx, &s.conf.P2P, p2pKey, p2pStore, blocklist)
 	if err != nil {
 		log.Crit("Failed to construct libp2p host", "err", err)
 	}
 
-	// ignore self-signed signatures
-	ignoreSigners := []common.Address{}
+	// ignore self-signed signatures, and prove that oneself is a validator to peers
+	var (
+		ignoreSigners = []common.Address{}
+		p2pSigner     p2p.Signer
+	)
 	if signer, ok := s.signers[s.conf.Verifier.Wallet]; ok {
 		ignoreSigners = append(ignoreSigners, signer.From())
+		p2pSigner = signer
 	}
 
+	// detect signers who signed conflicting messages
+	s.detector = equivocation.NewDetector(s.db, s.smcache, s.versepool)
+
 	s.p2p, err = p2p.NewNode(&s.conf.P2P, s.db, host, dht, bwm,
-		hpHelper, s.conf.HubLayer.ChainID, ignoreSigners, s.smcache, s.versepool)
+		hpHelper, s.conf.HubLayer.ChainID, ignoreSigners, s.smcache, s.versepool, s.detector, p2pSigner, blocklist)
 	if err != nil {
 		log.Crit("Failed to construct p2p node", "err", err)
 	}
 
-	ipc.SetHandler(ipccmd.PingCmd.NewHandler(ctx, s.p2p.Host(), s.p2p.HolePunchHelper()))
-	ipc.SetHandler(ipccmd.StatusCmd.NewHandler(s.p2p.Host()))
+	p2pAPI := ipccmd.NewP2PAPI(s.p2p.Host(), s.p2p.HolePunchHelper(),
+		s.p2p.VerifiedPeers, s.p2p.NodeInfo, s.p2p.BlockedPeers)
+	mustRegisterAPI(ipc, ipccmd.AdminNamespace, ipccmd.NewAdminAPI(p2pAPI, s.reload))
+	mustRegisterAPI(ipc, ipccmd.P2PNamespace, p2pAPI)
+	mustRegisterAPI(ipc, ipccmd.VerifierNamespace, ipccmd.NewVerifierAPI(s.db))
+	mustRegisterAPI(ipc, ipccmd.SubmitterNamespace, ipccmd.NewSubmitterAPI(
+		func() *config.Submitter { return &s.config().Submitter }, s.db, s.smcache, s.conf.HubLayer.ChainID, s.versepool))
 
+	enableSubscriber := s.conf.Submitter.Enable
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
 
-		enableSubscriber := s.conf.Submitter.Enable
 		s.p2p.Start(ctx, enableSubscriber)
+
+		if p2pStore != nil {
+			if err := p2pStore.Close(); err != nil {
+				log.Error("Failed to close p2p datastore", "err", err)
+			}
+		}
 	}()
 }
 
@@ -321,6 +469,9 @@ func (s *server) setupSubmitter() {
 	}
 
 	var newSignerFn submitter.L1SignerFn = func(chainID uint64) ethutil.SignableClient {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
 		for _, cfg := range s.conf.Submitter.Targets {
 			if cfg.ChainID == chainID {
 				if signer, ok := s.signers[cfg.Wallet]; ok {
@@ -331,7 +482,7 @@ func (s *server) setupSubmitter() {
 		}
 		return nil
 	}
-	s.submitter = submitter.NewSubmitter(&s.conf.Submitter, s.db, newSignerFn, s.smcache, s.versepool)
+	s.submitter = submitter.NewSubmitter(&s.conf.Submitter, s.db, newSignerFn, s.smcache, s.versepool, s.detector, s.p2p)
 }
 
 func (s *server) startVerseDiscovery(ctx context.Context) {
@@ -365,6 +516,7 @@ func (s *server) startVerseDiscovery(ctx context.Context) {
 		log.Crit("Failed to work verse discovery", "err", err)
 	}
 
+	endpoint, interval := s.conf.VerseLayer.Discovery.Endpoint, s.conf.VerseLayer.Discovery.RefreshInterval
 	s.wg.Add(1)
 	go func() {
 		defer func() {
@@ -373,10 +525,10 @@ func (s *server) startVerseDiscovery(ctx context.Context) {
 			log.Info("Verse discovery has stopped, decrement wait group")
 		}()
 
-		discTick := time.NewTicker(s.conf.VerseLayer.Discovery.RefreshInterval)
+		discTick := time.NewTicker(interval)
 		defer discTick.Stop()
 
-		log.Info("Verse discovery started", "endpoint", s.conf.VerseLayer.Discovery.Endpoint, "interval", s.conf.VerseLayer.Discovery.RefreshInterval)
+		log.Info("Verse discovery started", "endpoint", endpoint, "interval", interval)
 
 		for {
 			select {
@@ -400,14 +552,9 @@ func (s *server) verseDiscoveryHandler(ctx context.Context, discovers []*config.
 		return
 	}
 
-	verseFactories := map[string]verse.VerseFactory{
-		SCCName:  verse.NewOPLegacy,
-		L2OOName: verse.NewOPStack,
-	}
-	verifyContracts := map[string]common.Address{
-		SCCName:  common.HexToAddress(s.conf.Submitter.SCCVerifierAddress),
-		L2OOName: common.HexToAddress(s.conf.Submitter.L2OOVerifierAddress),
-	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verses = discovers
 
 	// Delete erased Verse-Layer from the discovery JSON from the pool.
 	erased := make(map[common.Address]bool)
@@ -425,18 +572,14 @@ func (s *server) verseDiscoveryHandler(ctx context.Context, discovers []*config.
 	// Create a new Verse instance and add it to the pool.
 	var chainIDs []uint64
 	for _, cfg := range discovers {
-		for name, addr := range cfg.L1Contracts {
-			if factory, ok := verseFactories[name]; ok {
-				verse := factory(s.db, s.hub, cfg.ChainID,
-					cfg.RPC, common.HexToAddress(addr), verifyContracts[name])
-				if s.versepool.Add(verse, canSubmits[cfg.ChainID]) {
-					log.Info("Add verse to verse pool",
-						"chain-id", cfg.ChainID, "rpc", cfg.RPC)
-				}
-
-				delete(erased, verse.RollupContract())
-				chainIDs = append(chainIDs, cfg.ChainID)
+		for _, verse := range newVerses(&s.conf.Submitter, s.db, s.hub, cfg) {
+			if s.versepool.Add(verse, canSubmits[cfg.ChainID]) {
+				log.Info("Add verse to verse pool",
+					"chain-id", cfg.ChainID, "rpc", cfg.RPC)
 			}
+
+			delete(erased, verse.RollupContract())
+			chainIDs = append(chainIDs, cfg.ChainID)
 		}
 	}
 
@@ -451,6 +594,31 @@ func (s *server) verseDiscoveryHandler(ctx context.Context, discovers []*config.
 	log.Info("Discovered verses", "count", len(chainIDs), "chain-ids", chainIDs)
 }
 
+// Construct verses for each L1 contract of the Verse-Layer.
+func newVerses(
+	conf *config.Submitter,
+	db *database.Database,
+	hub ethutil.Client,
+	cfg *config.Verse,
+) (verses []verse.Verse) {
+	verseFactories := map[string]verse.VerseFactory{
+		SCCName:  verse.NewOPLegacy,
+		L2OOName: verse.NewOPStack,
+	}
+	verifyContracts := map[string]common.Address{
+		SCCName:  common.HexToAddress(conf.SCCVerifierAddress),
+		L2OOName: common.HexToAddress(conf.L2OOVerifierAddress),
+	}
+
+	for name, addr := range cfg.L1Contracts {
+		if factory, ok := verseFactories[name]; ok {
+			verses = append(verses, factory(db, hub, cfg.ChainID,
+				cfg.RPC, common.HexToAddress(addr), verifyContracts[name]))
+		}
+	}
+	return verses
+}
+
 func (s *server) mustSetupBeacon() {
 	if !s.conf.Beacon.Enable || !s.conf.Verifier.Enable {
 		return
@@ -546,12 +714,13 @@ func getOrCreateP2PKey(filename string) (crypto.PrivKey, error) {
 	return priv, nil
 }
 
-func (s *server) mustLoadSigners(ctx context.Context, ipc *ipc.IPCServer) {
+func (s *server) mustLoadSigners(ctx context.Context, ipc *ipc.Server) {
 	// open geth keystore
-	var ks *wallet.KeyStore
 	if s.conf.Keystore != "" {
-		ks = wallet.NewKeyStore(s.conf.Keystore)
-		ipc.SetHandler(ipccmd.WalletUnlockCmd.NewHandler(ks))
+		s.keystore = wallet.NewKeyStore(s.conf.Keystore)
+		walletAPI := ipccmd.NewWalletAPI(s.keystore)
+		mustRegisterAPI(ipc, ipccmd.WalletNamespace, walletAPI)
+		s.mustStartLegacyIPC(walletAPI)
 	}
 
 	var wg sync.WaitGroup
@@ -560,65 +729,83 @@ func (s *server) mustLoadSigners(ctx context.Context, ipc *ipc.IPCServer) {
 	for n, w := range s.conf.Wallets {
 		go func(name string, wallet *config.Wallet) {
 			defer wg.Done()
-			address := common.HexToAddress(wallet.Address)
-
-			// Plain text private key.
-			if wallet.Plain != "" {
-				priv, err := ethcrypto.HexToECDSA(strings.TrimPrefix(wallet.Plain, "0x"))
-				if err != nil {
-					log.Crit("Failed to decode private key",
-						"name", name, "address", wallet.Address, "err", err)
-				}
 
-				signer := ethutil.NewPrivateKeySigner(priv)
-				if signer.From() != address {
-					log.Crit("Decrypted private key address does not "+
-						"match the wallet address in the config",
-						"name", name, "want", address, "got", signer.From())
-				}
-
-				s.signers[name] = signer
-				log.Info("Loaded plaintext private key wallet", "name", name, "address", address)
-				return
+			signer, err := s.newSigner(ctx, name, wallet, true)
+			if err != nil {
+				log.Crit("Failed to load wallet", "name", name, "address", wallet.Address, "err", err)
 			}
 
-			// go-ethereum's private key.
-			if ks == nil {
-				log.Crit("Keystore directory is not specified")
-			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.signers[name] = signer
+		}(n, w)
+	}
 
-			_wallet, account, err := ks.FindWallet(address)
-			if err != nil {
-				log.Crit("Failed to find the wallet",
-					"name", name, "address", wallet.Address, "err", err)
-			}
+	wg.Wait()
+}
 
-			if wallet.Password != "" {
-				pw, err := os.ReadFile(wallet.Password)
-				if err != nil {
-					log.Crit("Failed to read password file",
-						"name", name, "address", address, "err", err)
-				}
+// Returns the signer of the wallet. If the keystore wallet cannot be unlocked using
+// the password file or the empty password, waits for the unlock via IPC when `wait`
+// is true, otherwise returns an error unless it has already been unlocked.
+func (s *server) newSigner(
+	ctx context.Context,
+	name string,
+	wallet *config.Wallet,
+	wait bool,
+) (ethutil.Signer, error) {
+	address := common.HexToAddress(wallet.Address)
+
+	// Plain text private key.
+	if wallet.Plain != "" {
+		priv, err := ethcrypto.HexToECDSA(strings.TrimPrefix(wallet.Plain, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode private key: %w", err)
+		}
 
-				if err := ks.Unlock(*account, strings.Trim(string(pw), "\r\n\t ")); err != nil {
-					log.Crit("Failed to unlock wallet using password file",
-						"name", name, "address", address, "err", err)
-				}
-				log.Info("Wallet unlocked using password file", "name", name, "address", address)
-			} else if ks.Unlock(*account, "") == nil {
-				log.Info("Wallet unlocked using empty password", "name", name, "address", address)
-			} else {
-				log.Info("Waiting for wallet unlock via IPC", "name", name, "address", address)
-				if err := ks.WaitForUnlock(ctx, _wallet); err != nil {
-					log.Crit("Wallet was not unlocked",
-						"name", name, "address", address, "err", err)
-				}
-				log.Info("Wallet unlocked via IPC", "name", name, "address", address)
-			}
+		signer := ethutil.NewPrivateKeySigner(priv)
+		if signer.From() != address {
+			return nil, fmt.Errorf("decrypted private key address does not match "+
+				"the wallet address in the config, want: %s, got: %s", address, signer.From())
+		}
 
-			s.signers[name] = ethutil.NewKeystoreSigner(_wallet, account)
-		}(n, w)
+		log.Info("Loaded plaintext private key wallet", "name", name, "address", address)
+		return signer, nil
 	}
 
-	wg.Wait()
+	// go-ethereum's private key.
+	ks := s.keystore
+	if ks == nil {
+		return nil, errors.New("keystore directory is not specified")
+	}
+
+	_wallet, account, err := ks.FindWallet(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the wallet: %w", err)
+	}
+
+	if wallet.Password != "" {
+		pw, err := os.ReadFile(wallet.Password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read password file: %w", err)
+		}
+
+		if err := ks.Unlock(*account, strings.Trim(string(pw), "\r\n\t ")); err != nil {
+			return nil, fmt.Errorf("failed to unlock wallet using password file: %w", err)
+		}
+		log.Info("Wallet unlocked using password file", "name", name, "address", address)
+	} else if ks.Unlock(*account, "") == nil {
+		log.Info("Wallet unlocked using empty password", "name", name, "address", address)
+	} else if wait {
+		log.Info("Waiting for wallet unlock via IPC", "name", name, "address", address)
+		if err := ks.WaitForUnlock(ctx, _wallet); err != nil {
+			return nil, fmt.Errorf("wallet was not unlocked: %w", err)
+		}
+		log.Info("Wallet unlocked via IPC", "name", name, "address", address)
+	} else if locked, err := ks.IsLocked(_wallet); err != nil {
+		return nil, err
+	} else if locked {
+		return nil, errors.New("wallet is locked, unlock it via IPC beforehand")
+	}
+
+	return ethutil.NewKeystoreSigner(_wallet, account), nil
 }
@@ -11,11 +11,12 @@ var statusCmd = &cobra.Command{
 	Short: "Show status",
 	Long:  "Show status",
 	Run: func(cmd *cobra.Command, args []string) {
-		conf, err := globalConfigLoader.load(true)
+		// only the ipc path is used, so the bootnode configuration is also accepted
+		conf, err := globalConfigLoader.loadBootnode()
 		if err != nil {
 			util.Exit(1, "Failed to load configuration: %s\n", err)
 		}
-		ipccmd.StatusCmd.Run(conf.IPC.Sockname)
+		ipccmd.StatusCmd.Run(conf.IPCPath())
 	},
 }
 
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/oasysgames/oasys-optimism-verifier/cmd/ipccmd"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	sinceFlag  = "since"
+	formatFlag = "format"
+)
+
+var submissionsCmd = &cobra.Command{
+	Use:   "submissions",
+	Short: "Show the history of the verify transactions",
+	Long: "Show the verify transactions sent by the submitter with their gas cost and status.\n" +
+		"The status is 1 if succeeded, 0 if reverted, 2 if the receipt has not been received yet\n" +
+		"and 3 if waiting for the receipt failed, with the error in the revert reason.\n" +
+		"The gas of a transaction verifying multiple verses is split among them by the number of calls.",
+	Run: func(cmd *cobra.Command, args []string) {
+		conf, err := globalConfigLoader.load(true)
+		if err != nil {
+			util.Exit(1, "Failed to load configuration: %s\n", err)
+		}
+
+		chainID, err := cmd.Flags().GetUint64(chainIDFlag)
+		if err != nil {
+			util.Exit(1, "Failed to read '%s' argument: %s\n", chainIDFlag, err)
+		}
+
+		sinceStr, err := cmd.Flags().GetString(sinceFlag)
+		if err != nil {
+			util.Exit(1, "Failed to read '%s' argument: %s\n", sinceFlag, err)
+		}
+		since, err := parseSince(sinceStr, time.Now())
+		if err != nil {
+			util.Exit(1, "Failed to parse '%s' argument: %s\n", sinceFlag, err)
+		}
+
+		limit, err := cmd.Flags().GetInt(limitFlag)
+		if err != nil {
+			util.Exit(1, "Failed to read '%s' argument: %s\n", limitFlag, err)
+		}
+
+		format, err := cmd.Flags().GetString(formatFlag)
+		if err != nil {
+			util.Exit(1, "Failed to read '%s' argument: %s\n", formatFlag, err)
+		} else if format != "json" && format != "csv" {
+			util.Exit(1, "Unsupported format: %s\n", format)
+		}
+
+		ipccmd.SubmissionsCmd.Run(conf.IPCPath(), chainID, since, limit, format == "csv")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(submissionsCmd)
+
+	submissionsCmd.Flags().Uint64(chainIDFlag, 0, "Filter by the chain ID of the Verse-Layer")
+	submissionsCmd.Flags().String(sinceFlag, "", "Show transactions sent since the duration ago (e.g. 24h) or the date (e.g. 2006-01-02, RFC3339)")
+	submissionsCmd.Flags().Int(limitFlag, 100, "Maximum number of records")
+	submissionsCmd.Flags().String(formatFlag, "json", "Output format (json or csv)")
+}
+
+// Parse the `since` argument as a duration ago or a date.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid duration or date: %s", s)
+}
@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	"github.com/oasysgames/oasys-optimism-verifier/submitter"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+	"github.com/oasysgames/oasys-optimism-verifier/wallet"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	chainIDFlag = "chain-id"
+	indexFlag   = "index"
+	walletFlag  = "wallet"
+	yesFlag     = "yes"
+)
+
+var submitCmd = &cobra.Command{
+	Use:   "submit",
+	Short: "Submit signatures for the specified rollup index manually",
+	Long: "Build the verify transaction for the specified rollup index " +
+		"from the signatures in the local database, and send it after confirmation",
+	Run: runSubmitCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(submitCmd)
+
+	submitCmd.Flags().Uint64(chainIDFlag, 0, "Chain ID of the Verse-Layer")
+	submitCmd.MarkFlagRequired(chainIDFlag)
+
+	submitCmd.Flags().Uint64(indexFlag, 0, "Rollup index to submit")
+	submitCmd.MarkFlagRequired(indexFlag)
+
+	submitCmd.Flags().String(contractFlag, "", "Address of the rollup contract (required if the verse has multiple rollup contracts)")
+
+	submitCmd.Flags().String(walletFlag, "", "Wallet name used to send the transaction (default: wallet of the submitter target)")
+	submitCmd.Flags().Bool(yesFlag, false, "Send the transaction without confirmation")
+}
+
+func runSubmitCmd(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	conf, err := globalConfigLoader.load(true)
+	if err != nil {
+		util.Exit(1, "Failed to load configuration: %s\n", err)
+	}
+
+	chainID, err := cmd.Flags().GetUint64(chainIDFlag)
+	if err != nil {
+		util.Exit(1, "Failed to read '%s' argument: %s\n", chainIDFlag, err)
+	}
+	rollupIndex, err := cmd.Flags().GetUint64(indexFlag)
+	if err != nil {
+		util.Exit(1, "Failed to read '%s' argument: %s\n", indexFlag, err)
+	}
+	contract, err := cmd.Flags().GetString(contractFlag)
+	if err != nil {
+		util.Exit(1, "Failed to read '%s' argument: %s\n", contractFlag, err)
+	}
+	if contract != "" && !common.IsHexAddress(contract) {
+		util.Exit(1, "Invalid '%s' argument: %s\n", contractFlag, contract)
+	}
+	walletName, err := cmd.Flags().GetString(walletFlag)
+	if err != nil {
+		util.Exit(1, "Failed to read '%s' argument: %s\n", walletFlag, err)
+	}
+	yes, err := cmd.Flags().GetBool(yesFlag)
+	if err != nil {
+		util.Exit(1, "Failed to read '%s' argument: %s\n", yesFlag, err)
+	}
+
+	if walletName == "" {
+		if walletName, err = targetWallet(conf.Submitter.Targets, chainID); err != nil {
+			util.Exit(1, "The '%s' argument is required as %s\n", walletFlag, err)
+		}
+	}
+
+	signer, err := loadSigner(conf, walletName)
+	if err != nil {
+		util.Exit(1, "Failed to load wallet: %s\n", err)
+	}
+
+	if conf.Database.Path == "" {
+		conf.Database.Path = conf.DatabasePath()
+	}
+	db, err := database.NewDatabase(&conf.Database)
+	if err != nil {
+		util.Exit(1, "Failed to open database: %s\n", err)
+	}
+
+	hub, err := ethutil.NewClient(conf.HubLayer.RPC, conf.HubLayer.BlockTime)
+	if err != nil {
+		util.Exit(1, "Failed to construct hub-layer client: %s\n", err)
+	}
+
+	sm, err := stakemanager.NewStakemanagerCaller(common.HexToAddress(StakeManagerAddress), hub)
+	if err != nil {
+		util.Exit(1, "Failed to construct StakeManager: %s\n", err)
+	}
+	smcache := stakemanager.NewCache(sm, time.Hour)
+	if _, err := smcache.TotalStakeWithError(ctx); err != nil {
+		util.Exit(1, "Failed to fetch total stake: %s\n", err)
+	}
+
+	verseCfg, err := findVerseConfig(ctx, conf, chainID)
+	if err != nil {
+		util.Exit(1, "Failed to find the Verse-Layer: %s\n", err)
+	}
+	if verseCfg, err = selectRollupContract(verseCfg, contract); err != nil {
+		util.Exit(1, "Failed to select the rollup contract: %s\n", err)
+	}
+	verses := newVerses(&conf.Submitter, db, hub, verseCfg)
+
+	l1Signer := ethutil.NewSignableClient(new(big.Int).SetUint64(conf.HubLayer.ChainID), hub, signer)
+	task := verses[0].WithTransactable(l1Signer, verses[0].VerifyContract())
+
+	sub := submitter.NewSubmitter(&conf.Submitter, db, nil, smcache, nil, nil, nil)
+	prepared, err := sub.Prepare(ctx, task, rollupIndex)
+	if errors.Is(err, submitter.ErrAlreadyVerified) {
+		util.Exit(1, "The rollup index %d is already verified\n", rollupIndex)
+	} else if errors.Is(err, submitter.ErrNotNextIndex) {
+		util.Exit(1, "The rollup index %d is not the next index to verify\n", rollupIndex)
+	} else if errors.Is(err, submitter.ErrNoSignatures) {
+		util.Exit(1, "No signatures for the rollup index %d\n", rollupIndex)
+	} else if err != nil {
+		util.Exit(1, "Failed to prepare transaction: %s\n", err)
+	}
+
+	printPreparedTx(prepared)
+
+	if !yes && !confirm("Send transaction?") {
+		fmt.Println("Cancelled")
+		return
+	}
+
+	if err := sub.SendPrepared(ctx, prepared); err != nil {
+		util.Exit(1, "Failed to submit: %s\n", err)
+	}
+	fmt.Printf("Successfully verified, tx: %s\n", prepared.Tx.Hash().Hex())
+}
+
+func printPreparedTx(prepared *submitter.PreparedTx) {
+	fmt.Printf("Chain ID:        %d\n", prepared.Verse.ChainID())
+	fmt.Printf("Rollup contract: %s\n", prepared.Verse.RollupContract().Hex())
+	fmt.Printf("Verify contract: %s\n", prepared.Verse.VerifyContract().Hex())
+	fmt.Printf("Next index:      %d\n", prepared.NextIndex)
+	fmt.Printf("Rollup index:    %d\n", prepared.RollupIndex)
+	fmt.Printf("Rollup hash:     %s\n", prepared.RollupHash.Hex())
+	fmt.Printf("Approved:        %v\n", prepared.Approved)
+	fmt.Printf("Stake coverage:  %s / %s OAS (%.2f%%)\n",
+		toOAS(prepared.SignedStake), toOAS(prepared.TotalStake), prepared.Coverage())
+	fmt.Printf("Sender:          %s\n", prepared.Verse.L1Signer().Signer().Hex())
+	fmt.Printf("Nonce:           %d\n", prepared.Tx.Nonce())
+	fmt.Printf("Gas limit:       %d\n", prepared.Tx.Gas())
+	fmt.Printf("Gas fee cap:     %s\n", prepared.Tx.GasFeeCap())
+	fmt.Printf("Gas tip cap:     %s\n", prepared.Tx.GasTipCap())
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SIGNER\tSTAKE(OAS)\tSIGNATURE ID")
+	for i, sig := range prepared.Signatures {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", sig.Signer.Address.Hex(), toOAS(prepared.Stakes[i]), sig.ID)
+	}
+	w.Flush()
+	fmt.Println()
+}
+
+// Convert the wei amount to OAS, truncating the fraction.
+func toOAS(wei *big.Int) string {
+	return new(big.Int).Div(wei, big.NewInt(params.Ether)).String()
+}
+
+func confirm(prompt string) bool {
+	fmt.Printf("%s [y/N]: ", prompt)
+	input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	input = strings.ToLower(strings.TrimSpace(input))
+	return input == "y" || input == "yes"
+}
+
+// Returns the wallet name of the submitter target for the chain id.
+// Fails if the chain is not a target, or is a target of multiple wallets.
+func targetWallet(targets []*config.SubmitterTarget, chainID uint64) (string, error) {
+	var wallet string
+	for _, target := range targets {
+		if target.ChainID != chainID {
+			continue
+		} else if wallet != "" && wallet != target.Wallet {
+			return "", fmt.Errorf("the chain id %d is a submitter target of multiple wallets", chainID)
+		}
+		wallet = target.Wallet
+	}
+	if wallet == "" {
+		return "", fmt.Errorf("the chain id %d is not a submitter target", chainID)
+	}
+	return wallet, nil
+}
+
+// Returns a copy of the Verse-Layer configuration containing only the rollup contract
+// to submit for. The `contract` is required if the verse has multiple rollup contracts.
+func selectRollupContract(cfg *config.Verse, contract string) (*config.Verse, error) {
+	selected := *cfg
+	selected.L1Contracts = map[string]string{}
+	for _, name := range []string{SCCName, L2OOName} {
+		addr, ok := cfg.L1Contracts[name]
+		if !ok {
+			continue
+		}
+		if contract == "" || common.HexToAddress(addr) == common.HexToAddress(contract) {
+			selected.L1Contracts[name] = addr
+		}
+	}
+
+	switch len(selected.L1Contracts) {
+	case 0:
+		if contract != "" {
+			return nil, fmt.Errorf("%s is not a rollup contract of the chain id %d", contract, cfg.ChainID)
+		}
+		return nil, fmt.Errorf("no rollup contract for the chain id %d", cfg.ChainID)
+	case 1:
+		return &selected, nil
+	default:
+		return nil, fmt.Errorf("the chain id %d has multiple rollup contracts, "+
+			"specify one with the '%s' argument", cfg.ChainID, contractFlag)
+	}
+}
+
+// Returns the Verse-Layer configuration from the static settings or the discovery.
+func findVerseConfig(ctx context.Context, conf *config.Config, chainID uint64) (*config.Verse, error) {
+	for _, cfg := range conf.VerseLayer.Directs {
+		if cfg.ChainID == chainID {
+			return cfg, nil
+		}
+	}
+
+	if conf.VerseLayer.Discovery.Endpoint == "" {
+		return nil, fmt.Errorf("chain id %d is not found in the static verses", chainID)
+	}
+
+	verses, err := discoverVerseConfigs(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	for _, cfg := range verses {
+		if cfg.ChainID == chainID {
+			return cfg, nil
+		}
+	}
+	return nil, fmt.Errorf("chain id %d is not found in the discovery", chainID)
+}
+
+// Returns the Verse-Layer configurations fetched from the discovery once.
+func discoverVerseConfigs(ctx context.Context, conf *config.Config) ([]*config.Verse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	disc, err := config.NewVerseDiscovery(ctx, http.DefaultClient,
+		conf.VerseLayer.Discovery.Endpoint, conf.VerseLayer.Discovery.RefreshInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := disc.Subscribe(ctx)
+	defer sub.Cancel()
+
+	if err := disc.Work(ctx); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case verses := <-sub.Next():
+		return verses, nil
+	}
+}
+
+// Load the wallet for command line use, prompting for
+// the password if the keystore can not be unlocked.
+func loadSigner(conf *config.Config, name string) (ethutil.Signer, error) {
+	w, ok := conf.Wallets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown wallet: %s", name)
+	}
+	address := common.HexToAddress(w.Address)
+
+	// Plain text private key.
+	if w.Plain != "" {
+		priv, err := ethcrypto.HexToECDSA(strings.TrimPrefix(w.Plain, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode private key: %w", err)
+		}
+
+		signer := ethutil.NewPrivateKeySigner(priv)
+		if signer.From() != address {
+			return nil, fmt.Errorf("decrypted private key address does not match "+
+				"the wallet address in the config, want: %s, got: %s", address, signer.From())
+		}
+		return signer, nil
+	}
+
+	// go-ethereum's private key.
+	if conf.Keystore == "" {
+		return nil, errors.New("keystore directory is not specified")
+	}
+	ks := wallet.NewKeyStore(conf.Keystore)
+
+	_wallet, account, err := ks.FindWallet(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the wallet: %w", err)
+	}
+
+	var password string
+	if w.Password != "" {
+		pw, err := os.ReadFile(w.Password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read password file: %w", err)
+		}
+		password = strings.Trim(string(pw), "\r\n\t ")
+	} else if ks.Unlock(*account, "") != nil {
+		fmt.Printf("Password for %s: ", address.Hex())
+		input, err := term.ReadPassword(int(syscall.Stdin))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read password: %w", err)
+		}
+		fmt.Print("\n")
+		password = string(input)
+	}
+
+	if err := ks.Unlock(*account, password); err != nil {
+		return nil, fmt.Errorf("failed to unlock wallet: %w", err)
+	}
+	return ethutil.NewKeystoreSigner(_wallet, account), nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type SubmitTestSuite struct {
+	suite.Suite
+}
+
+func TestSubmit(t *testing.T) {
+	suite.Run(t, new(SubmitTestSuite))
+}
+
+func (s *SubmitTestSuite) TestSelectRollupContract() {
+	scc := "0x0000000000000000000000000000000000000001"
+	l2oo := "0x0000000000000000000000000000000000000002"
+
+	cases := []struct {
+		name      string
+		contracts map[string]string
+		contract  string
+		want      map[string]string
+		err       string
+	}{
+		{
+			name:      "only scc",
+			contracts: map[string]string{SCCName: scc, "Unknown": l2oo},
+			want:      map[string]string{SCCName: scc},
+		},
+		{
+			name:      "ambiguous",
+			contracts: map[string]string{SCCName: scc, L2OOName: l2oo},
+			err:       "the chain id 420 has multiple rollup contracts, specify one with the 'contract' argument",
+		},
+		{
+			name:      "specified",
+			contracts: map[string]string{SCCName: scc, L2OOName: l2oo},
+			contract:  "0x0000000000000000000000000000000000000002",
+			want:      map[string]string{L2OOName: l2oo},
+		},
+		{
+			name:      "not found",
+			contracts: map[string]string{SCCName: scc, L2OOName: l2oo},
+			contract:  "0x0000000000000000000000000000000000000003",
+			err:       "0x0000000000000000000000000000000000000003 is not a rollup contract of the chain id 420",
+		},
+		{
+			name:      "no rollup contract",
+			contracts: map[string]string{"Unknown": scc},
+			err:       "no rollup contract for the chain id 420",
+		},
+	}
+
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			cfg := &config.Verse{ChainID: 420, RPC: "http://127.0.0.1:8545/", L1Contracts: tc.contracts}
+			got, err := selectRollupContract(cfg, tc.contract)
+			if tc.err != "" {
+				s.EqualError(err, tc.err)
+				return
+			}
+			s.NoError(err)
+			s.Equal(cfg.ChainID, got.ChainID)
+			s.Equal(cfg.RPC, got.RPC)
+			s.Equal(tc.want, got.L1Contracts)
+			s.Len(cfg.L1Contracts, len(tc.contracts))
+		})
+	}
+}
+
+func (s *SubmitTestSuite) TestTargetWallet() {
+	targets := []*config.SubmitterTarget{
+		{ChainID: 420, Wallet: "wallet0"},
+		{ChainID: 421, Wallet: "wallet1"},
+		{ChainID: 421, Wallet: "wallet1"},
+		{ChainID: 422, Wallet: "wallet0"},
+		{ChainID: 422, Wallet: "wallet2"},
+	}
+
+	got, err := targetWallet(targets, 420)
+	s.NoError(err)
+	s.Equal("wallet0", got)
+
+	got, err = targetWallet(targets, 421)
+	s.NoError(err)
+	s.Equal("wallet1", got)
+
+	_, err = targetWallet(targets, 422)
+	s.EqualError(err, "the chain id 422 is a submitter target of multiple wallets")
+
+	_, err = targetWallet(targets, 423)
+	s.EqualError(err, "the chain id 423 is not a submitter target")
+}
@@ -26,17 +26,20 @@ var (
 func init() {
 	// Convert error message to use the field names from
 	// configuration file instead of the struct field names.
-	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
-		name := strings.SplitN(fld.Tag.Get("koanf"), ",", 2)[0]
-		switch name {
-		case "":
-			return strings.ToLower(fld.Name)
-		case "-":
-			return ""
-		default:
-			return name
-		}
-	})
+	validate.RegisterTagNameFunc(fieldName)
+}
+
+// Returns the field name used in the configuration file.
+func fieldName(fld reflect.StructField) string {
+	name := strings.SplitN(fld.Tag.Get("koanf"), ",", 2)[0]
+	switch name {
+	case "":
+		return strings.ToLower(fld.Name)
+	case "-":
+		return ""
+	default:
+		return name
+	}
 }
 
 func Defaults() map[string]interface{} {
@@ -57,19 +60,47 @@ func Defaults() map[string]interface{} {
 			"/ip4/172.16.0.0/ipcidr/12",
 			"/ip4/192.168.0.0/ipcidr/16",
 		},
-		"p2p.transports.tcp":               true,
-		"p2p.transports.quic":              true,
-		"p2p.nat.upnp":                     true,
-		"p2p.nat.autonat":                  true,
-		"p2p.nat.holepunch":                true,
-		"p2p.relay_client.enable":          true,
-		"p2p.publish_interval":             5 * time.Minute,
-		"p2p.stream_timeout":               10 * time.Second,
-		"p2p.outbound_limits.concurrency":  10,
-		"p2p.outbound_limits.throttling":   500,
-		"p2p.inbound_limits.concurrency":   10,
-		"p2p.inbound_limits.throttling":    500,
-		"p2p.inbound_limits.max_send_time": 30 * time.Second,
+		"p2p.transports.tcp":                      true,
+		"p2p.transports.quic":                     true,
+		"p2p.transports.websocket.enable":         false,
+		"p2p.transports.webtransport":             false,
+		"p2p.nat.upnp":                            true,
+		"p2p.nat.autonat":                         true,
+		"p2p.nat.holepunch":                       true,
+		"p2p.relay_client.enable":                 true,
+		"p2p.publish_interval":                    5 * time.Minute,
+		"p2p.legacy_topic":                        true,
+		"p2p.stream_timeout":                      10 * time.Second,
+		"p2p.outbound_limits.concurrency":         10,
+		"p2p.outbound_limits.throttling":          500,
+		"p2p.inbound_limits.concurrency":          10,
+		"p2p.inbound_limits.throttling":           500,
+		"p2p.inbound_limits.max_send_time":        30 * time.Second,
+		"p2p.inbound_limits.peer_throttling":      100,
+		"p2p.misbehaviour.threshold":              100.0,
+		"p2p.misbehaviour.block_duration":         time.Hour,
+		"p2p.misbehaviour.score_decay":            10 * time.Minute,
+		"p2p.peer_score.enable":                   true,
+		"p2p.peer_score.invalid_message_weight":   -100.0,
+		"p2p.peer_score.invalid_message_decay":    time.Hour,
+		"p2p.peer_score.ip_colocation_weight":     -10.0,
+		"p2p.peer_score.ip_colocation_threshold":  5,
+		"p2p.peer_score.behaviour_penalty_weight": -10.0,
+		"p2p.peer_score.behaviour_penalty_decay":  time.Hour,
+		"p2p.peer_score.gossip_threshold":         -100.0,
+		"p2p.peer_score.publish_threshold":        -500.0,
+		"p2p.peer_score.graylist_threshold":       -1000.0,
+		"p2p.catch_up.enable":                     true,
+		"p2p.catch_up.interval":                   10 * time.Minute,
+		"p2p.catch_up.peers":                      3,
+		"p2p.catch_up.max_signatures":             5000,
+		"p2p.peer_store.enable":                   true,
+		"p2p.peer_store.reconnect_peers":          50,
+		"p2p.conn_manager.low_water":              160,
+		"p2p.conn_manager.high_water":             192,
+		"p2p.conn_manager.grace_period":           time.Minute,
+		"p2p.mdns.enable":                         false,
+		"p2p.mdns.service_name":                   "oasys-optimism-verifier",
 
 		"ipc.sockname": "oasvlfy",
 
@@ -96,6 +127,7 @@ func Defaults() map[string]interface{} {
 		"submitter.l2oo_verifier_address": "0xF62fD2d4ef5a99C5bAa1effd0dc20889c5021E1c",
 		"submitter.use_multicall":         true,
 		"submitter.multicall_address":     "0x5200000000000000000000000000000000000022",
+		"submitter.cross_verse_batching":  false,
 
 		"beacon.enable":   true,
 		"beacon.endpoint": "https://script.google.com/macros/s/AKfycbzJpDKyn271jbm5otk_BxGkrS2b1YdMQerVq2-XxLdTOdhUPKCZICqvagvGgByxx_nq0Q/exec",
@@ -109,6 +141,8 @@ func Defaults() map[string]interface{} {
 		"metrics.listen":   "127.0.0.1:9200",
 		"metrics.endpoint": "/metrics",
 
+		"status_api.listen": "127.0.0.1:9300",
+
 		"debug.pprof.listen":              "127.0.0.1:6060",
 		"debug.pprof.basic_auth.username": "username",
 		"debug.pprof.basic_auth.password": "password",
@@ -119,6 +153,36 @@ func Defaults() map[string]interface{} {
 
 // Build configuration.
 func NewConfig(input []byte, enableStrictValidation bool) (*Config, error) {
+	conf, err := unmarshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// run validation
+	if err := Validate(conf, enableStrictValidation); err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+// Build configuration for the bootnode, which does not require
+// the Hub-Layer, the Verse-Layer, the wallets and the workers.
+func NewBootnodeConfig(input []byte) (*Config, error) {
+	conf, err := unmarshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// run validation
+	if err := ValidateBootnode(conf); err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+func unmarshal(input []byte) (*Config, error) {
 	k := koanf.New(".")
 
 	// load default values
@@ -138,11 +202,6 @@ func NewConfig(input []byte, enableStrictValidation bool) (*Config, error) {
 		return nil, err
 	}
 
-	// run validation
-	if err := Validate(&conf, enableStrictValidation); err != nil {
-		return nil, err
-	}
-
 	return &conf, nil
 }
 
@@ -181,6 +240,13 @@ func Validate(conf *Config, strict bool) error {
 	return nil
 }
 
+// Validate the configuration except the sections not used by the bootnode.
+func ValidateBootnode(conf *Config) error {
+	return validate.StructExcept(conf,
+		"Keystore", "Wallets", "HubLayer", "VerseLayer",
+		"Verifier", "Submitter", "Beacon", "Database")
+}
+
 // App configuration.
 type Config struct {
 	// Datastore directory path.
@@ -219,6 +285,12 @@ type Config struct {
 	// Metrics configuration
 	Metrics Metrics
 
+	// Read-only HTTP status API configuration.
+	StatusAPI StatusAPI `koanf:"status_api"`
+
+	// Log configuration.
+	Log Log
+
 	// Debug configuration.
 	Debug Debug
 }
@@ -227,10 +299,21 @@ func (c *Config) DatabasePath() string {
 	return filepath.Join(c.Datastore, "db.sqlite")
 }
 
+func (c *Config) IPCPath() string {
+	if c.IPC.Path != "" {
+		return c.IPC.Path
+	}
+	return filepath.Join(c.Datastore, "oasvlfy.ipc")
+}
+
 func (c *Config) P2PKeyPath() string {
 	return filepath.Join(c.Datastore, "p2p.key")
 }
 
+func (c *Config) P2PDatastorePath() string {
+	return filepath.Join(c.Datastore, "p2p")
+}
+
 type Wallet struct {
 	// Address of the wallet.
 	Address string `validate:"hexadecimal"`
@@ -292,6 +375,19 @@ type P2P struct {
 	Transports struct {
 		TCP  bool
 		QUIC bool
+
+		// WebSocket transport for the networks allowing only HTTP(S)-like traffic.
+		// Listen on `/tls/ws` addresses to accept the secure WebSocket(WSS).
+		WebSocket struct {
+			Enable bool
+
+			// TLS certificate and private key files used for WSS.
+			CertFile string `koanf:"cert_file" validate:"required_with=KeyFile,omitempty,file"`
+			KeyFile  string `koanf:"key_file" validate:"required_with=CertFile,omitempty,file"`
+		} `koanf:"websocket"`
+
+		// WebTransport transport over QUIC(HTTP/3).
+		WebTransport bool `koanf:"webtransport"`
 	}
 
 	// Deprecated: Address and port to listen.
@@ -344,6 +440,10 @@ type P2P struct {
 	// Interval to publish own signature status.
 	PublishInterval time.Duration `koanf:"publish_interval"`
 
+	// Publish and subscribe the signatures of all verses on the legacy topic
+	// in addition to the per-verse topics, for the nodes of older versions.
+	LegacyTopic bool `koanf:"legacy_topic"`
+
 	// Timeout for P2P stream communication.
 	StreamTimeout time.Duration `koanf:"stream_timeout"`
 
@@ -364,8 +464,119 @@ type P2P struct {
 
 		// Maximum time to send signatures to a peer.
 		MaxSendTime time.Duration `koanf:"max_send_time"`
+
+		// The number of signatures that can be sent to each peer per second.
+		PeerThrottling int `koanf:"peer_throttling"`
 	} `koanf:"inbound_limits"`
 
+	// Misbehaviour scoring of the stream protocols. The peers sending invalid
+	// signatures, unknown messages or timing out are scored, and blocklisted
+	// for a while when the score exceeds the threshold.
+	Misbehaviour struct {
+		// Score to blocklist the peer, zero disables the blocklist.
+		Threshold float64
+
+		// Duration to refuse the connections from the blocklisted peer.
+		BlockDuration time.Duration `koanf:"block_duration"`
+
+		// Interval to halve the score.
+		ScoreDecay time.Duration `koanf:"score_decay"`
+	}
+
+	// Gossipsub peer scoring, penalizes peers that propagate invalid signatures.
+	PeerScore struct {
+		Enable bool
+
+		// Weight of the penalty for each invalid message, should be negative.
+		InvalidMessageWeight float64 `koanf:"invalid_message_weight"`
+
+		// Time for the penalty of invalid messages to decay to zero.
+		InvalidMessageDecay time.Duration `koanf:"invalid_message_decay"`
+
+		// Penalty for too many peers sharing the same IP address.
+		IPColocationWeight    float64 `koanf:"ip_colocation_weight"`
+		IPColocationThreshold int     `koanf:"ip_colocation_threshold"`
+
+		// Penalty for protocol misbehaviour such as GRAFT flooding.
+		BehaviourPenaltyWeight float64       `koanf:"behaviour_penalty_weight"`
+		BehaviourPenaltyDecay  time.Duration `koanf:"behaviour_penalty_decay"`
+
+		// Below these scores, gossip is suppressed, own messages are not
+		// published to and all messages are ignored from the peer respectively.
+		GossipThreshold   float64 `koanf:"gossip_threshold"`
+		PublishThreshold  float64 `koanf:"publish_threshold"`
+		GraylistThreshold float64 `koanf:"graylist_threshold"`
+	} `koanf:"peer_score"`
+
+	// Request signatures missed while offline from peers on startup and periodically.
+	CatchUp struct {
+		Enable bool
+
+		// Interval to request signatures.
+		Interval time.Duration
+
+		// Number of peers to request at once, chosen by the stake of their signers.
+		Peers int
+
+		// Maximum number of signatures received from a peer per verse.
+		MaxSignatures int `koanf:"max_signatures"`
+	} `koanf:"catch_up"`
+
+	// Persist the known peers and the DHT records under the datastore directory,
+	// and reconnect to the recently connected peers on startup before the bootnodes.
+	PeerStore struct {
+		Enable bool
+
+		// Maximum number of recently connected peers to remember and reconnect to.
+		ReconnectPeers int `koanf:"reconnect_peers"`
+	} `koanf:"peer_store"`
+
+	// Connection manager, trims the connections down to the low watermark when
+	// exceeding the high watermark. The bootnodes and the relay nodes are never trimmed.
+	ConnManager struct {
+		LowWater  int `koanf:"low_water"`
+		HighWater int `koanf:"high_water"`
+
+		// New connections are not trimmed for this period.
+		GracePeriod time.Duration `koanf:"grace_period"`
+
+		// Additional peers not to be trimmed, e.g. /ip4/1.2.3.4/tcp/4001/p2p/{PeerID}.
+		ProtectedPeers []string `koanf:"protected_peers"`
+	} `koanf:"conn_manager"`
+
+	// Resource manager limits. Zero means the libp2p default scaled to the system resources.
+	ResourceManager struct {
+		// Total memory in bytes, connections and streams of the node.
+		MaxMemory      int64 `koanf:"max_memory"`
+		MaxConnections int   `koanf:"max_connections"`
+		MaxStreams     int   `koanf:"max_streams"`
+
+		// Streams and memory in bytes of each verifier protocol.
+		ProtocolStreams int   `koanf:"protocol_streams"`
+		ProtocolMemory  int64 `koanf:"protocol_memory"`
+	} `koanf:"resource_manager"`
+
+	// Discover and connect to the peers in the local network using mDNS.
+	MDNS struct {
+		Enable bool
+
+		// Only the peers advertising the same service name are discovered.
+		ServiceName string `koanf:"service_name"`
+	} `koanf:"mdns"`
+
+	// Hex-encoded 32 bytes pre-shared key to form a private network with the nodes
+	// having the same key. QUIC and the legacy topic must be disabled.
+	PrivateNetworkKey string `koanf:"private_network_key" validate:"omitempty,hexadecimal,len=64"`
+
+	// Peers to keep connected, redialed with backoff when disconnected.
+	// e.g. /dns4/verifier/tcp/4101/p2p/{PeerID}
+	StaticPeers []string `koanf:"static_peers"`
+
+	// Disconnect the peers running a version older than this, e.g. 1.2.0, and
+	// refuse them for `Misbehaviour.BlockDuration`. The verifier peers not
+	// supporting the node info exchange are regarded as version 0.
+	MinPeerVersion string `koanf:"min_peer_version" validate:"omitempty,semver"`
+
 	// Options for go-libp2p-kad-dht/LanDHT
 	ExperimentalLanDHT struct {
 		Loopback  bool
@@ -374,7 +585,15 @@ type P2P struct {
 }
 
 type IPC struct {
-	// Socket file name, In UNIX-based OS, it is created as /tmp/{sockname}.sock.
+	// Path of the Unix domain socket serving the JSON-RPC admin API.
+	// Default is `{datastore}/oasvlfy.ipc`.
+	Path string
+
+	// Socket file name of the IPC used by the older versions, In UNIX-based OS,
+	// it is created as /tmp/{sockname}.sock. Only the wallet unlock is served
+	// for the transition, and empty disables it.
+	//
+	// Deprecated: will be removed in a future release.
 	Sockname string
 }
 
@@ -455,6 +674,9 @@ type Submitter struct {
 	UseMulticall     bool   `koanf:"use_multicall"`
 	MulticallAddress string `koanf:"multicall_address"`
 
+	// Pack the calls of all verses sharing the same wallet into one multicall transaction.
+	CrossVerseBatching bool `koanf:"cross_verse_batching"`
+
 	// List of verses to submit signatures
 	Targets []*SubmitterTarget `validate:"dive"`
 }
@@ -467,10 +689,10 @@ func (c *Submitter) String() string {
 
 	return fmt.Sprintf("max_workers:%d interval:%s confirmations:%d gas_multiplier:%f"+
 		" max_gas:%d batch_size:%d scc_verifier_address:%s l2oo_verifier_address:%s"+
-		" use_multicall:%v multicall_address:%s targets:[%s]",
+		" use_multicall:%v multicall_address:%s cross_verse_batching:%v targets:[%s]",
 		c.MaxWorkers, c.Interval, c.Confirmations, c.GasMultiplier,
 		c.MaxGas, c.BatchSize, c.SCCVerifierAddress, c.L2OOVerifierAddress,
-		c.UseMulticall, c.MulticallAddress, strings.Join(targets, ","))
+		c.UseMulticall, c.MulticallAddress, c.CrossVerseBatching, strings.Join(targets, ","))
 }
 
 type SubmitterTarget struct {
@@ -522,6 +744,26 @@ type Metrics struct {
 	Prefix string
 }
 
+type StatusAPI struct {
+	// Whether to start the status API server.
+	Enable bool
+
+	// Address and port to listen.
+	Listen string `validate:"hostname_port"`
+
+	// Require the basic authentication if both are set.
+	BasicAuth struct {
+		Username string
+		Password string
+	} `koanf:"basic_auth"`
+}
+
+type Log struct {
+	// One of trace, debug, info, warn and error. If empty, debug when
+	// the `DEBUG` environment variable is set, otherwise info.
+	Level string `validate:"omitempty,oneof=trace debug info warn error"`
+}
+
 type Debug struct {
 	Pprof Pprof
 }
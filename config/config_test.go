@@ -52,6 +52,10 @@ func (s *ConfigTestSuite) TestNewConfig() {
 			- noann0
 		connection_filter:
 			- connfil0
+		transports:
+			websocket:
+				enable: true
+			webtransport: true
 		bootnodes:
 			- /ip4/127.0.0.1/tcp/20002/p2p/12D3KooWCNqRgVdwAhGrurCc8XE4RsWB8S2T83yMZR9R7Gdtf899
 		relay_service:
@@ -67,8 +71,52 @@ func (s *ConfigTestSuite) TestNewConfig() {
 			max_reservations_per_asn: 9
 		relay_client:
 			relay_nodes: ["relay-0", "relay-1"]
+		legacy_topic: false
+		peer_score:
+			enable: false
+			invalid_message_weight: -1
+			invalid_message_decay: 2m
+			ip_colocation_weight: -3
+			ip_colocation_threshold: 4
+			behaviour_penalty_weight: -5
+			behaviour_penalty_decay: 6m
+			gossip_threshold: -7
+			publish_threshold: -8
+			graylist_threshold: -9
+		catch_up:
+			enable: false
+			interval: 1m
+			peers: 2
+			max_signatures: 3
+		peer_store:
+			enable: false
+			reconnect_peers: 4
+		conn_manager:
+			low_water: 1
+			high_water: 2
+			grace_period: 3s
+			protected_peers:
+				- /ip4/127.0.0.1/tcp/20004/p2p/12D3KooWCNqRgVdwAhGrurCc8XE4RsWB8S2T83yMZR9R7Gdtf899
+		resource_manager:
+			max_memory: 1
+			max_connections: 2
+			max_streams: 3
+			protocol_streams: 4
+			protocol_memory: 5
+		mdns:
+			enable: true
+			service_name: test-service
+		private_network_key: 000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f
+		static_peers:
+			- /ip4/127.0.0.1/tcp/20005/p2p/12D3KooWCNqRgVdwAhGrurCc8XE4RsWB8S2T83yMZR9R7Gdtf899
+		min_peer_version: 1.2.0
+		misbehaviour:
+			threshold: 10
+			block_duration: 2h
+			score_decay: 3m
 
 	ipc:
+		path: /tmp/test.ipc
 		sockname: testsock
 
 	verifier:
@@ -96,6 +144,7 @@ func (s *ConfigTestSuite) TestNewConfig() {
 		l2oo_verifier_address: '0x67a16865f03F6d46a206EF894F7A56597E0152b7'
 		use_multicall: true
 		multicall_address: '0x74746c14ABD3b4e8B6317e279E8C9e27D9dA56E5'
+		cross_verse_batching: true
 		targets:
 			- chain_id: 12345
 			  wallet: wallet1
@@ -116,6 +165,16 @@ func (s *ConfigTestSuite) TestNewConfig() {
 		listen: 127.0.0.1:3030
 		endpoint: /testmetrics
 
+	status_api:
+		enable: true
+		listen: 127.0.0.1:4040
+		basic_auth:
+			username: status-username
+			password: status-password
+
+	log:
+		level: debug
+
 	debug:
 		pprof:
 			enable: true
@@ -165,11 +224,23 @@ func (s *ConfigTestSuite) TestNewConfig() {
 			NoAnnounce:       []string{"noann0"},
 			ConnectionFilter: []string{"connfil0"},
 			Transports: struct {
-				TCP  bool
-				QUIC bool
+				TCP       bool
+				QUIC      bool
+				WebSocket struct {
+					Enable   bool
+					CertFile string `koanf:"cert_file" validate:"required_with=KeyFile,omitempty,file"`
+					KeyFile  string `koanf:"key_file" validate:"required_with=CertFile,omitempty,file"`
+				} `koanf:"websocket"`
+				WebTransport bool `koanf:"webtransport"`
 			}{
 				TCP:  true,
 				QUIC: true,
+				WebSocket: struct {
+					Enable   bool
+					CertFile string `koanf:"cert_file" validate:"required_with=KeyFile,omitempty,file"`
+					KeyFile  string `koanf:"key_file" validate:"required_with=CertFile,omitempty,file"`
+				}{Enable: true},
+				WebTransport: true,
 			},
 			Listen: "",
 			Bootnodes: []string{
@@ -215,6 +286,7 @@ func (s *ConfigTestSuite) TestNewConfig() {
 				RelayNodes: []string{"relay-0", "relay-1"},
 			},
 			PublishInterval: 5 * time.Minute,
+			LegacyTopic:     false,
 			StreamTimeout:   10 * time.Second,
 			OutboundLimits: struct {
 				Concurrency int
@@ -224,16 +296,106 @@ func (s *ConfigTestSuite) TestNewConfig() {
 				Throttling:  500,
 			},
 			InboundLimits: struct {
-				Concurrency int
-				Throttling  int
-				MaxSendTime time.Duration "koanf:\"max_send_time\""
+				Concurrency    int
+				Throttling     int
+				MaxSendTime    time.Duration "koanf:\"max_send_time\""
+				PeerThrottling int           "koanf:\"peer_throttling\""
 			}{
-				Concurrency: 10,
-				Throttling:  500,
-				MaxSendTime: 30 * time.Second,
+				Concurrency:    10,
+				Throttling:     500,
+				MaxSendTime:    30 * time.Second,
+				PeerThrottling: 100,
+			},
+			Misbehaviour: struct {
+				Threshold     float64
+				BlockDuration time.Duration "koanf:\"block_duration\""
+				ScoreDecay    time.Duration "koanf:\"score_decay\""
+			}{
+				Threshold:     10,
+				BlockDuration: 2 * time.Hour,
+				ScoreDecay:    3 * time.Minute,
+			},
+			PeerScore: struct {
+				Enable                 bool
+				InvalidMessageWeight   float64       "koanf:\"invalid_message_weight\""
+				InvalidMessageDecay    time.Duration "koanf:\"invalid_message_decay\""
+				IPColocationWeight     float64       "koanf:\"ip_colocation_weight\""
+				IPColocationThreshold  int           "koanf:\"ip_colocation_threshold\""
+				BehaviourPenaltyWeight float64       "koanf:\"behaviour_penalty_weight\""
+				BehaviourPenaltyDecay  time.Duration "koanf:\"behaviour_penalty_decay\""
+				GossipThreshold        float64       "koanf:\"gossip_threshold\""
+				PublishThreshold       float64       "koanf:\"publish_threshold\""
+				GraylistThreshold      float64       "koanf:\"graylist_threshold\""
+			}{
+				Enable:                 false,
+				InvalidMessageWeight:   -1,
+				InvalidMessageDecay:    2 * time.Minute,
+				IPColocationWeight:     -3,
+				IPColocationThreshold:  4,
+				BehaviourPenaltyWeight: -5,
+				BehaviourPenaltyDecay:  6 * time.Minute,
+				GossipThreshold:        -7,
+				PublishThreshold:       -8,
+				GraylistThreshold:      -9,
+			},
+			CatchUp: struct {
+				Enable        bool
+				Interval      time.Duration
+				Peers         int
+				MaxSignatures int "koanf:\"max_signatures\""
+			}{
+				Enable:        false,
+				Interval:      time.Minute,
+				Peers:         2,
+				MaxSignatures: 3,
+			},
+			PeerStore: struct {
+				Enable         bool
+				ReconnectPeers int "koanf:\"reconnect_peers\""
+			}{
+				Enable:         false,
+				ReconnectPeers: 4,
 			},
+			ConnManager: struct {
+				LowWater       int           "koanf:\"low_water\""
+				HighWater      int           "koanf:\"high_water\""
+				GracePeriod    time.Duration "koanf:\"grace_period\""
+				ProtectedPeers []string      "koanf:\"protected_peers\""
+			}{
+				LowWater:    1,
+				HighWater:   2,
+				GracePeriod: 3 * time.Second,
+				ProtectedPeers: []string{
+					"/ip4/127.0.0.1/tcp/20004/p2p/12D3KooWCNqRgVdwAhGrurCc8XE4RsWB8S2T83yMZR9R7Gdtf899",
+				},
+			},
+			ResourceManager: struct {
+				MaxMemory       int64 "koanf:\"max_memory\""
+				MaxConnections  int   "koanf:\"max_connections\""
+				MaxStreams      int   "koanf:\"max_streams\""
+				ProtocolStreams int   "koanf:\"protocol_streams\""
+				ProtocolMemory  int64 "koanf:\"protocol_memory\""
+			}{
+				MaxMemory:       1,
+				MaxConnections:  2,
+				MaxStreams:      3,
+				ProtocolStreams: 4,
+				ProtocolMemory:  5,
+			},
+			MDNS: struct {
+				Enable      bool
+				ServiceName string "koanf:\"service_name\""
+			}{
+				Enable:      true,
+				ServiceName: "test-service",
+			},
+			PrivateNetworkKey: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+			StaticPeers: []string{
+				"/ip4/127.0.0.1/tcp/20005/p2p/12D3KooWCNqRgVdwAhGrurCc8XE4RsWB8S2T83yMZR9R7Gdtf899",
+			},
+			MinPeerVersion: "1.2.0",
 		},
-		IPC: IPC{Sockname: "testsock"},
+		IPC: IPC{Path: "/tmp/test.ipc", Sockname: "testsock"},
 		Verifier: Verifier{
 			Enable:                true,
 			Wallet:                "wallet1",
@@ -259,6 +421,7 @@ func (s *ConfigTestSuite) TestNewConfig() {
 			L2OOVerifierAddress: "0x67a16865f03F6d46a206EF894F7A56597E0152b7",
 			UseMulticall:        true,
 			MulticallAddress:    "0x74746c14ABD3b4e8B6317e279E8C9e27D9dA56E5",
+			CrossVerseBatching:  true,
 			Targets: []*SubmitterTarget{
 				{
 					ChainID: 12345,
@@ -282,6 +445,20 @@ func (s *ConfigTestSuite) TestNewConfig() {
 			Listen:   "127.0.0.1:3030",
 			Endpoint: "/testmetrics",
 		},
+		StatusAPI: StatusAPI{
+			Enable: true,
+			Listen: "127.0.0.1:4040",
+			BasicAuth: struct {
+				Username string
+				Password string
+			}{
+				Username: "status-username",
+				Password: "status-password",
+			},
+		},
+		Log: Log{
+			Level: "debug",
+		},
 		Debug: Debug{
 			Pprof: Pprof{
 				Enable: true,
@@ -320,6 +497,11 @@ func (s *ConfigTestSuite) TestValidate() {
 			password: passw0rd
 	p2p:
 		listen: xxx
+		private_network_key: xxx
+		min_peer_version: xxx
+		transports:
+			websocket:
+				cert_file: xxx
 	verifier:
 		enable: true
 	submitter:
@@ -327,6 +509,10 @@ func (s *ConfigTestSuite) TestValidate() {
 			- {}
 	metrics:
 		listen: xxx
+	status_api:
+		listen: xxx
+	log:
+		level: xxx
 	`)
 
 	wants := map[string]string{
@@ -341,10 +527,16 @@ func (s *ConfigTestSuite) TestValidate() {
 		"Config.verse_layer.directs[0].rpc":                "url",
 		"Config.verse_layer.directs[0].l1_contracts[test]": "hexadecimal",
 		"Config.p2p.listen":                                "hostname_port",
+		"Config.p2p.private_network_key":                   "hexadecimal",
+		"Config.p2p.min_peer_version":                      "semver",
+		"Config.p2p.transports.websocket.cert_file":        "file",
+		"Config.p2p.transports.websocket.key_file":         "required_with",
 		"Config.verifier.wallet":                           "required_if",
 		"Config.submitter.targets[0].chain_id":             "required",
 		"Config.submitter.targets[0].wallet":               "required",
 		"Config.metrics.listen":                            "hostname_port",
+		"Config.status_api.listen":                         "hostname_port",
+		"Config.log.level":                                 "oneof",
 	}
 
 	_, err := NewConfig(s.toBytes(input), false)
@@ -360,6 +552,50 @@ func (s *ConfigTestSuite) TestValidate() {
 	}
 }
 
+func (s *ConfigTestSuite) TestValidateBootnode() {
+	input := (`
+	keystore: /xxx
+	wallets:
+		wallet1:
+			address: xxx
+	p2p:
+		listen: xxx
+	verifier:
+		enable: true
+	metrics:
+		listen: xxx
+	`)
+
+	wants := map[string]string{
+		"Config.datastore":      "dir",
+		"Config.p2p.listen":     "hostname_port",
+		"Config.metrics.listen": "hostname_port",
+	}
+
+	_, err := NewBootnodeConfig(s.toBytes(input))
+
+	gots := map[string]string{}
+	for _, e := range err.(validator.ValidationErrors) {
+		gots[e.Namespace()] = e.Tag()
+	}
+
+	s.Len(gots, len(wants))
+	for field := range wants {
+		s.Equal(wants[field], gots[field])
+	}
+
+	// the hub_layer is not required
+	input = (`
+	datastore: /tmp
+	p2p:
+		listens:
+			- /ip4/0.0.0.0/tcp/4101
+	`)
+	got, err := NewBootnodeConfig(s.toBytes(input))
+	s.NoError(err)
+	s.Equal([]string{"/ip4/0.0.0.0/tcp/4101"}, got.P2P.Listens)
+}
+
 func (s *ConfigTestSuite) TestDefaultValues() {
 	input := (`
 	datastore: /tmp
@@ -398,17 +634,49 @@ func (s *ConfigTestSuite) TestDefaultValues() {
 	}, got.P2P.ConnectionFilter)
 	s.Equal(true, got.P2P.Transports.TCP)
 	s.Equal(true, got.P2P.Transports.QUIC)
+	s.Equal(false, got.P2P.Transports.WebSocket.Enable)
+	s.Equal(false, got.P2P.Transports.WebTransport)
 	s.Equal(true, got.P2P.NAT.UPnP)
 	s.Equal(true, got.P2P.NAT.AutoNAT)
 	s.Equal(true, got.P2P.NAT.HolePunch)
 	s.Equal(5*time.Minute, got.P2P.PublishInterval)
+	s.True(got.P2P.LegacyTopic)
 	s.Equal(10*time.Second, got.P2P.StreamTimeout)
 	s.Equal(10, got.P2P.OutboundLimits.Concurrency)
 	s.Equal(500, got.P2P.OutboundLimits.Throttling)
 	s.Equal(10, got.P2P.InboundLimits.Concurrency)
 	s.Equal(500, got.P2P.InboundLimits.Throttling)
 	s.Equal(30*time.Second, got.P2P.InboundLimits.MaxSendTime)
-
+	s.Equal(100, got.P2P.InboundLimits.PeerThrottling)
+	s.Equal(100.0, got.P2P.Misbehaviour.Threshold)
+	s.Equal(time.Hour, got.P2P.Misbehaviour.BlockDuration)
+	s.Equal(10*time.Minute, got.P2P.Misbehaviour.ScoreDecay)
+	s.True(got.P2P.PeerScore.Enable)
+	s.Equal(-100.0, got.P2P.PeerScore.InvalidMessageWeight)
+	s.Equal(time.Hour, got.P2P.PeerScore.InvalidMessageDecay)
+	s.Equal(-10.0, got.P2P.PeerScore.IPColocationWeight)
+	s.Equal(5, got.P2P.PeerScore.IPColocationThreshold)
+	s.Equal(-10.0, got.P2P.PeerScore.BehaviourPenaltyWeight)
+	s.Equal(time.Hour, got.P2P.PeerScore.BehaviourPenaltyDecay)
+	s.Equal(-100.0, got.P2P.PeerScore.GossipThreshold)
+	s.Equal(-500.0, got.P2P.PeerScore.PublishThreshold)
+	s.Equal(-1000.0, got.P2P.PeerScore.GraylistThreshold)
+	s.True(got.P2P.CatchUp.Enable)
+	s.Equal(10*time.Minute, got.P2P.CatchUp.Interval)
+	s.Equal(3, got.P2P.CatchUp.Peers)
+	s.Equal(5000, got.P2P.CatchUp.MaxSignatures)
+	s.True(got.P2P.PeerStore.Enable)
+	s.Equal(50, got.P2P.PeerStore.ReconnectPeers)
+	s.Equal(160, got.P2P.ConnManager.LowWater)
+	s.Equal(192, got.P2P.ConnManager.HighWater)
+	s.Equal(time.Minute, got.P2P.ConnManager.GracePeriod)
+	s.Equal(int64(0), got.P2P.ResourceManager.MaxMemory)
+	s.Equal(0, got.P2P.ResourceManager.ProtocolStreams)
+	s.False(got.P2P.MDNS.Enable)
+	s.Equal("oasys-optimism-verifier", got.P2P.MDNS.ServiceName)
+
+	s.Equal("", got.IPC.Path)
+	s.Equal("/tmp/oasvlfy.ipc", got.IPCPath())
 	s.Equal("oasvlfy", got.IPC.Sockname)
 
 	s.Equal(10, got.Verifier.MaxWorkers)
@@ -431,6 +699,7 @@ func (s *ConfigTestSuite) TestDefaultValues() {
 	s.Equal("0xF62fD2d4ef5a99C5bAa1effd0dc20889c5021E1c", got.Submitter.L2OOVerifierAddress)
 	s.Equal(true, got.Submitter.UseMulticall)
 	s.Equal("0x5200000000000000000000000000000000000022", got.Submitter.MulticallAddress)
+	s.False(got.Submitter.CrossVerseBatching)
 
 	s.True(got.Beacon.Enable)
 	s.Equal(
@@ -447,6 +716,13 @@ func (s *ConfigTestSuite) TestDefaultValues() {
 	s.Equal("127.0.0.1:9200", got.Metrics.Listen)
 	s.Equal("/metrics", got.Metrics.Endpoint)
 
+	s.False(got.StatusAPI.Enable)
+	s.Equal("127.0.0.1:9300", got.StatusAPI.Listen)
+	s.Equal("", got.StatusAPI.BasicAuth.Username)
+	s.Equal("", got.StatusAPI.BasicAuth.Password)
+
+	s.Equal("", got.Log.Level)
+
 	s.Equal("127.0.0.1:6060", got.Debug.Pprof.Listen)
 	s.Equal("username", got.Debug.Pprof.BasicAuth.Username)
 	s.Equal("password", got.Debug.Pprof.BasicAuth.Password)
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Fields applied to the running node without restarting. The wallets
+// are not listed, because they can be added or removed but not changed.
+var reloadableFields = []string{
+	"verse_layer.directs",
+
+	"verifier.max_workers",
+	"verifier.interval",
+	"verifier.state_collect_limit",
+	"verifier.state_collect_timeout",
+	"verifier.confirmations",
+	"verifier.max_log_fetch_block_range",
+	"verifier.max_index_diff",
+	"verifier.max_retry_backoff",
+	"verifier.retry_timeout",
+
+	"submitter.max_workers",
+	"submitter.interval",
+	"submitter.confirmations",
+	"submitter.gas_multiplier",
+	"submitter.batch_size",
+	"submitter.max_gas",
+	"submitter.use_multicall",
+	"submitter.multicall_address",
+	"submitter.cross_verse_batching",
+	"submitter.targets",
+
+	"log.level",
+}
+
+// Returns the fields that differ between the two configurations in
+// the notation of the configuration file, e.g. `p2p.listens`, `wallets.name`.
+func Diff(a, b *Config) []string {
+	var diffs []string
+	diff(reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem(), "", &diffs)
+	sort.Strings(diffs)
+	return diffs
+}
+
+func diff(a, b reflect.Value, path string, diffs *[]string) {
+	join := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return path + "." + name
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			diff(a.Field(i), b.Field(i), join(fieldName(a.Type().Field(i))), diffs)
+		}
+	case reflect.Map:
+		keys := map[string]reflect.Value{}
+		for _, key := range append(a.MapKeys(), b.MapKeys()...) {
+			keys[fmt.Sprint(key.Interface())] = key
+		}
+		for name, key := range keys {
+			av, bv := a.MapIndex(key), b.MapIndex(key)
+			if !av.IsValid() || !bv.IsValid() || !reflect.DeepEqual(av.Interface(), bv.Interface()) {
+				*diffs = append(*diffs, join(name))
+			}
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*diffs = append(*diffs, path)
+		}
+	}
+}
+
+// Returns the changed fields if all of them can be applied to the running
+// node, otherwise returns an error listing the fields requiring a restart.
+func CheckReloadable(old, new *Config) ([]string, error) {
+	changed := Diff(old, new)
+
+	var rejects []string
+	for _, path := range changed {
+		if name, ok := strings.CutPrefix(path, "wallets."); ok {
+			_, inOld := old.Wallets[name]
+			_, inNew := new.Wallets[name]
+			if inOld && inNew {
+				rejects = append(rejects, path)
+			}
+		} else if !slices.Contains(reloadableFields, path) {
+			rejects = append(rejects, path)
+		}
+	}
+	if len(rejects) > 0 {
+		return nil, fmt.Errorf("restart is required to change %s", strings.Join(rejects, ", "))
+	}
+	return changed, nil
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"time"
+)
+
+func (s *ConfigTestSuite) newReloadConfig() *Config {
+	input := (`
+	datastore: /tmp
+	wallets:
+		wallet1:
+			address: '0xBA3186c30Bb0d9e8c7924147238F82617C3fE729'
+	hub_layer:
+		chain_id: 12345
+		rpc: http://127.0.0.1:8545/
+	verse_layer:
+		directs:
+			- chain_id: 12345
+			  rpc: http://127.0.0.1:8545/
+			  l1_contracts:
+			    StateCommitmentChain: '0x01'
+	p2p:
+		listens:
+			- /ip4/0.0.0.0/tcp/4101
+	verifier:
+		enable: true
+		wallet: wallet1
+	submitter:
+		enable: true
+		targets:
+			- chain_id: 12345
+			  wallet: wallet1
+	`)
+
+	conf, err := NewConfig(s.toBytes(input), false)
+	s.Require().NoError(err)
+	return conf
+}
+
+func (s *ConfigTestSuite) TestDiff() {
+	a, b := s.newReloadConfig(), s.newReloadConfig()
+	s.Empty(Diff(a, b))
+
+	b.P2P.Listens = append(b.P2P.Listens, "/ip4/0.0.0.0/udp/4101/quic-v1")
+	b.Verifier.Interval = time.Minute
+	b.VerseLayer.Directs[0].RPC = "http://127.0.0.1:8546/"
+	b.Wallets["wallet1"].Plain = "0x01"
+	b.Wallets["wallet2"] = &Wallet{Address: "0x02"}
+	b.Debug.Pprof.BasicAuth.Username = "xxx"
+
+	s.Equal([]string{
+		"debug.pprof.basic_auth.username",
+		"p2p.listens",
+		"verifier.interval",
+		"verse_layer.directs",
+		"wallets.wallet1",
+		"wallets.wallet2",
+	}, Diff(a, b))
+}
+
+func (s *ConfigTestSuite) TestCheckReloadable() {
+	cases := []struct {
+		name    string
+		modify  func(c *Config)
+		changed []string
+		err     string
+	}{
+		{
+			name:   "no changes",
+			modify: func(c *Config) {},
+		},
+		{
+			name: "reloadable",
+			modify: func(c *Config) {
+				c.VerseLayer.Directs = append(c.VerseLayer.Directs, &Verse{ChainID: 2})
+				c.Verifier.MaxWorkers = 1
+				c.Submitter.Interval = time.Minute
+				c.Submitter.Targets = append(c.Submitter.Targets, &SubmitterTarget{ChainID: 2, Wallet: "wallet2"})
+				c.Wallets["wallet2"] = &Wallet{Address: "0x02"}
+				c.Log.Level = "debug"
+			},
+			changed: []string{
+				"log.level",
+				"submitter.interval",
+				"submitter.targets",
+				"verifier.max_workers",
+				"verse_layer.directs",
+				"wallets.wallet2",
+			},
+		},
+		{
+			name:    "wallet removed",
+			modify:  func(c *Config) { delete(c.Wallets, "wallet1") },
+			changed: []string{"wallets.wallet1"},
+		},
+		{
+			name: "non-reloadable",
+			modify: func(c *Config) {
+				c.Verifier.MaxWorkers = 1
+				c.Verifier.Wallet = "wallet2"
+				c.Submitter.Enable = false
+				c.HubLayer.RPC = "http://127.0.0.1:8546/"
+			},
+			err: "restart is required to change hub_layer.rpc, submitter.enable, verifier.wallet",
+		},
+		{
+			name:   "wallet changed",
+			modify: func(c *Config) { c.Wallets["wallet1"].Password = "/tmp/password" },
+			err:    "restart is required to change wallets.wallet1",
+		},
+	}
+
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			old, new := s.newReloadConfig(), s.newReloadConfig()
+			tc.modify(new)
+
+			changed, err := CheckReloadable(old, new)
+			if tc.err != "" {
+				s.EqualError(err, tc.err)
+				s.Nil(changed)
+			} else {
+				s.NoError(err)
+				s.Equal(tc.changed, changed)
+			}
+		})
+	}
+}
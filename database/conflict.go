@@ -0,0 +1,183 @@
+package database
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm/clause"
+)
+
+type ConflictDB db
+
+// Save the evidence that the signer of `local` has also signed a different message.
+// Returns false if the evidence has already been saved, unless the saved
+// evidence was suspected and is now confirmed.
+func (db *ConflictDB) SaveEquivocation(
+	local *OptimismSignature,
+	rollupHash common.Hash,
+	approved bool,
+	signature Signature,
+	confirmed bool,
+) (bool, error) {
+	row := &Equivocation{
+		SignerID:         local.Signer.ID,
+		ContractID:       local.Contract.ID,
+		RollupIndex:      local.RollupIndex,
+		FirstSignatureID: local.ID,
+		FirstRollupHash:  local.RollupHash,
+		FirstApproved:    local.Approved,
+		FirstSignature:   local.Signature,
+		SecondRollupHash: rollupHash,
+		SecondApproved:   approved,
+		SecondSignature:  signature,
+		Confirmed:        confirmed,
+	}
+	tx := db.rawdb.Clauses(clause.OnConflict{DoNothing: true}).Create(row)
+	if tx.Error != nil {
+		return false, tx.Error
+	} else if tx.RowsAffected > 0 || !confirmed {
+		return tx.RowsAffected > 0, nil
+	}
+
+	tx = db.rawdb.Model(&Equivocation{}).
+		Where("signer_id = ? AND contract_id = ? AND rollup_index = ?",
+			row.SignerID, row.ContractID, row.RollupIndex).
+		Where("confirmed = ?", false).
+		Updates(map[string]interface{}{
+			"first_signature_id": local.ID,
+			"first_rollup_hash":  local.RollupHash,
+			"first_approved":     local.Approved,
+			"first_signature":    local.Signature,
+			"second_rollup_hash": rollupHash,
+			"second_approved":    approved,
+			"second_signature":   signature,
+			"confirmed":          true,
+		})
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+// Return the evidence of the signer for the specific rollup.
+func (db *ConflictDB) FindEquivocation(
+	signer common.Address,
+	contract common.Address,
+	rollupIndex uint64,
+) (*Equivocation, error) {
+	var row Equivocation
+	tx := db.rawdb.
+		Joins("Signer").
+		Joins("Contract").
+		Where("Signer.address = ? AND Contract.address = ?", signer, contract).
+		Where("equivocations.rollup_index = ?", rollupIndex).
+		First(&row)
+
+	if err := errconv(tx.Error); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+func (db *ConflictDB) FindEquivocations(
+	signer *common.Address,
+	contract *common.Address,
+	limit, offset int,
+) ([]*Equivocation, error) {
+	tx := db.rawdb.
+		Joins("Signer").
+		Joins("Contract").
+		Order("equivocations.id DESC").
+		Limit(limit).
+		Offset(offset)
+
+	if signer != nil {
+		tx = tx.Where("Signer.address = ?", *signer)
+	}
+	if contract != nil {
+		tx = tx.Where("Contract.address = ?", *contract)
+	}
+
+	var rows []*Equivocation
+	if tx = tx.Find(&rows); tx.Error != nil {
+		return nil, tx.Error
+	}
+	return rows, nil
+}
+
+// Save a group of signatures that voted for the same rollup hash and approval.
+// Returns true if the group is newly saved or its stake amount has changed.
+func (db *ConflictDB) SaveVoteConflict(
+	contract common.Address,
+	rollupIndex uint64,
+	rollupHash common.Hash,
+	approved bool,
+	stake *big.Int,
+	signers uint64,
+) (bool, error) {
+	_contract, err := db.db.OPContract.FindOrCreate(contract)
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		row     VoteConflict
+		changed bool
+	)
+	tx := db.rawdb.
+		Where("contract_id = ? AND rollup_index = ?", _contract.ID, rollupIndex).
+		Where("rollup_hash = ? AND approved = ?", rollupHash, approved).
+		Limit(1).
+		Find(&row)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		row = VoteConflict{
+			ContractID:  _contract.ID,
+			RollupIndex: rollupIndex,
+			RollupHash:  rollupHash,
+			Approved:    approved,
+		}
+		changed = true
+	} else if row.Stake != stake.String() || row.Signers != signers {
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+
+	row.Stake = stake.String()
+	row.Signers = signers
+	if err := db.rawdb.Save(&row).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (db *ConflictDB) FindVoteConflicts(
+	contract *common.Address,
+	rollupIndex *uint64,
+	limit, offset int,
+) ([]*VoteConflict, error) {
+	tx := db.rawdb.
+		Joins("Contract").
+		Order("vote_conflicts.rollup_index DESC").
+		Order("vote_conflicts.id").
+		Limit(limit).
+		Offset(offset)
+
+	if contract != nil {
+		tx = tx.Where("Contract.address = ?", *contract)
+	}
+	if rollupIndex != nil {
+		tx = tx.Where("vote_conflicts.rollup_index = ?", *rollupIndex)
+	}
+
+	var rows []*VoteConflict
+	if tx = tx.Find(&rows); tx.Error != nil {
+		return nil, tx.Error
+	}
+	return rows, nil
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestConflictDB(t *testing.T) {
+	suite.Run(t, new(ConflictDBTestSuite))
+}
+
+type ConflictDBTestSuite struct {
+	DatabaseTestSuite
+
+	db *ConflictDB
+}
+
+func (s *ConflictDBTestSuite) SetupTest() {
+	s.DatabaseTestSuite.SetupTest()
+	s.db = s.DatabaseTestSuite.db.Conflict
+}
+
+func (s *ConflictDBTestSuite) TestSaveEquivocation() {
+	signer0, signer1 := s.createSigner(), s.createSigner()
+	contract := s.createContract()
+	local0 := s.createSignature(signer0, contract, 0)
+	local1 := s.createSignature(signer1, contract, 0)
+
+	hash, sig := s.RandHash(), RandSignature()
+
+	created, err := s.db.SaveEquivocation(local0, hash, true, sig, true)
+	s.NoError(err)
+	s.True(created)
+
+	// duplicated
+	created, err = s.db.SaveEquivocation(local0, s.RandHash(), false, RandSignature(), true)
+	s.NoError(err)
+	s.False(created)
+
+	// suspected
+	created, err = s.db.SaveEquivocation(local1, s.RandHash(), false, RandSignature(), false)
+	s.NoError(err)
+	s.True(created)
+	created, err = s.db.SaveEquivocation(local1, s.RandHash(), false, RandSignature(), false)
+	s.NoError(err)
+	s.False(created)
+
+	// find all
+	gots, err := s.db.FindEquivocations(nil, nil, 10, 0)
+	s.NoError(err)
+	s.Len(gots, 2)
+
+	// find by signer
+	gots, err = s.db.FindEquivocations(&signer0.Address, &contract.Address, 10, 0)
+	s.NoError(err)
+	s.Len(gots, 1)
+	s.Equal(signer0.Address, gots[0].Signer.Address)
+	s.Equal(contract.Address, gots[0].Contract.Address)
+	s.Equal(local0.ID, gots[0].FirstSignatureID)
+	s.Equal(local0.RollupHash, gots[0].FirstRollupHash)
+	s.Equal(local0.Signature, gots[0].FirstSignature)
+	s.Equal(hash, gots[0].SecondRollupHash)
+	s.True(gots[0].SecondApproved)
+	s.Equal(sig, gots[0].SecondSignature)
+	s.True(gots[0].Confirmed)
+
+	// find the specific rollup
+	got, err := s.db.FindEquivocation(signer1.Address, contract.Address, 0)
+	s.NoError(err)
+	s.Equal(local1.ID, got.FirstSignatureID)
+	s.False(got.Confirmed)
+
+	_, err = s.db.FindEquivocation(signer1.Address, contract.Address, 1)
+	s.ErrorIs(err, ErrNotFound)
+
+	// the suspected evidence is confirmed
+	gots, _ = s.db.FindEquivocations(&signer1.Address, nil, 10, 0)
+	s.False(gots[0].Confirmed)
+
+	hash, sig = s.RandHash(), RandSignature()
+	created, err = s.db.SaveEquivocation(local1, hash, true, sig, true)
+	s.NoError(err)
+	s.True(created)
+	created, err = s.db.SaveEquivocation(local1, s.RandHash(), true, RandSignature(), true)
+	s.NoError(err)
+	s.False(created)
+
+	gots, _ = s.db.FindEquivocations(&signer1.Address, nil, 10, 0)
+	s.Len(gots, 1)
+	s.True(gots[0].Confirmed)
+	s.Equal(hash, gots[0].SecondRollupHash)
+	s.Equal(sig, gots[0].SecondSignature)
+}
+
+func (s *ConflictDBTestSuite) TestSaveVoteConflict() {
+	contract0, contract1 := s.RandAddress(), s.RandAddress()
+	hash0, hash1 := s.RandHash(), s.RandHash()
+
+	changed, err := s.db.SaveVoteConflict(contract0, 5, hash0, true, big.NewInt(100), 2)
+	s.NoError(err)
+	s.True(changed)
+
+	changed, err = s.db.SaveVoteConflict(contract0, 5, hash1, false, big.NewInt(50), 1)
+	s.NoError(err)
+	s.True(changed)
+
+	// unchanged
+	changed, err = s.db.SaveVoteConflict(contract0, 5, hash0, true, big.NewInt(100), 2)
+	s.NoError(err)
+	s.False(changed)
+
+	// stake increased
+	changed, err = s.db.SaveVoteConflict(contract0, 5, hash0, true, big.NewInt(150), 3)
+	s.NoError(err)
+	s.True(changed)
+
+	changed, err = s.db.SaveVoteConflict(contract1, 6, hash0, false, big.NewInt(10), 1)
+	s.NoError(err)
+	s.True(changed)
+
+	gots, err := s.db.FindVoteConflicts(nil, nil, 10, 0)
+	s.NoError(err)
+	s.Len(gots, 3)
+
+	index := uint64(5)
+	gots, err = s.db.FindVoteConflicts(&contract0, &index, 10, 0)
+	s.NoError(err)
+	s.Len(gots, 2)
+	s.Equal(hash0, gots[0].RollupHash)
+	s.True(gots[0].Approved)
+	s.Equal("150", gots[0].Stake)
+	s.Equal(uint64(3), gots[0].Signers)
+	s.Equal(hash1, gots[1].RollupHash)
+	s.False(gots[1].Approved)
+	s.Equal("50", gots[1].Stake)
+	s.Equal(uint64(1), gots[1].Signers)
+}
@@ -20,6 +20,9 @@ var (
 		&OpstackProposal{},
 		&OptimismSignature{},
 		&Misc{},
+		&Equivocation{},
+		&VoteConflict{},
+		&Submission{},
 	}
 )
 
@@ -30,6 +33,8 @@ type Database struct {
 	Signer      *SignerDB
 	OPContract  *OptimismContractDB
 	OPSignature *OptimismSignatureDB
+	Conflict    *ConflictDB
+	Submission  *SubmissionDB
 }
 
 type db struct {
@@ -78,6 +83,8 @@ func newDB(rawdb *gorm.DB) *Database {
 		Signer:      &SignerDB{rawdb: rawdb, db: &db},
 		OPContract:  &OptimismContractDB{rawdb: rawdb, db: &db},
 		OPSignature: &OptimismSignatureDB{rawdb: rawdb, db: &db},
+		Conflict:    &ConflictDB{rawdb: rawdb, db: &db},
+		Submission:  &SubmissionDB{rawdb: rawdb, db: &db},
 	}
 	return &db
 }
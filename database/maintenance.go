@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	// Number of pages copied per backup step, the lock of the source
+	// database is released between the steps so that the node can write.
+	backupPagesPerStep = 1024
+	backupStepInterval = 10 * time.Millisecond
+)
+
+// Copy the database to `dst` using the SQLite online backup API,
+// it is safe to call while other processes are writing to the database.
+func (db *Database) Backup(ctx context.Context, dst string) error {
+	if _, err := os.Stat(dst); err == nil {
+		return fmt.Errorf("destination already exists: %s", dst)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	srcdb, err := db.rawdb.DB()
+	if err != nil {
+		return err
+	}
+	srcConn, err := srcdb.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+
+	dstdb, err := sql.Open("sqlite3", dst)
+	if err != nil {
+		return err
+	}
+	defer dstdb.Close()
+	dstConn, err := dstdb.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer dstConn.Close()
+
+	return dstConn.Raw(func(dstRaw any) error {
+		return srcConn.Raw(func(srcRaw any) error {
+			bk, err := dstRaw.(*sqlite3.SQLiteConn).Backup("main", srcRaw.(*sqlite3.SQLiteConn), "main")
+			if err != nil {
+				return err
+			}
+			defer bk.Close()
+
+			for {
+				// `Step` restarts the copy if the source was modified by other connections
+				if done, err := bk.Step(backupPagesPerStep); err != nil {
+					return err
+				} else if done {
+					return bk.Finish()
+				}
+
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(backupStepInterval):
+				}
+			}
+		})
+	})
+}
+
+// Rebuild the database file to reclaim the space of the deleted records.
+// Note that an exclusive lock is required, so the node should be stopped.
+func (db *Database) Vacuum() error {
+	return db.rawdb.Exec("VACUUM").Error
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMaintenance(t *testing.T) {
+	suite.Run(t, new(MaintenanceTestSuite))
+}
+
+type MaintenanceTestSuite struct {
+	DatabaseTestSuite
+}
+
+func (s *MaintenanceTestSuite) TestBackup() {
+	signer := s.createSigner()
+	contract := s.createContract()
+	sigs := make([]*OptimismSignature, 10)
+	for i := range sigs {
+		sigs[i] = s.createSignature(signer, contract, i)
+	}
+
+	dst := filepath.Join(s.T().TempDir(), "backup.db")
+	s.NoError(s.db.Backup(context.Background(), dst))
+
+	// refuse to overwrite
+	s.ErrorContains(s.db.Backup(context.Background(), dst), "already exists")
+
+	backup, err := NewDatabase(&config.Database{Path: dst})
+	s.Require().NoError(err)
+	gots, _ := backup.OPSignature.Find(nil, nil, nil, nil, 100, 0)
+	s.Len(gots, len(sigs))
+	for i, got := range gots {
+		s.Equal(sigs[i].ID, got.ID)
+		s.Equal(sigs[i].Signature, got.Signature)
+	}
+}
+
+func (s *MaintenanceTestSuite) TestVacuum() {
+	s.NoError(s.db.Vacuum())
+}
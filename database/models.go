@@ -1,6 +1,8 @@
 package database
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -89,6 +91,86 @@ func (OptimismSignature) TableName() string {
 	return "optimism_signatures"
 }
 
+// Model representing evidence that a signer has signed
+// two different messages for the same rollup.
+type Equivocation struct {
+	ID uint64 `gorm:"primarykey"`
+
+	SignerID uint64 `gorm:"uniqueIndex:equivocation_idx0,priority:1"`
+	Signer   Signer
+
+	ContractID uint64 `gorm:"uniqueIndex:equivocation_idx0,priority:2"`
+	Contract   OptimismContract
+
+	RollupIndex uint64 `gorm:"uniqueIndex:equivocation_idx0,priority:3"`
+
+	// The signature that was stored locally.
+	FirstSignatureID string
+	FirstRollupHash  common.Hash
+	FirstApproved    bool
+	FirstSignature   Signature
+
+	// The conflicting signature that arrived later.
+	SecondRollupHash common.Hash
+	SecondApproved   bool
+	SecondSignature  Signature
+
+	// False if the conflict may have been caused by re-signing after a
+	// reorg, i.e. the rollup hash on the L1 could not be checked.
+	Confirmed bool
+
+	CreatedAt time.Time
+}
+
+// Model representing one side of a split vote for the same rollup.
+type VoteConflict struct {
+	ID uint64 `gorm:"primarykey"`
+
+	ContractID uint64 `gorm:"uniqueIndex:vote_conflict_idx0,priority:1"`
+	Contract   OptimismContract
+
+	RollupIndex uint64      `gorm:"uniqueIndex:vote_conflict_idx0,priority:2"`
+	RollupHash  common.Hash `gorm:"uniqueIndex:vote_conflict_idx0,priority:3"`
+	Approved    bool        `gorm:"uniqueIndex:vote_conflict_idx0,priority:4"`
+
+	Stake   string // total stake amount of the signers in wei
+	Signers uint64 // number of signers
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Model representing a verify transaction sent by the submitter.
+// A multicall transaction for multiple verses is saved as one row per verse.
+type Submission struct {
+	ID uint64 `gorm:"primarykey"`
+
+	TxHash common.Hash `gorm:"uniqueIndex:submission_idx0,priority:1"`
+	Nonce  uint64
+
+	ContractID uint64 `gorm:"uniqueIndex:submission_idx0,priority:2"`
+	Contract   OptimismContract
+	ChainID    uint64 `gorm:"index:submission_idx1,priority:1"`
+
+	// Range of the rollup indexes verified by the transaction.
+	FirstIndex uint64
+	LastIndex  uint64
+
+	// Comma separated addresses of the signers included in the transaction.
+	Signers string
+
+	// Gas of the transaction. For a transaction verifying multiple verses,
+	// the gas is split among them by the number of calls so that the sum
+	// over the rows of the transaction equals the gas of the transaction.
+	GasLimit       uint64
+	GasUsed        uint64
+	EffectivePrice string // effective gas price in wei
+	Status         uint64 // receipt status, or `SubmissionPending` until received, or `SubmissionUnknown`
+	RevertReason   string
+
+	CreatedAt time.Time `gorm:"index:submission_idx1,priority:2"`
+}
+
 // Model for storing miscellaneous data.
 type Misc struct {
 	ID    string `gorm:"primarykey"`
@@ -43,6 +43,7 @@ type IOPEventDB interface {
 	) ([]OPEvent, error)
 	Save(contract common.Address, e any) (OPEvent, error)
 	Deletes(contract common.Address, rollupIndex uint64) (int64, error)
+	DeleteOlds(contract common.Address, rollupIndex uint64, limit int) (int64, error)
 }
 
 func NewOPEventDB[T any, PT OPEventConstraint[T]](db *Database) IOPEventDB {
@@ -176,6 +177,41 @@ func (db *OPEventDB[T, PT]) Deletes(contract common.Address, rollupIndex uint64)
 	return affected, nil
 }
 
+// Delete events before the specified rollup index(inclusive).
+func (db *OPEventDB[T, PT]) DeleteOlds(contract common.Address, rollupIndex uint64, limit int) (int64, error) {
+	_contract, err := db.db.OPContract.FindOrCreate(contract)
+	if err != nil {
+		return -1, err
+	}
+
+	var affected int64
+	err = db.db.rawdb.Transaction(func(s *gorm.DB) error {
+		var ids []uint64
+		tx := s.
+			Model(db.m).
+			Where(fmt.Sprintf("%s = ?", db.m.contractCol()), _contract.ID).
+			Where(fmt.Sprintf("%s <= ?", db.m.rollupIndexCol()), rollupIndex).
+			Limit(limit).
+			Pluck(db.m.idCol(), &ids)
+		if tx.Error != nil {
+			return tx.Error
+		}
+
+		tx = s.Where("id IN (?)", ids).Delete(db.m)
+		if tx.Error != nil {
+			return tx.Error
+		}
+
+		affected = tx.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return affected, nil
+}
+
 func NewMessage(event OPEvent, l1ChainID *big.Int, approved bool) *ethutil.Message {
 	return ethutil.NewMessage(
 		l1ChainID,
@@ -172,3 +172,36 @@ func (s *OpstackProposalDBTestSuite) TestDeletes() {
 	assert(l2oo0, s.Range(0, 3))
 	assert(l2oo1, s.Range(0, 6))
 }
+
+func (s *OpstackProposalDBTestSuite) TestDeleteOlds() {
+	assert := func(contract *OptimismContract, want []int) {
+		var gots []int
+		s.DatabaseTestSuite.db.rawdb.Model(&OpstackProposal{}).
+			Where("contract_id = ?", contract.ID).
+			Order("l2_output_index").
+			Pluck("l2_output_index", &gots)
+		s.Equal(want, gots)
+	}
+
+	contract0 := s.createContract()
+	contract1 := s.createContract()
+	for _, i := range s.Shuffle(s.Range(0, 10)) {
+		s.createProposal(contract0, i)
+		s.createProposal(contract1, i)
+	}
+
+	rows0, _ := s.db.DeleteOlds(contract0.Address, 3, 100)
+	rows1, _ := s.db.DeleteOlds(contract1.Address, 6, 2)
+
+	s.Equal(int64(4), rows0)
+	s.Equal(int64(2), rows1)
+	assert(contract0, s.Range(4, 10))
+
+	// deleted up to the limit
+	var remains int64
+	s.DatabaseTestSuite.db.rawdb.Model(&OpstackProposal{}).
+		Where("contract_id = ?", contract1.ID).
+		Where("l2_output_index <= ?", 6).
+		Count(&remains)
+	s.Equal(int64(5), remains)
+}
@@ -76,6 +76,121 @@ func (db *OptimismSignatureDB) Find(
 	return rows, nil
 }
 
+// Conditions of `FindByFilter`, the nil fields are not used for filtering.
+type OptimismSignatureFilter struct {
+	Signer    *common.Address
+	Contracts []common.Address
+
+	// Range of the rollup index, both inclusive.
+	FromIndex, ToIndex *uint64
+
+	Approved *bool
+
+	// Range of the signature ID, both inclusive.
+	FromID, ToID *string
+}
+
+// Returns the signatures matching the filter, ordered by the contract, the rollup index and the ID.
+func (db *OptimismSignatureDB) FindByFilter(
+	filter *OptimismSignatureFilter,
+	limit, offset int,
+) ([]*OptimismSignature, error) {
+	tx, err := db.applyFilter(db.rawdb.Joins("Signer").Joins("Contract"), filter)
+	if err != nil {
+		return nil, err
+	}
+	tx = tx.
+		Order("optimism_signatures.optimism_scc_id").
+		Order("optimism_signatures.batch_index").
+		Order("optimism_signatures.id").
+		Limit(limit).
+		Offset(offset)
+
+	var rows []*OptimismSignature
+	tx = tx.Find(&rows)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return rows, nil
+}
+
+// Same as `FindByFilter`, but the limit and the offset apply to the rollups
+// (pairs of the contract and the rollup index) instead of the signatures,
+// so that the signatures of a rollup are never split across pages.
+func (db *OptimismSignatureDB) FindByFilterPerRollup(
+	filter *OptimismSignatureFilter,
+	limit, offset int,
+) ([]*OptimismSignature, error) {
+	rollups, err := db.applyFilter(db.rawdb.Model(&OptimismSignature{}), filter)
+	if err != nil {
+		return nil, err
+	}
+	rollups = rollups.
+		Select("optimism_signatures.optimism_scc_id, optimism_signatures.batch_index").
+		Group("optimism_signatures.optimism_scc_id").
+		Group("optimism_signatures.batch_index").
+		Order("optimism_signatures.optimism_scc_id").
+		Order("optimism_signatures.batch_index").
+		Limit(limit).
+		Offset(offset)
+
+	tx, err := db.applyFilter(db.rawdb.Joins("Signer").Joins("Contract"), filter)
+	if err != nil {
+		return nil, err
+	}
+	tx = tx.
+		Where("(optimism_signatures.optimism_scc_id, optimism_signatures.batch_index) IN (?)", rollups).
+		Order("optimism_signatures.optimism_scc_id").
+		Order("optimism_signatures.batch_index").
+		Order("optimism_signatures.id")
+
+	var rows []*OptimismSignature
+	tx = tx.Find(&rows)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return rows, nil
+}
+
+func (db *OptimismSignatureDB) applyFilter(tx *gorm.DB, filter *OptimismSignatureFilter) (*gorm.DB, error) {
+	if filter.Signer != nil {
+		_signer, err := db.db.Signer.FindOrCreate(*filter.Signer)
+		if err != nil {
+			return nil, err
+		}
+		tx = tx.Where("optimism_signatures.signer_id = ?", _signer.ID)
+	}
+	if filter.Contracts != nil {
+		ids := make([]uint64, len(filter.Contracts))
+		for i, contract := range filter.Contracts {
+			_contract, err := db.db.OPContract.FindOrCreate(contract)
+			if err != nil {
+				return nil, err
+			}
+			ids[i] = _contract.ID
+		}
+		tx = tx.Where("optimism_signatures.optimism_scc_id IN ?", ids)
+	}
+	if filter.FromIndex != nil {
+		tx = tx.Where("optimism_signatures.batch_index >= ?", *filter.FromIndex)
+	}
+	if filter.ToIndex != nil {
+		tx = tx.Where("optimism_signatures.batch_index <= ?", *filter.ToIndex)
+	}
+	if filter.Approved != nil {
+		tx = tx.Where("optimism_signatures.approved = ?", *filter.Approved)
+	}
+	if filter.FromID != nil {
+		tx = tx.Where("optimism_signatures.id >= ?", *filter.FromID)
+	}
+	if filter.ToID != nil {
+		tx = tx.Where("optimism_signatures.id <= ?", *filter.ToID)
+	}
+	return tx, nil
+}
+
 func (db *OptimismSignatureDB) FindLatestsPerSigners() ([]*OptimismSignature, error) {
 	// search foolishly because group by is slow
 	var signers []uint64
@@ -134,6 +249,35 @@ func (db *OptimismSignatureDB) FindLatestsBySigner(
 	return rows, nil
 }
 
+// Returns the signature of the highest rollup index signed by the signer for the contract.
+func (db *OptimismSignatureDB) FindLatestBySignerAndContract(
+	signer common.Address,
+	contract common.Address,
+) (*OptimismSignature, error) {
+	_signer, err := db.db.Signer.FindOrCreate(signer)
+	if err != nil {
+		return nil, err
+	}
+	_contract, err := db.db.OPContract.FindOrCreate(contract)
+	if err != nil {
+		return nil, err
+	}
+
+	var row OptimismSignature
+	tx := db.rawdb.
+		Joins("Signer").
+		Joins("Contract").
+		Where("optimism_signatures.signer_id = ?", _signer.ID).
+		Where("optimism_signatures.optimism_scc_id = ?", _contract.ID).
+		Order("optimism_signatures.batch_index DESC").
+		First(&row)
+
+	if err := errconv(tx.Error); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
 func (db *OptimismSignatureDB) FindUnverifiedBySigner(
 	signer common.Address,
 	unverifiedIndex uint64,
@@ -169,6 +313,35 @@ func (db *OptimismSignatureDB) FindUnverifiedBySigner(
 	return rows, nil
 }
 
+// Returns the signatures of the contract whose rollup index is `fromIndex` or later.
+func (db *OptimismSignatureDB) FindFromIndex(
+	contract common.Address,
+	fromIndex uint64,
+	limit, offset int,
+) ([]*OptimismSignature, error) {
+	_contract, err := db.db.OPContract.FindOrCreate(contract)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*OptimismSignature
+	tx := db.rawdb.
+		Joins("Signer").
+		Joins("Contract").
+		Where("optimism_signatures.optimism_scc_id = ?", _contract.ID).
+		Where("optimism_signatures.batch_index >= ?", fromIndex).
+		Order("optimism_signatures.batch_index").
+		Order("optimism_signatures.id").
+		Limit(limit).
+		Offset(offset).
+		Find(&rows)
+
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return rows, nil
+}
+
 func (db *OptimismSignatureDB) Save(
 	id, previousID *string,
 	signer common.Address,
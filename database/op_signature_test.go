@@ -143,6 +143,155 @@ func (s *OptimismSignatureDBTestSuite) TestFind() {
 	}
 }
 
+func (s *OptimismSignatureDBTestSuite) TestFindByFilter() {
+	signer0 := s.createSigner()
+	signer1 := s.createSigner()
+	contract0 := s.createContract()
+	contract1 := s.createContract()
+	contract2 := s.createContract()
+
+	// created in the reverse order to check the sorting
+	var creates [3][2][]*OptimismSignature
+	for _, index := range []int{2, 1, 0} {
+		for i, contract := range []*OptimismContract{contract0, contract1, contract2} {
+			for j, signer := range []*Signer{signer0, signer1} {
+				sig := s.createSignature(signer, contract, index)
+				if index == 1 {
+					sig.Approved = true
+					s.NoDBError(s.DatabaseTestSuite.db.rawdb.Save(sig))
+				}
+				creates[i][j] = append([]*OptimismSignature{sig}, creates[i][j]...)
+			}
+		}
+	}
+
+	approved := true
+	fromIndex, toIndex := uint64(1), uint64(2)
+	cases := []struct {
+		name          string
+		filter        *OptimismSignatureFilter
+		limit, offset int
+		wants         []*OptimismSignature
+	}{
+		{
+			"signer and contract",
+			&OptimismSignatureFilter{
+				Signer:    &signer1.Address,
+				Contracts: []common.Address{contract1.Address},
+			},
+			100, 0,
+			creates[1][1],
+		},
+		{
+			"contracts",
+			&OptimismSignatureFilter{
+				Contracts: []common.Address{contract0.Address, contract2.Address},
+				ToIndex:   &fromIndex,
+			},
+			100, 0,
+			[]*OptimismSignature{
+				creates[0][0][0], creates[0][1][0], creates[0][0][1], creates[0][1][1],
+				creates[2][0][0], creates[2][1][0], creates[2][0][1], creates[2][1][1],
+			},
+		},
+		{
+			"no contracts",
+			&OptimismSignatureFilter{Contracts: []common.Address{}},
+			100, 0,
+			[]*OptimismSignature{},
+		},
+		{
+			"index range and approved",
+			&OptimismSignatureFilter{
+				Signer:    &signer0.Address,
+				FromIndex: &fromIndex,
+				ToIndex:   &toIndex,
+				Approved:  &approved,
+			},
+			100, 0,
+			[]*OptimismSignature{creates[0][0][1], creates[1][0][1], creates[2][0][1]},
+		},
+		{
+			"id range",
+			&OptimismSignatureFilter{
+				Signer: &signer0.Address,
+				FromID: &creates[0][0][1].ID,
+				ToID:   &creates[2][0][1].ID,
+			},
+			100, 0,
+			[]*OptimismSignature{creates[0][0][1], creates[1][0][1], creates[2][0][1]},
+		},
+		{
+			"limit and offset",
+			&OptimismSignatureFilter{Signer: &signer0.Address},
+			2, 3,
+			[]*OptimismSignature{creates[1][0][0], creates[1][0][1]},
+		},
+	}
+	for _, c := range cases {
+		s.Run(c.name, func() {
+			gots, err := s.db.FindByFilter(c.filter, c.limit, c.offset)
+			s.NoError(err)
+			s.Len(gots, len(c.wants))
+			for i, want := range c.wants {
+				s.Equal(want.ID, gots[i].ID, i)
+			}
+		})
+	}
+}
+
+func (s *OptimismSignatureDBTestSuite) TestFindByFilterPerRollup() {
+	signer0 := s.createSigner()
+	signer1 := s.createSigner()
+	contract0 := s.createContract()
+	contract1 := s.createContract()
+
+	var creates [2][3][]*OptimismSignature
+	for i, contract := range []*OptimismContract{contract0, contract1} {
+		for index := 0; index < 3; index++ {
+			for _, signer := range []*Signer{signer0, signer1} {
+				creates[i][index] = append(creates[i][index], s.createSignature(signer, contract, index))
+			}
+		}
+	}
+
+	cases := []struct {
+		name          string
+		filter        *OptimismSignatureFilter
+		limit, offset int
+		wants         []*OptimismSignature
+	}{
+		{
+			"the page does not split the rollups",
+			&OptimismSignatureFilter{},
+			2, 1,
+			append(append([]*OptimismSignature{}, creates[0][1]...), creates[0][2]...),
+		},
+		{
+			"across the contracts",
+			&OptimismSignatureFilter{},
+			2, 2,
+			append(append([]*OptimismSignature{}, creates[0][2]...), creates[1][0]...),
+		},
+		{
+			"filtered",
+			&OptimismSignatureFilter{Signer: &signer1.Address, Contracts: []common.Address{contract1.Address}},
+			2, 0,
+			[]*OptimismSignature{creates[1][0][1], creates[1][1][1]},
+		},
+	}
+	for _, c := range cases {
+		s.Run(c.name, func() {
+			gots, err := s.db.FindByFilterPerRollup(c.filter, c.limit, c.offset)
+			s.NoError(err)
+			s.Len(gots, len(c.wants))
+			for i, want := range c.wants {
+				s.Equal(want.ID, gots[i].ID, i)
+			}
+		})
+	}
+}
+
 func (s *OptimismSignatureDBTestSuite) TestFindLatestsPerSigners() {
 	signer0 := s.createSigner()
 	signer1 := s.createSigner()
@@ -210,6 +359,64 @@ func (s *OptimismSignatureDBTestSuite) TestFindLatestsBySigner() {
 	s.Equal(wants1[len(wants1)-10].ID, gots1[7].ID)
 }
 
+func (s *OptimismSignatureDBTestSuite) TestFindLatestBySignerAndContract() {
+	signer0 := s.createSigner()
+	signer1 := s.createSigner()
+	contract0 := s.createContract()
+	contract1 := s.createContract()
+
+	for _, index := range []int{3, 5, 4} {
+		s.createSignature(signer0, contract0, index)
+	}
+	s.createSignature(signer0, contract1, 10)
+	s.createSignature(signer1, contract0, 20)
+
+	got, err := s.db.FindLatestBySignerAndContract(signer0.Address, contract0.Address)
+	s.NoError(err)
+	s.Equal(uint64(5), got.RollupIndex)
+	s.Equal(signer0.Address, got.Signer.Address)
+	s.Equal(contract0.Address, got.Contract.Address)
+
+	got, _ = s.db.FindLatestBySignerAndContract(signer0.Address, contract1.Address)
+	s.Equal(uint64(10), got.RollupIndex)
+
+	_, err = s.db.FindLatestBySignerAndContract(signer1.Address, contract1.Address)
+	s.ErrorIs(err, ErrNotFound)
+}
+
+func (s *OptimismSignatureDBTestSuite) TestFindFromIndex() {
+	signer0 := s.createSigner()
+	signer1 := s.createSigner()
+	contract0 := s.createContract()
+	contract1 := s.createContract()
+
+	var wants []*OptimismSignature
+	for _, index := range s.Range(0, 5) {
+		sig0 := s.createSignature(signer0, contract0, index)
+		sig1 := s.createSignature(signer1, contract0, index)
+		s.createSignature(signer0, contract1, index)
+		if index >= 2 {
+			wants = append(wants, sig0, sig1)
+		}
+	}
+
+	gots, _ := s.db.FindFromIndex(contract0.Address, 2, 100, 0)
+	s.Len(gots, len(wants))
+	for i, want := range wants {
+		s.Equal(want.ID, gots[i].ID, i)
+		s.Equal(want.RollupIndex, gots[i].RollupIndex, i)
+	}
+
+	// limit and offset
+	gots, _ = s.db.FindFromIndex(contract0.Address, 2, 2, 3)
+	s.Len(gots, 2)
+	s.Equal(wants[3].ID, gots[0].ID)
+	s.Equal(wants[4].ID, gots[1].ID)
+
+	gots, _ = s.db.FindFromIndex(contract0.Address, 5, 100, 0)
+	s.Len(gots, 0)
+}
+
 func (s *OptimismSignatureDBTestSuite) TestSave() {
 	signer := s.createSigner()
 	contract := s.createContract()
@@ -29,6 +29,24 @@ func (db *OptimismSignatureDB) repairOvertakingSignatures(signer common.Address)
 	}
 }
 
+// Returns the signatures whose previous signature does not exist.
+func (db *OptimismSignatureDB) FindOrphans(limit int) ([]*OptimismSignature, error) {
+	var rows []*OptimismSignature
+	tx := db.rawdb.
+		Joins("Signer").
+		Joins("Contract").
+		Joins("LEFT JOIN optimism_signatures AS t2 ON optimism_signatures.previous_id = t2.id").
+		Where("optimism_signatures.previous_id != ''").
+		Where("t2.id IS NULL").
+		Order("optimism_signatures.id").
+		Limit(limit).
+		Find(&rows)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return rows, nil
+}
+
 func (db *OptimismSignatureDB) repairMissingPrevID(signer common.Address) {
 	var rows []*OptimismSignature
 	tx := db.rawdb.
@@ -183,3 +183,31 @@ func (s *OptimizeDatabaseTestSuite) TestRepairMissingPrevID() {
 	s.Equal(actual2.PreviousID, "01GSSK5XTZB6EQK946EMQ2SBAE")
 	s.Greater(actual2.ID, actual2.PreviousID)
 }
+
+func (s *OptimizeDatabaseTestSuite) TestFindOrphans() {
+	signer0 := s.createSigner()
+	signer1 := s.createSigner()
+	contract := s.createContract()
+
+	sig0 := s.createSignature(signer0, contract, 0)
+	sig1 := s.createSignature(signer1, contract, 0)
+	sig2 := s.createSignature(signer0, contract, 1)
+	sig3 := s.createSignature(signer1, contract, 1)
+
+	sig0.PreviousID = ""
+	sig2.PreviousID = sig0.ID
+	sig3.PreviousID = sig1.ID
+	for _, sig := range []*OptimismSignature{sig0, sig2, sig3} {
+		s.NoDBError(s.db.rawdb.Save(sig))
+	}
+
+	// `sig1` refers the missing signature
+	rows, _ := s.db.OPSignature.FindOrphans(100)
+	s.Len(rows, 1)
+	s.Equal(sig1.ID, rows[0].ID)
+	s.Equal(signer1.Address, rows[0].Signer.Address)
+
+	s.db.OPSignature.RepairPreviousID(signer1.Address)
+	rows, _ = s.db.OPSignature.FindOrphans(100)
+	s.Len(rows, 0)
+}
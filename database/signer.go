@@ -23,3 +23,11 @@ func (db *SignerDB) FindOrCreate(signer common.Address) (row *Signer, err error)
 	}
 	return row, nil
 }
+
+func (db *SignerDB) FindAll() ([]*Signer, error) {
+	var rows []*Signer
+	if tx := db.rawdb.Order("id").Find(&rows); tx.Error != nil {
+		return nil, tx.Error
+	}
+	return rows, nil
+}
@@ -49,3 +49,14 @@ func (s *SignerDBTestSuite) TestFindOrCreate() {
 	got3, _ = s.db.FindOrCreate(addr3)
 	assert(got1, got2, got3)
 }
+
+func (s *SignerDBTestSuite) TestFindAll() {
+	rows, _ := s.db.FindAll()
+	s.Len(rows, 0)
+
+	signer0 := s.createSigner()
+	signer1 := s.createSigner()
+
+	rows, _ = s.db.FindAll()
+	s.Equal([]*Signer{signer0, signer1}, rows)
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// Status of the verify transaction whose receipt has not been received yet.
+	SubmissionPending uint64 = 2
+
+	// Status of the verify transaction whose receipt could not be received,
+	// e.g. timed out. The error is saved as the revert reason.
+	SubmissionUnknown uint64 = 3
+)
+
+type SubmissionDB db
+
+// Save the verify transaction for the rollup contract.
+func (db *SubmissionDB) Save(contract common.Address, row *Submission) error {
+	_contract, err := db.db.OPContract.FindOrCreate(contract)
+	if err != nil {
+		return err
+	}
+
+	row.ContractID = _contract.ID
+	row.Contract = *_contract
+	return db.rawdb.Omit("Contract").Create(row).Error
+}
+
+// Update the verify transaction for the rollup contract with its receipt.
+func (db *SubmissionDB) SaveReceipt(
+	contract common.Address,
+	txHash common.Hash,
+	gasUsed uint64,
+	effectivePrice string,
+	status uint64,
+	revertReason string,
+) error {
+	_contract, err := db.db.OPContract.FindOrCreate(contract)
+	if err != nil {
+		return err
+	}
+
+	tx := db.rawdb.Model(&Submission{}).
+		Where("tx_hash = ? AND contract_id = ?", txHash, _contract.ID).
+		Updates(map[string]interface{}{
+			"gas_used":        gasUsed,
+			"effective_price": effectivePrice,
+			"status":          status,
+			"revert_reason":   revertReason,
+		})
+	if tx.Error != nil {
+		return tx.Error
+	} else if tx.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Find the verify transactions sent after `since`, newest first.
+func (db *SubmissionDB) Find(
+	chainID *uint64,
+	since time.Time,
+	limit, offset int,
+) ([]*Submission, error) {
+	tx := db.rawdb.
+		Joins("Contract").
+		Where("submissions.created_at >= ?", since).
+		Order("submissions.id DESC").
+		Limit(limit).
+		Offset(offset)
+
+	if chainID != nil {
+		tx = tx.Where("submissions.chain_id = ?", *chainID)
+	}
+
+	var rows []*Submission
+	if tx = tx.Find(&rows); tx.Error != nil {
+		return nil, tx.Error
+	}
+	return rows, nil
+}
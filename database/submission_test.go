@@ -0,0 +1,81 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestSubmissionDB(t *testing.T) {
+	suite.Run(t, new(SubmissionDBTestSuite))
+}
+
+type SubmissionDBTestSuite struct {
+	DatabaseTestSuite
+
+	db *SubmissionDB
+}
+
+func (s *SubmissionDBTestSuite) SetupTest() {
+	s.DatabaseTestSuite.SetupTest()
+	s.db = s.DatabaseTestSuite.db.Submission
+}
+
+func (s *SubmissionDBTestSuite) TestSaveAndFind() {
+	contract0, contract1 := s.RandAddress(), s.RandAddress()
+	tx0, tx1 := s.RandHash(), s.RandHash()
+
+	// multicall transaction for two verses
+	s.NoError(s.db.Save(contract0, &Submission{TxHash: tx0, ChainID: 1, FirstIndex: 0, LastIndex: 2, Status: 1}))
+	s.NoError(s.db.Save(contract1, &Submission{TxHash: tx0, ChainID: 2, FirstIndex: 5, LastIndex: 5, Status: 1}))
+	s.NoError(s.db.Save(contract0, &Submission{TxHash: tx1, ChainID: 1, FirstIndex: 3, LastIndex: 3, Status: 0}))
+
+	// duplicated
+	s.Error(s.db.Save(contract0, &Submission{TxHash: tx0, ChainID: 1}))
+
+	// find all
+	gots, err := s.db.Find(nil, time.Time{}, 10, 0)
+	s.NoError(err)
+	s.Len(gots, 3)
+	s.Equal(tx1, gots[0].TxHash)
+	s.Equal(contract0, gots[0].Contract.Address)
+
+	// find by chain id
+	chainID := uint64(1)
+	gots, err = s.db.Find(&chainID, time.Time{}, 10, 0)
+	s.NoError(err)
+	s.Len(gots, 2)
+	s.Equal(uint64(3), gots[0].FirstIndex)
+	s.Equal(uint64(2), gots[1].LastIndex)
+
+	// find by time
+	gots, err = s.db.Find(nil, time.Now().Add(time.Minute), 10, 0)
+	s.NoError(err)
+	s.Len(gots, 0)
+}
+
+func (s *SubmissionDBTestSuite) TestSaveReceipt() {
+	contract0, contract1 := s.RandAddress(), s.RandAddress()
+	tx0 := s.RandHash()
+
+	s.NoError(s.db.Save(contract0, &Submission{TxHash: tx0, ChainID: 1, GasLimit: 200, Status: SubmissionPending}))
+	s.NoError(s.db.Save(contract1, &Submission{TxHash: tx0, ChainID: 2, GasLimit: 100, Status: SubmissionPending}))
+
+	s.NoError(s.db.SaveReceipt(contract0, tx0, 150, "1000", 0, "reverted"))
+
+	gots, _ := s.db.Find(nil, time.Time{}, 10, 0)
+	s.Len(gots, 2)
+	s.Equal(contract1, gots[0].Contract.Address)
+	s.Equal(SubmissionPending, gots[0].Status)
+	s.Equal(uint64(0), gots[0].GasUsed)
+	s.Equal(contract0, gots[1].Contract.Address)
+	s.Equal(uint64(200), gots[1].GasLimit)
+	s.Equal(uint64(150), gots[1].GasUsed)
+	s.Equal("1000", gots[1].EffectivePrice)
+	s.Equal(uint64(0), gots[1].Status)
+	s.Equal("reverted", gots[1].RevertReason)
+
+	// not found
+	s.ErrorIs(s.db.SaveReceipt(contract0, s.RandHash(), 0, "", 1, ""), ErrNotFound)
+}
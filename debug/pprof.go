@@ -20,7 +20,7 @@ func NewPprofServer(cfg *config.Pprof) (*PprofServer, *http.Server) {
 	runtime.SetBlockProfileRate(cfg.BlockProfileRate)
 	runtime.MemProfileRate = cfg.MemProfileRate
 
-	auth := wrapBasicAuth(cfg.BasicAuth.Username, cfg.BasicAuth.Password)
+	auth := WrapBasicAuth(cfg.BasicAuth.Username, cfg.BasicAuth.Password)
 	mux := http.NewServeMux()
 	mux.HandleFunc("/debug/pprof/", auth(pprof.Index))
 	mux.HandleFunc("/debug/pprof/cmdline", auth(pprof.Cmdline))
@@ -45,7 +45,9 @@ func (w *PprofServer) ListenAndServe(ctx context.Context, psvr *http.Server) err
 	return psvr.ListenAndServe()
 }
 
-func wrapBasicAuth(username, password string) func(origin http.HandlerFunc) http.HandlerFunc {
+// Returns the middleware requiring the basic authentication, does nothing if
+// the username or the password is empty.
+func WrapBasicAuth(username, password string) func(origin http.HandlerFunc) http.HandlerFunc {
 	return func(origin http.HandlerFunc) http.HandlerFunc {
 		if username == "" || password == "" {
 			return origin
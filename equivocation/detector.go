@@ -0,0 +1,217 @@
+package equivocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	meter "github.com/oasysgames/oasys-optimism-verifier/metrics"
+)
+
+// Source of the rollup hashes currently recorded on the L1, implemented by `verse.VersePool`.
+type RollupHashSource interface {
+	RollupHash(ctx context.Context, contract common.Address, rollupIndex uint64) (common.Hash, error)
+}
+
+var (
+	// Number and lifetime of the cached rollup hashes, which prevent every
+	// re-gossip of a conflicting signature from querying the L1.
+	rollupHashCacheSize = 1024
+	rollupHashCacheTTL  = time.Minute
+)
+
+type rollupKey struct {
+	contract    common.Address
+	rollupIndex uint64
+}
+
+type rollupHashResult struct {
+	hash common.Hash
+	err  error
+}
+
+// Detector finds signers who signed conflicting messages for the same
+// rollup (equivocation), and rollups on which the validators are split.
+type Detector struct {
+	db           *database.Database
+	stakemanager *stakemanager.Cache
+	rollups      RollupHashSource
+	rollupHashes *expirable.LRU[rollupKey, *rollupHashResult]
+	log          log.Logger
+
+	meterEquivocations meter.Counter
+	meterVoteConflicts meter.Counter
+}
+
+// The `rollups` may be nil, in which case all equivocations are saved as suspected.
+func NewDetector(
+	db *database.Database,
+	stakemanager *stakemanager.Cache,
+	rollups RollupHashSource,
+) *Detector {
+	return &Detector{
+		db:                 db,
+		stakemanager:       stakemanager,
+		rollups:            rollups,
+		rollupHashes:       expirable.NewLRU[rollupKey, *rollupHashResult](rollupHashCacheSize, nil, rollupHashCacheTTL),
+		log:                log.New("worker", "equivocation"),
+		meterEquivocations: meter.GetOrRegisterCounter([]string{"equivocation", "signers"}, ""),
+		meterVoteConflicts: meter.GetOrRegisterCounter([]string{"equivocation", "vote", "conflicts"}, ""),
+	}
+}
+
+// Compare the received signature with the locally stored signature of the same
+// signer for the same rollup, and save the evidence if they are different.
+//
+// Since validators re-sign after a reorg of the L1, the conflict is confirmed only
+// if the older signature is for the rollup hash still recorded on the L1. If the
+// newer signature is for it instead, the conflict is regarded as a re-sign and
+// ignored. Otherwise the evidence is saved as suspected.
+// Returns true if the signature is a confirmed equivocation.
+// The rollup hash on the L1 is cached for a while, including the failure to fetch it.
+func (d *Detector) CheckSignature(
+	ctx context.Context,
+	id string,
+	signer common.Address,
+	contract common.Address,
+	rollupIndex uint64,
+	rollupHash common.Hash,
+	approved bool,
+	signature database.Signature,
+) bool {
+	locals, err := d.db.OPSignature.Find(nil, &signer, &contract, &rollupIndex, 1, 0)
+	if err != nil {
+		d.log.Error("Failed to find local signature",
+			"signer", signer, "contract", contract, "rollup-index", rollupIndex, "err", err)
+		return false
+	} else if len(locals) == 0 {
+		return false
+	}
+
+	local := locals[0]
+	if local.RollupHash == rollupHash && local.Approved == approved {
+		return false
+	}
+
+	// the evidence has already been confirmed
+	if row, err := d.db.Conflict.FindEquivocation(signer, contract, rollupIndex); err == nil && row.Confirmed {
+		return true
+	} else if err != nil && !errors.Is(err, database.ErrNotFound) {
+		d.log.Error("Failed to find equivocation",
+			"signer", signer, "contract", contract, "rollup-index", rollupIndex, "err", err)
+		return false
+	}
+
+	// ignore signers without stake, as their signatures are never used
+	stake := d.stakemanager.StakeBySigner(ctx, signer)
+	if stake.Cmp(ethutil.TenMillionOAS) == -1 {
+		return false
+	}
+
+	logctx := []interface{}{
+		"signer", signer, "stake", stake, "contract", contract, "rollup-index", rollupIndex,
+		"local-id", local.ID, "local-rollup-hash", local.RollupHash, "local-approved", local.Approved,
+		"remote-id", id, "remote-rollup-hash", rollupHash, "remote-approved", approved,
+	}
+
+	older, newer := local.RollupHash, rollupHash
+	if strings.Compare(id, local.ID) == -1 {
+		older, newer = newer, older
+	}
+
+	var confirmed bool
+	if canonical, err := d.rollupHash(ctx, contract, rollupIndex); err != nil {
+		d.log.Warn("Failed to fetch rollup hash", append(logctx, "err", err)...)
+	} else if older == canonical {
+		confirmed = true
+	} else if newer == canonical {
+		d.log.Debug("Re-signed after reorg", logctx...)
+		return false
+	}
+
+	created, err := d.db.Conflict.SaveEquivocation(local, rollupHash, approved, signature, confirmed)
+	if err != nil {
+		d.log.Error("Failed to save equivocation", append(logctx, "err", err)...)
+		return confirmed
+	} else if !created {
+		return confirmed
+	}
+
+	if !confirmed {
+		d.log.Warn("Suspected equivocation", logctx...)
+		return false
+	}
+	d.meterEquivocations.Incr()
+	d.log.Error("Equivocation detected", logctx...)
+	return true
+}
+
+func (d *Detector) rollupHash(
+	ctx context.Context,
+	contract common.Address,
+	rollupIndex uint64,
+) (common.Hash, error) {
+	if d.rollups == nil {
+		return common.Hash{}, errors.New("no source of rollup hashes")
+	}
+
+	key := rollupKey{contract: contract, rollupIndex: rollupIndex}
+	if cached, ok := d.rollupHashes.Get(key); ok {
+		return cached.hash, cached.err
+	}
+
+	hash, err := d.rollups.RollupHash(ctx, contract, rollupIndex)
+	if ctx.Err() == nil {
+		d.rollupHashes.Add(key, &rollupHashResult{hash: hash, err: err})
+	}
+	return hash, err
+}
+
+// Check if the signatures for the same rollup are split into multiple groups,
+// and save the stake amount of each group as evidence.
+func (d *Detector) CheckVotes(
+	ctx context.Context,
+	contract common.Address,
+	rollupIndex uint64,
+	rows []*database.OptimismSignature,
+) []*Group {
+	groups, _ := GroupSignatures(rows, ethutil.TenMillionOAS,
+		func(signer common.Address) *big.Int { return d.stakemanager.StakeBySigner(ctx, signer) })
+	if len(groups) < 2 {
+		return groups
+	}
+
+	var changed bool
+	for _, group := range groups {
+		ok, err := d.db.Conflict.SaveVoteConflict(contract, rollupIndex,
+			group.RollupHash, group.Approved, group.Stake, uint64(len(group.Rows)))
+		if err != nil {
+			d.log.Error("Failed to save vote conflict",
+				"contract", contract, "rollup-index", rollupIndex, "err", err)
+			return groups
+		}
+		changed = changed || ok
+	}
+	if !changed {
+		return groups
+	}
+
+	d.meterVoteConflicts.Incr()
+	logctx := []interface{}{"contract", contract, "rollup-index", rollupIndex}
+	for i, group := range groups {
+		logctx = append(logctx, fmt.Sprintf("group%d", i),
+			fmt.Sprintf("rollup-hash=%s approved=%v stake=%s signers=%d",
+				group.RollupHash, group.Approved, group.Stake, len(group.Rows)))
+	}
+	d.log.Error("Conflicting votes detected", logctx...)
+	return groups
+}
@@ -0,0 +1,254 @@
+package equivocation
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	"github.com/oasysgames/oasys-optimism-verifier/testhelper"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+	"github.com/stretchr/testify/suite"
+)
+
+type EquivocationTestSuite struct {
+	testhelper.Suite
+
+	db       *database.Database
+	sm       *testhelper.StakeManagerMock
+	rollups  *rollupHashMock
+	detector *Detector
+	contract common.Address
+}
+
+type rollupHashMock struct {
+	hashes map[uint64]common.Hash
+	calls  int
+}
+
+func (m *rollupHashMock) RollupHash(
+	ctx context.Context,
+	contract common.Address,
+	rollupIndex uint64,
+) (common.Hash, error) {
+	m.calls++
+	if hash, ok := m.hashes[rollupIndex]; ok {
+		return hash, nil
+	}
+	return common.Hash{}, errors.New("not found")
+}
+
+func TestEquivocation(t *testing.T) {
+	suite.Run(t, new(EquivocationTestSuite))
+}
+
+func (s *EquivocationTestSuite) SetupTest() {
+	db, err := database.NewDatabase(&config.Database{Path: ":memory:"})
+	if err != nil {
+		panic(err)
+	}
+	s.db = db
+	s.sm = &testhelper.StakeManagerMock{}
+	s.rollups = &rollupHashMock{hashes: map[uint64]common.Hash{}}
+	s.detector = NewDetector(db, stakemanager.NewCache(s.sm, time.Hour), s.rollups)
+	s.contract = s.RandAddress()
+}
+
+func (s *EquivocationTestSuite) newSigner(stake int64) common.Address {
+	signer := s.RandAddress()
+	s.sm.Owners = append(s.sm.Owners, s.RandAddress())
+	s.sm.Operators = append(s.sm.Operators, signer)
+	s.sm.Stakes = append(s.sm.Stakes, new(big.Int).Mul(ethutil.TenMillionOAS, big.NewInt(stake)))
+	s.sm.Candidates = append(s.sm.Candidates, true)
+	return signer
+}
+
+func (s *EquivocationTestSuite) saveSignature(
+	signer common.Address,
+	index uint64,
+	hash common.Hash,
+	approved bool,
+) *database.OptimismSignature {
+	sig, err := s.db.OPSignature.Save(nil, nil, signer, s.contract,
+		index, hash, approved, database.RandSignature())
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+func (s *EquivocationTestSuite) TestGroupSignatures() {
+	signers := []common.Address{s.newSigner(1), s.newSigner(2), s.newSigner(3), s.newSigner(4)}
+	noStake := s.RandAddress()
+	hash0, hash1 := s.RandHash(), s.RandHash()
+
+	rows := []*database.OptimismSignature{
+		s.saveSignature(signers[0], 0, hash0, true),
+		s.saveSignature(signers[1], 0, hash1, true),
+		s.saveSignature(signers[2], 0, hash0, true),
+		s.saveSignature(signers[3], 0, hash0, false),
+		s.saveSignature(noStake, 0, hash1, true),
+	}
+
+	groups, stakes := GroupSignatures(rows, ethutil.TenMillionOAS,
+		func(signer common.Address) *big.Int {
+			stake, _ := s.sm.GetOperatorStakes(nil, signer, nil)
+			return stake
+		})
+
+	s.Len(groups, 3)
+	s.Len(stakes, 4)
+
+	// order by stake desc
+	s.Equal(hash0, groups[0].RollupHash)
+	s.True(groups[0].Approved)
+	s.Equal(new(big.Int).Mul(ethutil.TenMillionOAS, big.NewInt(4)), groups[0].Stake)
+	s.Equal(rows[0].ID, groups[0].Rows[0].ID)
+	s.Equal(rows[2].ID, groups[0].Rows[1].ID)
+
+	s.Equal(hash0, groups[1].RollupHash)
+	s.False(groups[1].Approved)
+	s.Len(groups[1].Rows, 1)
+
+	s.Equal(hash1, groups[2].RollupHash)
+	s.Equal(new(big.Int).Mul(ethutil.TenMillionOAS, big.NewInt(2)), groups[2].Stake)
+	s.Len(groups[2].Rows, 1)
+}
+
+func (s *EquivocationTestSuite) TestCheckSignature() {
+	ctx := context.Background()
+	signer := s.newSigner(1)
+	noStake := s.RandAddress()
+
+	local := s.saveSignature(signer, 0, s.RandHash(), true)
+	s.saveSignature(noStake, 0, local.RollupHash, true)
+	s.rollups.hashes[0] = local.RollupHash
+
+	// same message
+	s.False(s.detector.CheckSignature(ctx, util.ULID(nil).String(), signer, s.contract, 0,
+		local.RollupHash, local.Approved, database.RandSignature()))
+
+	// no local signature
+	s.False(s.detector.CheckSignature(ctx, util.ULID(nil).String(), signer, s.contract, 1,
+		s.RandHash(), true, database.RandSignature()))
+
+	// signer without stake
+	s.False(s.detector.CheckSignature(ctx, util.ULID(nil).String(), noStake, s.contract, 0,
+		local.RollupHash, false, database.RandSignature()))
+
+	// different approval
+	sig := database.RandSignature()
+	s.True(s.detector.CheckSignature(ctx, util.ULID(nil).String(), signer, s.contract, 0,
+		local.RollupHash, false, sig))
+
+	gots, err := s.db.Conflict.FindEquivocations(nil, nil, 10, 0)
+	s.NoError(err)
+	s.Len(gots, 1)
+	s.Equal(signer, gots[0].Signer.Address)
+	s.Equal(s.contract, gots[0].Contract.Address)
+	s.Equal(local.ID, gots[0].FirstSignatureID)
+	s.True(gots[0].FirstApproved)
+	s.False(gots[0].SecondApproved)
+	s.Equal(sig, gots[0].SecondSignature)
+	s.True(gots[0].Confirmed)
+}
+
+func (s *EquivocationTestSuite) TestCheckSignatureReorg() {
+	ctx := context.Background()
+	signer := s.newSigner(1)
+	oldHash, newHash := s.RandHash(), s.RandHash()
+
+	// the local signature was signed before the reorg
+	local := s.saveSignature(signer, 0, oldHash, true)
+	s.rollups.hashes[0] = newHash
+
+	// re-signed after the reorg
+	s.False(s.detector.CheckSignature(ctx, util.ULID(nil).String(), signer, s.contract, 0,
+		newHash, true, database.RandSignature()))
+	gots, _ := s.db.Conflict.FindEquivocations(nil, nil, 10, 0)
+	s.Len(gots, 0)
+
+	// the signature before the reorg arrives after the re-signed one
+	s.saveSignature(signer, 1, newHash, true)
+	s.rollups.hashes[1] = newHash
+	s.False(s.detector.CheckSignature(ctx, local.ID, signer, s.contract, 1,
+		oldHash, true, database.RandSignature()))
+	gots, _ = s.db.Conflict.FindEquivocations(nil, nil, 10, 0)
+	s.Len(gots, 0)
+
+	// the rollup hash on the L1 is unknown
+	s.saveSignature(signer, 2, oldHash, true)
+	s.False(s.detector.CheckSignature(ctx, util.ULID(nil).String(), signer, s.contract, 2,
+		newHash, true, database.RandSignature()))
+	gots, _ = s.db.Conflict.FindEquivocations(nil, nil, 10, 0)
+	s.Len(gots, 1)
+	s.False(gots[0].Confirmed)
+
+	// signed a different hash although the older one is still on the L1
+	s.rollups.hashes[2] = oldHash
+	s.detector.rollupHashes.Purge()
+	s.True(s.detector.CheckSignature(ctx, util.ULID(nil).String(), signer, s.contract, 2,
+		newHash, true, database.RandSignature()))
+	gots, _ = s.db.Conflict.FindEquivocations(nil, nil, 10, 0)
+	s.Len(gots, 1)
+	s.True(gots[0].Confirmed)
+}
+
+func (s *EquivocationTestSuite) TestCheckSignatureRegossip() {
+	ctx := context.Background()
+	signer := s.newSigner(1)
+	oldHash, newHash := s.RandHash(), s.RandHash()
+	s.saveSignature(signer, 0, oldHash, true)
+	s.saveSignature(signer, 1, oldHash, true)
+
+	// the rollup hash on the L1 is fetched only once while cached
+	for i := 0; i < 3; i++ {
+		s.False(s.detector.CheckSignature(ctx, util.ULID(nil).String(), signer, s.contract, 0,
+			newHash, true, database.RandSignature()))
+	}
+	s.Equal(1, s.rollups.calls)
+
+	// the confirmed evidence doesn't need the rollup hash
+	s.rollups.hashes[1] = oldHash
+	for i := 0; i < 3; i++ {
+		s.True(s.detector.CheckSignature(ctx, util.ULID(nil).String(), signer, s.contract, 1,
+			newHash, true, database.RandSignature()))
+	}
+	s.Equal(2, s.rollups.calls)
+
+	gots, _ := s.db.Conflict.FindEquivocations(nil, nil, 10, 0)
+	s.Len(gots, 2)
+}
+
+func (s *EquivocationTestSuite) TestCheckVotes() {
+	ctx := context.Background()
+	signers := []common.Address{s.newSigner(1), s.newSigner(2), s.newSigner(1)}
+	hash0, hash1 := s.RandHash(), s.RandHash()
+
+	// unanimous
+	rows := []*database.OptimismSignature{
+		s.saveSignature(signers[0], 0, hash0, true),
+		s.saveSignature(signers[1], 0, hash0, true),
+	}
+	s.Len(s.detector.CheckVotes(ctx, s.contract, 0, rows), 1)
+
+	// split
+	rows = append(rows, s.saveSignature(signers[2], 0, hash1, false))
+	s.Len(s.detector.CheckVotes(ctx, s.contract, 0, rows), 2)
+
+	index := uint64(0)
+	gots, err := s.db.Conflict.FindVoteConflicts(&s.contract, &index, 10, 0)
+	s.NoError(err)
+	s.Len(gots, 2)
+	s.Equal(hash0, gots[0].RollupHash)
+	s.Equal(new(big.Int).Mul(ethutil.TenMillionOAS, big.NewInt(3)).String(), gots[0].Stake)
+	s.Equal(uint64(2), gots[0].Signers)
+	s.Equal(hash1, gots[1].RollupHash)
+	s.Equal(uint64(1), gots[1].Signers)
+}
@@ -0,0 +1,65 @@
+package equivocation
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+)
+
+// Signatures that voted for the same rollup hash and approval.
+type Group struct {
+	RollupHash common.Hash
+	Approved   bool
+	Stake      *big.Int
+	Rows       []*database.OptimismSignature
+}
+
+// Group signatures by `RollupHash` and `Approved`, ignoring signers whose
+// stake amount is less than `minStake`. The groups are sorted by stake amount
+// in descending order, and the stake amount of each signer is also returned.
+func GroupSignatures(
+	rows []*database.OptimismSignature,
+	minStake *big.Int,
+	stakeBySigner func(signer common.Address) *big.Int,
+) (groups []*Group, signerStakes map[common.Address]*big.Int) {
+	type key struct {
+		rollupHash common.Hash
+		approved   bool
+	}
+	keyed := map[key]*Group{}
+	signerStakes = map[common.Address]*big.Int{}
+
+	for _, row := range rows {
+		stake := stakeBySigner(row.Signer.Address)
+		if stake.Cmp(minStake) == -1 {
+			continue
+		}
+		signerStakes[row.Signer.Address] = stake
+
+		k := key{row.RollupHash, row.Approved}
+		group, ok := keyed[k]
+		if !ok {
+			group = &Group{RollupHash: row.RollupHash, Approved: row.Approved, Stake: new(big.Int)}
+			keyed[k] = group
+			groups = append(groups, group)
+		}
+
+		group.Stake = new(big.Int).Add(group.Stake, stake)
+		group.Rows = append(group.Rows, row)
+	}
+
+	// order by stake desc, ties are broken by the rollup hash for determinism
+	sort.SliceStable(groups, func(i, j int) bool {
+		if cmp := groups[i].Stake.Cmp(groups[j].Stake); cmp != 0 {
+			return cmp == 1
+		}
+		if cmp := bytes.Compare(groups[i].RollupHash[:], groups[j].RollupHash[:]); cmp != 0 {
+			return cmp == -1
+		}
+		return groups[i].Approved
+	})
+	return groups, signerStakes
+}
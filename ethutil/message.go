@@ -96,6 +96,29 @@ func NewMessageWithApprovedBug(
 	return &Message{AbiPacked: abiPacked, Eip712Msg: msg}
 }
 
+// Verify that the signature was signed by the signer, also accepting
+// old signatures containing the boolean type abi-encode bug.
+func VerifySignature(
+	hubChainID *big.Int,
+	contract common.Address,
+	rollupIndex *big.Int,
+	rollupHash [32]byte,
+	approved bool,
+	signature []byte,
+	signer common.Address,
+) error {
+	msg := NewMessage(hubChainID, contract, rollupIndex, rollupHash, approved)
+	err := msg.VerifySigner(signature, signer)
+
+	// possibly an old signature with an approved bug
+	if _, ok := err.(*SignerMismatchError); ok {
+		msg = NewMessageWithApprovedBug(hubChainID, contract, rollupIndex, rollupHash, approved)
+		err = msg.VerifySigner(signature, signer)
+	}
+
+	return err
+}
+
 func L2OORollupHashSource(outputRoot common.Hash, l1Timestamp, l2BlockNumber *big.Int) []byte {
 	return bytes.Join([][]byte{
 		outputRoot[:],
@@ -0,0 +1,152 @@
+package ipc
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+	goipc "github.com/james-barrow/golang-ipc"
+)
+
+const (
+	// message types of the legacy IPC
+	legacyWalletUnlock = 11
+	legacyEOM          = 1 << 16
+)
+
+// Server of the IPC protocol used by the older versions. It serves only
+// `wallet:unlock` so that the command of the older versions keeps working
+// during the transition to the JSON-RPC admin API.
+//
+// Deprecated: will be removed in a future release.
+type LegacyServer struct {
+	sockname string
+	unlock   func(address, password string) error
+	closed   chan struct{}
+	log      log.Logger
+
+	mu sync.Mutex
+	s  *goipc.Server
+}
+
+func NewLegacyServer(sockname string, unlock func(address, password string) error) (*LegacyServer, error) {
+	server, err := goipc.StartServer(sockname, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LegacyServer{
+		sockname: sockname,
+		unlock:   unlock,
+		closed:   make(chan struct{}),
+		log:      log.New("worker", "legacy-ipc"),
+		s:        server,
+	}, nil
+}
+
+// Serve the messages until the server is closed.
+func (s *LegacyServer) Start() {
+	s.log.Info("Legacy IPC server started", "sockname", s.sockname)
+
+	// The read channel of the ipc server is not closed when the server is closed,
+	// so the read loop is left behind instead of waiting for it.
+	go s.readLoop()
+	<-s.closed
+}
+
+func (s *LegacyServer) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.closed:
+	default:
+		close(s.closed)
+		s.s.Close()
+	}
+	return nil
+}
+
+func (s *LegacyServer) readLoop() {
+	for {
+		server := s.server()
+		msg, err := server.Read()
+		if s.isClosed() {
+			return
+		} else if err != nil {
+			s.log.Error("Read error", "err", err)
+			s.reConnect()
+			continue
+		}
+
+		switch msg.MsgType {
+		case -1:
+			if server.StatusCode() == goipc.Error {
+				s.log.Error("Error received", "sockname", s.sockname, "err", string(msg.Data))
+				s.reConnect()
+			}
+		case -2:
+			s.log.Warn("Error received", "sockname", s.sockname, "err", string(msg.Data))
+		case legacyWalletUnlock:
+			s.log.Warn("Wallet unlock via the legacy IPC is deprecated, " +
+				"use the wallet:unlock command of this version")
+			s.handleWalletUnlock(server, msg.Data)
+		default:
+			// let the clients of the other commands exit
+			s.write(server, msg.MsgType, []byte("not supported, use the command of this version"))
+			s.write(server, legacyEOM, nil)
+		}
+	}
+}
+
+func (s *LegacyServer) handleWalletUnlock(server *goipc.Server, data []byte) {
+	var msg struct {
+		Address  string
+		Password string
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		s.write(server, legacyWalletUnlock, []byte(err.Error()))
+	} else if err := s.unlock(msg.Address, msg.Password); err != nil {
+		s.write(server, legacyWalletUnlock, []byte(err.Error()))
+	} else {
+		s.write(server, legacyWalletUnlock, []byte("success!"))
+	}
+}
+
+func (s *LegacyServer) write(server *goipc.Server, msgType int, message []byte) {
+	if err := server.Write(msgType, message); err != nil {
+		s.log.Error("Failed to write ipc message", "err", err)
+	}
+}
+
+func (s *LegacyServer) server() *goipc.Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s
+}
+
+func (s *LegacyServer) isClosed() bool {
+	select {
+	case <-s.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *LegacyServer) reConnect() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isClosed() {
+		return
+	}
+	s.s.Close()
+
+	server, err := goipc.StartServer(s.sockname, nil)
+	if err != nil {
+		s.log.Error("Failed to re-connect", "err", err)
+		return
+	}
+	s.s = server
+}
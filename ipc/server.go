@@ -1,158 +1,120 @@
 package ipc
 
 import (
+	"context"
 	"errors"
-	"io"
-	"sync"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
-	goipc "github.com/james-barrow/golang-ipc"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
-const (
-	EOM = 1 << 16
-	// message type for closing the server
-	CLOSE_REQUEST = 1 << 8
-)
-
-const (
-	chunkSize = 1024
-)
+// Permission of the socket file, only the owner of the process can connect.
+const socketMode = 0600
 
-type Handler func(*IPCServer, []byte)
-
-type IPCServer struct {
-	sockname string
-
-	s        *goipc.Server
-	handlers *sync.Map
+// JSON-RPC 2.0 server on the Unix domain socket, serving the admin API.
+// Connections from other users are refused even if the socket file is
+// accessible, e.g. the directory is shared.
+type Server struct {
+	path     string
+	rpc      *rpc.Server
+	listener net.Listener
 	log      log.Logger
 }
 
-func NewIPCServer(sockname string) (*IPCServer, error) {
-	server, err := goipc.StartServer(sockname, nil)
+func NewServer(path string) (*Server, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", path)
 	if err != nil {
 		return nil, err
 	}
+	if err := os.Chmod(path, socketMode); err != nil {
+		listener.Close()
+		return nil, err
+	}
 
-	return &IPCServer{
-		sockname: sockname,
-		s:        server,
-		handlers: &sync.Map{},
-		log:      log.New("worker", "ipc"),
+	logger := log.New("worker", "ipc")
+	return &Server{
+		path:     path,
+		rpc:      rpc.NewServer(),
+		listener: &ownerListener{Listener: listener, log: logger},
+		log:      logger,
 	}, nil
 }
 
-func (s *IPCServer) SetHandler(id int, handler Handler) {
-	if _, ok := s.handlers.Load(id); !ok {
-		s.handlers.Store(id, handler)
-	}
+// Register the exported methods of the service as `{namespace}_{method}`.
+// Services can be registered after the server has started.
+func (s *Server) RegisterName(namespace string, service interface{}) error {
+	return s.rpc.RegisterName(namespace, service)
 }
 
-func (s *IPCServer) Start() {
-	s.log.Info("IPC server started", "sockname", s.sockname)
-
-	for {
-		msg, err := s.s.Read()
-		if err != nil {
-			s.log.Error("Read error", "err", err)
-			s.reConnect()
-			continue
-		}
-
-		// If the message type is CLOSE_REQUEST, exit the loop to close the server
-		// Need this to avoid infinite loop caused by the bug in ipc server
-		if msg.MsgType == CLOSE_REQUEST {
-			s.Write(EOM, nil)
-			s.log.Info("IPC server will close", "msgType", msg.MsgType, "sockname", s.sockname)
-			return
-		}
-
-		if msg.MsgType == -1 {
-			s.log.Debug("Status changed", "sockname", s.sockname, "status", msg.Status)
-			if msg.Status == "Closed" || msg.Status == "Closing" || s.s.StatusCode() == goipc.Closed || s.s.StatusCode() == goipc.Closing {
-				s.log.Info("IPC server will close", "msgType", msg.MsgType, "sockname", s.sockname)
-				return
-			} else if msg.Status == "Error" || s.s.StatusCode() == goipc.Error {
-				s.log.Error("Error recieved", "sockname", s.sockname, "err", string(msg.Data))
-				s.reConnect()
-				continue
-			}
-			continue
-		}
-
-		// message type -2 is an error, these won't automatically cause the recieve channel to close.
-		if msg.MsgType == -2 {
-			s.log.Warn("Error recieved", "sockname", s.sockname, "err", string(msg.Data))
-			continue
-		}
+// Serve the connections until the server is closed.
+func (s *Server) Start() {
+	s.log.Info("IPC server started", "path", s.path)
 
-		if handler, ok := s.handlers.Load(msg.MsgType); ok {
-			handler.(Handler)(s, msg.Data)
-		}
+	if err := s.rpc.ServeListener(s.listener); err != nil && !errors.Is(err, net.ErrClosed) {
+		s.log.Error("Failed to accept connection", "err", err)
 	}
+	s.log.Info("IPC server stopped", "path", s.path)
 }
 
-func (s *IPCServer) Close() error {
-	// Due to ipc server's bug, the `Close` method does not close read channel.
-	// Therefore, the read loop is not terminated and the server is not closed.
-	// So we need to send a close request to the server.
-	c, err := NewClient(s.sockname, CLOSE_REQUEST)
-	if err != nil {
-		s.log.Error("Failed to create ipc server close client", "err", err)
-		return err
-	}
-	// Send close request(msgType = 256)
-	if err = c.Write(nil); err != nil {
-		s.log.Error("Failed to write close request", "err", err)
-		return err
-	}
-	// err should be io.EOF
-	if _, err = c.Read(); !errors.Is(err, io.EOF) {
-		s.log.Error("unexpected error", "err", err)
-		return err
-	}
-
-	s.s.Close()
-	return nil
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.rpc.Stop()
+	return err
 }
 
-func (s *IPCServer) Write(msgType int, message []byte) error {
-	if err := s.s.Write(msgType, message); err != nil {
-		s.log.Error("Failed to write ipc message", "err", err)
-		return err
+// Connect to the admin API of the running node.
+func Dial(ctx context.Context, path string) (*rpc.Client, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("node is not running: %w", err)
 	}
-	// If they do not sleep, clients will read messages in the wrong order.
-	time.Sleep(time.Second / 10)
-	return nil
+	return rpc.DialIPC(ctx, path)
 }
 
-func (s *IPCServer) ChunkedWrite(msgType int, message []byte) error {
-	var chunks [][]byte
-	for chunkSize < len(message) {
-		message, chunks = message[chunkSize:],
-			append(chunks, message[0:chunkSize:chunkSize])
+// Remove the socket file left by the process that was not terminated
+// gracefully, fails if another process is still listening on it.
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
 	}
-	chunks = append(chunks, message)
-
-	for _, chunk := range chunks {
-		if err := s.Write(msgType, chunk); err != nil {
-			return err
-		}
+	if fi.Mode().Type() != fs.ModeSocket {
+		return fmt.Errorf("not a socket file: %s", path)
 	}
 
-	return nil
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("socket is already in use: %s", path)
+	}
+	return os.Remove(path)
 }
 
-func (s *IPCServer) reConnect() {
-	s.s.Close()
+type ownerListener struct {
+	net.Listener
+	log log.Logger
+}
 
-	server, err := goipc.StartServer(s.sockname, nil)
-	if err != nil {
-		s.log.Error("Failed to re-connect", "err", err)
-		return
+func (l *ownerListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if err := checkOwner(conn); err != nil {
+			l.log.Warn("Refused IPC connection", "err", err)
+			conn.Close()
+			continue
+		}
+		return conn, nil
 	}
-
-	s.s = server
 }
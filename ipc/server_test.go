@@ -2,9 +2,14 @@ package ipc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	goipc "github.com/james-barrow/golang-ipc"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -16,12 +21,72 @@ func TestIPCServer(t *testing.T) {
 	suite.Run(t, new(IPCServerTestSuite))
 }
 
-func (s *IPCServerTestSuite) TestIPCServerClose() {
+type testService struct{}
+
+func (t *testService) Echo(s string) (string, error) {
+	if s == "" {
+		return "", errors.New("empty")
+	}
+	return s, nil
+}
+
+func (s *IPCServerTestSuite) TestServer() {
+	path := filepath.Join(s.T().TempDir(), "test.ipc")
+
+	// left by the process that was not terminated gracefully
+	svr, err := NewServer(path)
+	s.NoError(err)
+	svr.listener.(*ownerListener).Listener.(interface{ SetUnlinkOnClose(bool) }).SetUnlinkOnClose(false)
+	svr.Close()
+	_, err = os.Stat(path)
+	s.NoError(err)
+
+	svr, err = NewServer(path)
+	s.NoError(err)
+	s.NoError(svr.RegisterName("test", &testService{}))
+
+	stopped := make(chan struct{})
+	go func() {
+		svr.Start()
+		close(stopped)
+	}()
+
+	fi, err := os.Stat(path)
+	s.NoError(err)
+	s.Equal(os.FileMode(socketMode), fi.Mode().Perm())
+
+	// in use
+	_, err = NewServer(path)
+	s.ErrorContains(err, "already in use")
+
+	ctx := context.Background()
+	cl, err := Dial(ctx, path)
+	s.NoError(err)
+	defer cl.Close()
+
+	var got string
+	s.NoError(cl.CallContext(ctx, &got, "test_echo", "hello"))
+	s.Equal("hello", got)
+	s.EqualError(cl.CallContext(ctx, &got, "test_echo", ""), "empty")
+	s.Error(cl.CallContext(ctx, &got, "test_unknown"))
+
+	s.NoError(svr.Close())
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		s.Fail("server not stopped")
+	}
+
+	_, err = Dial(ctx, path)
+	s.ErrorContains(err, "node is not running")
+}
+
+func (s *IPCServerTestSuite) TestLegacyServerClose() {
 	var (
 		sockname    = "ipc.sockname"
-		ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second)
 	)
-	svr, err := NewIPCServer(sockname)
+	svr, err := NewLegacyServer(sockname, nil)
 	s.NoError(err)
 
 	go func() {
@@ -33,4 +98,45 @@ func (s *IPCServerTestSuite) TestIPCServerClose() {
 	s.NoError(err)
 
 	<-ctx.Done()
+	s.ErrorIs(ctx.Err(), context.Canceled)
+}
+
+func (s *IPCServerTestSuite) TestLegacyWalletUnlock() {
+	sockname := "ipc.legacy"
+	unlocked := map[string]string{}
+	svr, err := NewLegacyServer(sockname, func(address, password string) error {
+		if password != "password" {
+			return errors.New("could not decrypt key with given password")
+		}
+		unlocked[address] = password
+		return nil
+	})
+	s.NoError(err)
+	go svr.Start()
+	defer svr.Close()
+
+	// the client of the older versions
+	cl, err := goipc.StartClient(sockname, nil)
+	s.Require().NoError(err)
+	defer cl.Close()
+	s.Eventually(func() bool {
+		cl.Read()
+		return cl.Status() == "Connected"
+	}, 3*time.Second, time.Second/4)
+
+	unlock := func(password string) string {
+		data, _ := json.Marshal(map[string]string{"Address": "0x01", "Password": password})
+		s.Require().NoError(cl.Write(legacyWalletUnlock, data))
+		for {
+			msg, err := cl.Read()
+			s.Require().NoError(err)
+			if msg.MsgType == legacyWalletUnlock {
+				return string(msg.Data)
+			}
+		}
+	}
+
+	s.Equal("could not decrypt key with given password", unlock("wrong"))
+	s.Equal("success!", unlock("password"))
+	s.Equal(map[string]string{"0x01": "password"}, unlocked)
 }
@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"log/slog"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// Level of the root logger, can be changed at runtime.
+	level = new(slog.LevelVar)
+
+	levels = map[string]slog.Level{
+		"trace": log.LevelTrace,
+		"debug": log.LevelDebug,
+		"info":  log.LevelInfo,
+		"warn":  log.LevelWarn,
+		"error": log.LevelError,
+	}
+)
+
+// Returns the level used when the configuration does not specify it,
+// debug if the `DEBUG` environment variable is set, otherwise info.
+func DefaultLevel() slog.Level {
+	if os.Getenv("DEBUG") != "" {
+		return log.LevelDebug
+	}
+	return log.LevelInfo
+}
+
+// Parse the level name, the empty name returns the default level.
+func ParseLevel(name string) (slog.Level, error) {
+	if name == "" {
+		return DefaultLevel(), nil
+	}
+	if lvl, ok := levels[strings.ToLower(name)]; ok {
+		return lvl, nil
+	}
+	return 0, fmt.Errorf("unknown log level: %s", name)
+}
+
+// Change the level of the handlers returned by `NewLevelHandler`.
+func SetLevel(lvl slog.Level) {
+	level.Set(lvl)
+}
+
+// Returns the current level.
+func Level() slog.Level {
+	return level.Level()
+}
+
+// Returns the handler dropping the records below the level set by `SetLevel`.
+// The parent handler should accept all levels.
+func NewLevelHandler(parent slog.Handler) slog.Handler {
+	return &LevelHandler{parent}
+}
+
+type LevelHandler struct {
+	slog.Handler
+}
+
+func (h *LevelHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	return lvl >= level.Level() && h.Handler.Enabled(ctx, lvl)
+}
+
+func (h *LevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LevelHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h *LevelHandler) WithGroup(name string) slog.Handler {
+	return &LevelHandler{h.Handler.WithGroup(name)}
+}
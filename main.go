@@ -16,15 +16,15 @@ func main() {
 }
 
 func setupLogger() {
-	level := slog.LevelInfo
-	if os.Getenv("DEBUG") != "" {
-		level = slog.LevelDebug
-	}
 	output := os.Stdout
 	useColor := true
 
+	// the level can be changed by the configuration at runtime
+	logger.SetLevel(logger.DefaultLevel())
+
 	var handler slog.Handler
-	handler = log.NewTerminalHandlerWithLevel(output, level, useColor)
+	handler = log.NewTerminalHandlerWithLevel(output, log.LevelTrace, useColor)
+	handler = logger.NewLevelHandler(handler)
 	handler = logger.NewCallerHandler(handler)
 
 	log.SetDefault(log.NewLogger(handler))
@@ -0,0 +1,44 @@
+package p2p
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+	ds "github.com/ipfs/go-datastore"
+	kaddht "github.com/libp2p/go-libp2p-kad-dht"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+)
+
+// Create libp2p host for the bootnode. Unlike `NewHost`, the DHT always runs
+// in server mode, as the bootnode is expected to be publicly reachable and
+// answer the queries of the other peers.
+func NewBootnodeHost(
+	ctx context.Context,
+	cfg *config.P2P,
+	priv crypto.PrivKey,
+	store ds.Batching,
+) (host.Host, error) {
+	h, _, _, _, err := newHost(ctx, cfg, priv, store, nil, kaddht.ModeServer)
+	if err != nil {
+		return nil, err
+	}
+
+	listens := []string{}
+	for _, ma := range h.Network().ListenAddresses() {
+		listens = append(listens, ma.String())
+	}
+	log.Info("Listening on: " + strings.Join(listens, ","))
+	log.Info("Bootnodes: " + strings.Join(cfg.Bootnodes, ","))
+	if cfg.PrivateNetworkKey != "" {
+		log.Info("Enabled private network")
+	}
+	if cfg.RelayService.Enable {
+		log.Info("Enabled circuit relay service")
+	}
+	log.Info("Bootnode started", "id", h.ID())
+
+	return h, nil
+}
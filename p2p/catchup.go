@@ -0,0 +1,384 @@
+package p2p
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ps "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	pb "github.com/oasysgames/oasys-optimism-verifier/proto/p2p/v1/gen"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+	"github.com/oasysgames/oasys-optimism-verifier/verse"
+)
+
+// Wait for connecting to peers before the first catch-up.
+var catchUpStartupDelay = 30 * time.Second
+
+func (w *Node) catchUpLoop(ctx context.Context) {
+	timer := time.NewTimer(catchUpStartupDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			w.catchUp(ctx)
+			timer.Reset(w.cfg.CatchUp.Interval)
+		}
+	}
+}
+
+// Request the signatures of the rollup indexes not yet verified of
+// the verses submitted by oneself from the stake-weighted peers.
+func (w *Node) catchUp(ctx context.Context) {
+	var requests []*pb.SignatureCatchUp_Request
+	w.versepool.Range(func(item *verse.VersePoolItem) bool {
+		if !item.CanSubmit() {
+			return true
+		}
+
+		contract := item.Verse().RollupContract()
+		nextIndex, err := w.versepool.NextIndex(ctx, contract, 0, false)
+		if err != nil {
+			w.log.Error("Failed to fetch next index", "contract", contract, "err", err)
+			return true
+		}
+
+		requests = append(requests, &pb.SignatureCatchUp_Request{
+			Contract:  contract[:],
+			FromIndex: nextIndex,
+			Limit:     uint64(w.cfg.CatchUp.MaxSignatures),
+		})
+		return true
+	})
+	if len(requests) == 0 {
+		return
+	}
+
+	peers := w.catchUpPeers(ctx, w.cfg.CatchUp.Peers)
+	if len(peers) == 0 {
+		w.log.Debug("No peers to catch up signatures")
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range peers {
+		wg.Add(1)
+		go func(id peer.ID) {
+			defer wg.Done()
+			w.requestSignatureCatchUp(ctx, id, requests)
+		}(id)
+	}
+	wg.Wait()
+}
+
+// Returns up to `n` connected peers supporting the catch-up protocol. Peers are chosen
+// at random, weighted by the total stake of the signers whose signatures they published.
+func (w *Node) catchUpPeers(ctx context.Context, n int) []peer.ID {
+	type candidate struct {
+		id  peer.ID
+		key float64
+	}
+	var candidates []*candidate
+	for _, id := range w.catchUpSupportedPeers() {
+		// weighted random sampling without replacement (Efraimidis and Spirakis)
+		weight := w.peerWeight(ctx, id, nil)
+		candidates = append(candidates, &candidate{id: id, key: math.Pow(rand.Float64(), 1/weight)})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].key > candidates[j].key })
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+
+	peers := make([]peer.ID, len(candidates))
+	for i, c := range candidates {
+		peers[i] = c.id
+	}
+	return peers
+}
+
+// Returns the connected peers supporting the catch-up protocol.
+func (w *Node) catchUpSupportedPeers() (peers []peer.ID) {
+	connected := w.h.Network().Peers()
+	w.peerSigners.retain(connected)
+
+	for _, id := range connected {
+		if id == w.h.ID() {
+			continue
+		}
+		if protos, err := w.h.Peerstore().SupportsProtocols(id, catchUpProtocol); err == nil && len(protos) > 0 {
+			peers = append(peers, id)
+		}
+	}
+	return peers
+}
+
+// Returns the weight of the peer, which is the total stake (in units of the minimum
+// stake) of the signers published by the peer excluding `excludes`, plus one.
+func (w *Node) peerWeight(ctx context.Context, id peer.ID, excludes map[common.Address]struct{}) float64 {
+	// peers without known signers have the minimum weight
+	weight := 1.0
+	for _, signer := range w.peerSigners.get(id) {
+		if _, ok := excludes[signer]; ok {
+			continue
+		}
+		stake := new(big.Float).SetInt(w.stakemanager.StakeBySigner(ctx, signer))
+		f, _ := stake.Quo(stake, new(big.Float).SetInt(ethutil.TenMillionOAS)).Float64()
+		weight += f
+	}
+	return weight
+}
+
+func (w *Node) requestSignatureCatchUp(
+	ctx context.Context,
+	peer peer.ID,
+	requests []*pb.SignatureCatchUp_Request,
+) {
+	logctx := []interface{}{"peer", peer}
+
+	s, err := w.openStream(ctx, peer, catchUpProtocol)
+	if err != nil {
+		return
+	}
+	defer w.closeStream(s)
+
+	m := &pb.Stream{Body: &pb.Stream_SignatureCatchUp{
+		SignatureCatchUp: &pb.SignatureCatchUp{Requests: requests},
+	}}
+	if err := w.writeStream(s, m); err != nil {
+		w.log.Error("Failed to send catch-up request", append(logctx, "err", err)...)
+		return
+	}
+	w.log.Info("Sent catch-up request", append(logctx, "verses", len(requests))...)
+
+	maxReceives := w.cfg.CatchUp.MaxSignatures * len(requests)
+	var receives, saves int
+	defer func() {
+		w.log.Info("Caught up signatures", append(logctx, "receives", receives, "saves", saves)...)
+	}()
+
+	for {
+		m, err := w.readStream(s)
+		if err != nil {
+			w.log.Debug("Failed to read stream message", append(logctx, "err", err)...)
+			return
+		}
+
+		body := m.GetSignatureCatchUp()
+		if body == nil {
+			if m.GetEom() == nil {
+				w.log.Warn("Received an unknown message", logctx...)
+				w.receivedUnknownMessage(peer)
+			}
+			return
+		}
+
+		responses := body.GetResponses()
+		if receives += len(responses); receives > maxReceives {
+			w.log.Warn("Received too many signatures", logctx...)
+			return
+		}
+
+		n, err := w.receiveSignatures(ctx, peer, responses, "requestSignatureCatchUp")
+		if saves += n; err != nil {
+			return
+		}
+	}
+}
+
+// Verify and save the signatures received from the peer. Returns
+// an error if any of them is invalid since the peer is dishonest.
+func (w *Node) receiveSignatures(
+	ctx context.Context,
+	peer peer.ID,
+	sigs []*pb.OptimismSignature,
+	caller string,
+) (saves int, err error) {
+	if err := w.outboundSem.Acquire(ctx, 1); err != nil {
+		w.log.Error("Failed to acquire outbound semaphore", "peer", peer, "err", err)
+		return 0, err
+	}
+	defer w.outboundSem.Release(1)
+	w.throttling(w.outboundThrot, len(sigs), "in", caller, "peer", peer)
+
+	for _, sig := range sigs {
+		switch result, err := validateSignature(ctx, w.hubLayerChainID, w.stakemanager, sig); result {
+		case ps.ValidationReject:
+			w.log.Error("Invalid signature", "peer", peer,
+				"signer", common.BytesToAddress(sig.Signer), "id", sig.Id, "err", err)
+			w.penalize(peer, misbehaviourInvalidSignature)
+			return saves, err
+		case ps.ValidationIgnore:
+			continue
+		}
+		if w.handleRemoteSignature(ctx, peer, sig) {
+			saves++
+		}
+	}
+	return saves, nil
+}
+
+func (w *Node) newCatchUpHandler(ctx context.Context) network.StreamHandler {
+	return func(s network.Stream) {
+		defer w.closeStream(s)
+
+		w.meterStreamHandled.Incr()
+
+		peer := s.Conn().RemotePeer()
+		m, err := w.readStream(s)
+		if err != nil {
+			w.log.Debug("Failed to read stream message", "peer", peer, "err", err)
+			return
+		}
+
+		switch t := m.Body.(type) {
+		case *pb.Stream_SignatureCatchUp:
+			w.handleSignatureCatchUpRequest(ctx, s, t.SignatureCatchUp)
+		case *pb.Stream_SignatureRequest:
+			w.handleSignatureRequest(ctx, s, t.SignatureRequest)
+		default:
+			w.log.Warn("Received an unknown message", "peer", peer)
+			w.receivedUnknownMessage(peer)
+		}
+	}
+}
+
+func (w *Node) handleSignatureCatchUpRequest(
+	ctx context.Context,
+	s network.Stream,
+	request *pb.SignatureCatchUp,
+) {
+	peerID := s.Conn().RemotePeer()
+	logctx := []interface{}{"peer", peerID}
+
+	// number of signatures obtained from the database
+	queryLimit := w.cfg.InboundLimits.Throttling / w.cfg.InboundLimits.Concurrency
+
+	// sending time limit
+	deadline := time.Now().Add(w.cfg.InboundLimits.MaxSendTime)
+
+	// By finely acquiring the semaphore, it prevents
+	// other peers from being blocked for a long time.
+	inboundSem, inboundThrot := w.inboundLimits(ctx, peerID)
+	sem := util.NewReleaseGuardSemaphore(inboundSem)
+	defer sem.ReleaseALL()
+
+	for _, req := range request.GetRequests() {
+		if len(req.Contract) != common.AddressLength {
+			w.log.Warn("Malformed catch-up request", logctx...)
+			return
+		}
+		contract := common.BytesToAddress(req.Contract)
+
+		limit := w.cfg.CatchUp.MaxSignatures
+		if req.Limit > 0 && req.Limit < uint64(limit) {
+			limit = int(req.Limit)
+		}
+
+		logctx := append(logctx, "contract", contract, "from-index", req.FromIndex)
+		w.log.Info("Received catch-up request", logctx...)
+
+		for offset := 0; offset < limit; {
+			if time.Now().After(deadline) {
+				w.log.Warn("Time up", logctx...)
+				return
+			} else if err := sem.Acquire(ctx, 1); err != nil {
+				w.log.Error("Failed to acquire inbound semaphore", append(logctx, "err", err)...)
+				return
+			}
+
+			sigs, err := w.db.OPSignature.FindFromIndex(
+				contract, req.FromIndex, min(queryLimit, limit-offset), offset)
+			sem.ReleaseALL()
+			if err != nil {
+				w.log.Error("Failed to find requested signatures", append(logctx, "err", err)...)
+				return
+			}
+
+			sigLen := len(sigs)
+			if sigLen == 0 {
+				break // reached the last
+			}
+			offset += sigLen
+			w.throttling(inboundThrot, sigLen,
+				"in", "handleSignatureCatchUpRequest", "peer", peerID)
+			w.throttling(w.peerThrots.get(peerID), sigLen,
+				"in", "handleSignatureCatchUpRequest", "peer", peerID)
+
+			responses := make([]*pb.OptimismSignature, 0, sigLen)
+			for _, sig := range sigs {
+				if w.stakemanager.StakeBySigner(ctx, sig.Signer.Address).Cmp(ethutil.TenMillionOAS) >= 0 {
+					responses = append(responses, toProtoBufSig(sig))
+				}
+			}
+			if len(responses) == 0 {
+				continue
+			}
+
+			m := &pb.Stream{Body: &pb.Stream_SignatureCatchUp{
+				SignatureCatchUp: &pb.SignatureCatchUp{Responses: responses},
+			}}
+			if err := w.writeStream(s, m); err != nil {
+				w.log.Error("Failed to send signatures", append(logctx, "err", err)...)
+				return
+			}
+			w.log.Info("Sent signatures", append(logctx, "sents", len(responses))...)
+		}
+	}
+}
+
+// Signers whose signatures were published by each peer.
+type peerSigners struct {
+	mu      sync.Mutex
+	signers map[peer.ID]map[common.Address]struct{}
+}
+
+func newPeerSigners() *peerSigners {
+	return &peerSigners{signers: map[peer.ID]map[common.Address]struct{}{}}
+}
+
+func (p *peerSigners) add(peer peer.ID, signer common.Address) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.signers[peer]; !ok {
+		p.signers[peer] = map[common.Address]struct{}{}
+	}
+	p.signers[peer][signer] = struct{}{}
+}
+
+func (p *peerSigners) get(peer peer.ID) (signers []common.Address) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for signer := range p.signers[peer] {
+		signers = append(signers, signer)
+	}
+	return signers
+}
+
+// Forget the peers not contained in `peers`.
+func (p *peerSigners) retain(peers []peer.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	keeps := map[peer.ID]struct{}{}
+	for _, peer := range peers {
+		keeps[peer] = struct{}{}
+	}
+	for peer := range p.signers {
+		if _, ok := keeps[peer]; !ok {
+			delete(p.signers, peer)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+)
+
+var (
+	// Interval to check the connections to the static peers.
+	staticPeerCheckInterval = 10 * time.Second
+
+	// Maximum interval to redial the static peer failed to connect.
+	staticPeerMaxBackoff = 5 * time.Minute
+
+	mdnsConnectTimeout = 10 * time.Second
+)
+
+// Start the mDNS discovery service, which is closed when the context is done.
+func startMDNS(ctx context.Context, cfg *config.P2P, h host.Host) error {
+	svc := mdns.NewMdnsService(h, cfg.MDNS.ServiceName, &mdnsNotifee{ctx: ctx, h: h})
+	if err := svc.Start(); err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		svc.Close()
+	}()
+	return nil
+}
+
+type mdnsNotifee struct {
+	ctx context.Context
+	h   host.Host
+}
+
+func (n *mdnsNotifee) HandlePeerFound(info peer.AddrInfo) {
+	if info.ID == n.h.ID() || n.h.Network().Connectedness(info.ID) == network.Connected {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(n.ctx, mdnsConnectTimeout)
+		defer cancel()
+
+		if err := n.h.Connect(ctx, info); err != nil {
+			log.Debug("Failed to connect to mDNS peer", "peer", info.ID, "err", err)
+		} else {
+			log.Info("Connected to mDNS peer", "peer", info.ID)
+		}
+	}()
+}
+
+// Keep connecting to the static peers until the context is done.
+func startStaticPeers(ctx context.Context, cfg *config.P2P, h host.Host) error {
+	infos := make([]*peer.AddrInfo, len(cfg.StaticPeers))
+	for i, addr := range cfg.StaticPeers {
+		info, err := peer.AddrInfoFromString(addr)
+		if err != nil {
+			return fmt.Errorf("invalid static peer: %s: %w", addr, err)
+		}
+		infos[i] = info
+	}
+
+	for _, info := range infos {
+		h.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
+		go keepStaticPeer(ctx, h, *info)
+	}
+	return nil
+}
+
+func keepStaticPeer(ctx context.Context, h host.Host, info peer.AddrInfo) {
+	wait := time.Duration(0)
+	backoff := staticPeerCheckInterval
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		wait = staticPeerCheckInterval
+		if h.Network().Connectedness(info.ID) == network.Connected {
+			backoff = staticPeerCheckInterval
+			continue
+		}
+
+		if err := h.Connect(ctx, info); err != nil {
+			log.Warn("Failed to connect to static peer",
+				"peer", info.ID, "retry-after", backoff, "err", err)
+			wait, backoff = backoff, min(backoff*2, staticPeerMaxBackoff)
+		} else {
+			log.Info("Connected to static peer", "peer", info.ID)
+			backoff = staticPeerCheckInterval
+		}
+	}
+}
@@ -17,3 +17,7 @@ func (e *ReadWriteError) Error() string {
 func (e *ReadWriteError) String() string {
 	return e.Error()
 }
+
+func (e *ReadWriteError) Unwrap() error {
+	return e.origin
+}
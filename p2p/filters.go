@@ -44,7 +44,9 @@ func AddrsFactoryOpt(appendAnnounce, noAnnounce []string) (libp2p.Option, error)
 	}), nil
 }
 
-func ConnectionFilterOpt(filters []string) (libp2p.Option, error) {
+// Returns the connection gater refusing the filtered addresses and the
+// blocklisted peers. The blocklist is optional and may be nil.
+func ConnectionGaterOpt(filters []string, blocklist *Blocklist) (libp2p.Option, error) {
 	mafilters := multiaddr.NewFilters()
 	for _, s := range filters {
 		if mask, err := mafilter.NewMask(s); err != nil {
@@ -53,27 +55,34 @@ func ConnectionFilterOpt(filters []string) (libp2p.Option, error) {
 			mafilters.AddFilter(*mask, multiaddr.ActionDeny)
 		}
 	}
-	return libp2p.ConnectionGater((*multiAddrFilter)(mafilters)), nil
+	return libp2p.ConnectionGater(&connectionGater{filters: mafilters, blocklist: blocklist}), nil
 }
 
-type multiAddrFilter multiaddr.Filters
+type connectionGater struct {
+	filters   *multiaddr.Filters
+	blocklist *Blocklist
+}
+
+func (g *connectionGater) blocked(p peer.ID) bool {
+	return g.blocklist != nil && g.blocklist.IsBlocked(p)
+}
 
-func (f *multiAddrFilter) InterceptAddrDial(_ peer.ID, addr multiaddr.Multiaddr) (allow bool) {
-	return !(*multiaddr.Filters)(f).AddrBlocked(addr)
+func (g *connectionGater) InterceptAddrDial(_ peer.ID, addr multiaddr.Multiaddr) (allow bool) {
+	return !g.filters.AddrBlocked(addr)
 }
 
-func (f *multiAddrFilter) InterceptPeerDial(p peer.ID) (allow bool) {
-	return true
+func (g *connectionGater) InterceptPeerDial(p peer.ID) (allow bool) {
+	return !g.blocked(p)
 }
 
-func (f *multiAddrFilter) InterceptAccept(connAddr network.ConnMultiaddrs) (allow bool) {
-	return !(*multiaddr.Filters)(f).AddrBlocked(connAddr.RemoteMultiaddr())
+func (g *connectionGater) InterceptAccept(connAddr network.ConnMultiaddrs) (allow bool) {
+	return !g.filters.AddrBlocked(connAddr.RemoteMultiaddr())
 }
 
-func (f *multiAddrFilter) InterceptSecured(_ network.Direction, _ peer.ID, connAddr network.ConnMultiaddrs) (allow bool) {
-	return !(*multiaddr.Filters)(f).AddrBlocked(connAddr.RemoteMultiaddr())
+func (g *connectionGater) InterceptSecured(_ network.Direction, p peer.ID, connAddr network.ConnMultiaddrs) (allow bool) {
+	return !g.filters.AddrBlocked(connAddr.RemoteMultiaddr()) && !g.blocked(p)
 }
 
-func (f *multiAddrFilter) InterceptUpgraded(_ network.Conn) (allow bool, reason control.DisconnectReason) {
+func (g *connectionGater) InterceptUpgraded(_ network.Conn) (allow bool, reason control.DisconnectReason) {
 	return true, 0
 }
@@ -0,0 +1,296 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	pb "github.com/oasysgames/oasys-optimism-verifier/proto/p2p/v1/gen"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
+)
+
+const (
+	handshakeProtocol = "/oasys-optimism-verifier/handshake/1.0.0"
+	handshakeNonceLen = 32
+
+	// tag of the connection manager to protect the connections to validators
+	validatorConnTag = "oasys-validator"
+)
+
+// Signer of the verifier, used to prove the ownership of the peer ID.
+type Signer interface {
+	From() common.Address
+	SignData(data []byte) (sig []byte, err error)
+}
+
+// Verified peer and the signer address of the verifier running on it.
+type VerifiedPeer struct {
+	PeerID peer.ID        `json:"peer_id"`
+	Signer common.Address `json:"signer"`
+}
+
+// Returns the message that binds the peer ID to the signer.
+func newHandshakeMessage(hubLayerChainID *big.Int, id peer.ID, nonce []byte) *ethutil.Message {
+	abiPacked := bytes.Join([][]byte{
+		common.LeftPadBytes(hubLayerChainID.Bytes(), 32),
+		[]byte(handshakeProtocol),
+		[]byte(id),
+		nonce,
+	}, nil)
+	_, msg := accounts.TextAndHash(crypto.Keccak256(abiPacked))
+	return &ethutil.Message{AbiPacked: abiPacked, Eip712Msg: msg}
+}
+
+func (w *Node) handshakeLoop(ctx context.Context) {
+	sub, err := w.h.EventBus().Subscribe([]interface{}{
+		new(event.EvtPeerIdentificationCompleted),
+		new(event.EvtPeerConnectednessChanged),
+	})
+	if err != nil {
+		w.log.Error("Failed to subscribe to the peer events", "err", err)
+		return
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-sub.Out():
+			switch evt := e.(type) {
+			case event.EvtPeerIdentificationCompleted:
+				w.peerIdentified(ctx, evt)
+			case event.EvtPeerConnectednessChanged:
+				if evt.Connectedness == network.NotConnected {
+					w.unverifyPeer(evt.Peer)
+					w.peerThrots.delete(evt.Peer)
+				}
+			}
+		}
+	}
+}
+
+// Exchange the node info and handshake with the identified peer.
+func (w *Node) peerIdentified(ctx context.Context, evt event.EvtPeerIdentificationCompleted) {
+	if slices.Contains(evt.Protocols, nodeInfoProtocol) {
+		go func(id peer.ID) {
+			if err := w.exchangeNodeInfo(ctx, id); err != nil {
+				w.log.Debug("Failed to exchange node info", "peer", id, "err", err)
+			}
+		}(evt.Peer)
+	} else if w.cfg.MinPeerVersion != "" && isVerifierPeer(evt.Protocols) {
+		// the verifier older than the node info exchange
+		w.refusePeer(evt.Peer, "0.0.0")
+		return
+	}
+
+	if !slices.Contains(evt.Protocols, handshakeProtocol) {
+		return
+	}
+	if _, ok := w.verifiedPeers.get(evt.Peer); ok {
+		return
+	}
+	go func(id peer.ID) {
+		if err := w.handshake(ctx, id); err != nil {
+			w.log.Debug("Failed to handshake", "peer", id, "err", err)
+		}
+	}(evt.Peer)
+}
+
+// Ask the peer to sign its peer ID with the verifier key.
+func (w *Node) handshake(ctx context.Context, id peer.ID) error {
+	s, err := w.openStream(ctx, id, handshakeProtocol)
+	if err != nil {
+		return err
+	}
+	defer w.closeStream(s)
+
+	nonce := make([]byte, handshakeNonceLen)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	req := &pb.Stream{Body: &pb.Stream_Handshake{Handshake: &pb.Handshake{Nonce: nonce}}}
+	if err := w.writeStream(s, req); err != nil {
+		return err
+	}
+
+	res, err := w.readStream(s)
+	if err != nil {
+		return err
+	}
+	t := res.GetHandshake()
+	if t == nil {
+		return errors.New("unexpected response")
+	} else if len(t.Signer) == 0 {
+		// not a verifier
+		return nil
+	}
+
+	signer, err := w.verifyHandshake(ctx, id, nonce, t)
+	if err != nil {
+		w.log.Warn("Invalid handshake", "peer", id, "err", err)
+		return err
+	}
+
+	w.verifiedPeers.set(id, signer)
+	w.h.ConnManager().Protect(id, validatorConnTag)
+	w.log.Info("Verified validator peer", "peer", id, "signer", signer)
+	return nil
+}
+
+func (w *Node) verifyHandshake(
+	ctx context.Context,
+	id peer.ID,
+	nonce []byte,
+	res *pb.Handshake,
+) (common.Address, error) {
+	if len(res.Signer) != common.AddressLength {
+		return common.Address{}, errors.New("malformed signer")
+	}
+	signer := common.BytesToAddress(res.Signer)
+
+	msg := newHandshakeMessage(w.hubLayerChainID, id, nonce)
+	if err := msg.VerifySigner(res.Signature, signer); err != nil {
+		return common.Address{}, err
+	}
+	if w.stakemanager.StakeBySigner(ctx, signer).Cmp(ethutil.TenMillionOAS) == -1 {
+		return common.Address{}, fmt.Errorf("stake amount shortage: %s", signer)
+	}
+	return signer, nil
+}
+
+func (w *Node) newHandshakeHandler() network.StreamHandler {
+	return func(s network.Stream) {
+		defer w.closeStream(s)
+
+		w.meterStreamHandled.Incr()
+
+		peer := s.Conn().RemotePeer()
+		m, err := w.readStream(s)
+		if err != nil {
+			w.log.Debug("Failed to read stream message", "peer", peer, "err", err)
+			return
+		}
+		t := m.GetHandshake()
+		if t == nil || len(t.Nonce) != handshakeNonceLen {
+			w.log.Warn("Received an unknown message", "peer", peer)
+			w.receivedUnknownMessage(peer)
+			return
+		}
+
+		res := &pb.Handshake{}
+		if w.signer != nil {
+			sig, err := newHandshakeMessage(w.hubLayerChainID, w.h.ID(), t.Nonce).Signature(w.signer.SignData)
+			if err != nil {
+				w.log.Error("Failed to sign handshake", "peer", peer, "err", err)
+				return
+			}
+			signer := w.signer.From()
+			res.Signer, res.Signature = signer[:], sig[:]
+		}
+		if err := w.writeStream(s, &pb.Stream{Body: &pb.Stream_Handshake{Handshake: res}}); err != nil {
+			w.log.Error("Failed to send handshake", "peer", peer, "err", err)
+		}
+	}
+}
+
+func (w *Node) unverifyPeer(id peer.ID) {
+	if _, ok := w.verifiedPeers.get(id); ok {
+		w.verifiedPeers.delete(id)
+		w.h.ConnManager().Unprotect(id, validatorConnTag)
+	}
+}
+
+// Returns the signer of the peer if the peer is verified
+// and the signer still has the validator candidate stake.
+func (w *Node) verifiedSigner(ctx context.Context, id peer.ID) (common.Address, bool) {
+	signer, ok := w.verifiedPeers.get(id)
+	if !ok || w.stakemanager.StakeBySigner(ctx, signer).Cmp(ethutil.TenMillionOAS) == -1 {
+		return common.Address{}, false
+	}
+	return signer, true
+}
+
+// Returns the verified peers.
+func (w *Node) VerifiedPeers() []*VerifiedPeer {
+	return w.verifiedPeers.list()
+}
+
+// Returns the inbound semaphore and rate limiter for the peer. Unverified peers
+// share separate limits so that they cannot exhaust the capacity for validators.
+func (w *Node) inboundLimits(ctx context.Context, id peer.ID) (*semaphore.Weighted, *rate.Limiter) {
+	if _, ok := w.verifiedSigner(ctx, id); ok {
+		return w.inboundSem, w.inboundThrot
+	}
+	return w.unverifiedSem, w.unverifiedThrot
+}
+
+// Returns whether to open the stream to the peer via circuit relay.
+func (w *Node) allowRelay(ctx context.Context, id peer.ID, pid protocol.ID) bool {
+	if pid == handshakeProtocol {
+		return true
+	}
+	if _, ok := w.verifiedSigner(ctx, id); ok {
+		return true
+	}
+	protos, err := w.h.Peerstore().SupportsProtocols(id, handshakeProtocol)
+	return err == nil && len(protos) == 0
+}
+
+type verifiedPeers struct {
+	mu      sync.Mutex
+	signers map[peer.ID]common.Address
+}
+
+func newVerifiedPeers() *verifiedPeers {
+	return &verifiedPeers{signers: map[peer.ID]common.Address{}}
+}
+
+func (v *verifiedPeers) get(id peer.ID) (common.Address, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	signer, ok := v.signers[id]
+	return signer, ok
+}
+
+func (v *verifiedPeers) set(id peer.ID, signer common.Address) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.signers[id] = signer
+}
+
+func (v *verifiedPeers) delete(id peer.ID) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	delete(v.signers, id)
+}
+
+func (v *verifiedPeers) list() []*VerifiedPeer {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	peers := make([]*VerifiedPeer, 0, len(v.signers))
+	for id, signer := range v.signers {
+		peers = append(peers, &VerifiedPeer{PeerID: id, Signer: signer})
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].PeerID < peers[j].PeerID })
+	return peers
+}
@@ -0,0 +1,155 @@
+package p2p
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+)
+
+// tag of the connection manager to protect the connections
+// to the bootnodes, the relay nodes and the static peers
+const protectedConnTag = "oasys-protected"
+
+// Stream protocols of the verifier.
+var verifierProtocols = []protocol.ID{streamProtocol, catchUpProtocol, handshakeProtocol, nodeInfoProtocol}
+
+func connManagerOpt(cfg *config.P2P) (libp2p.Option, error) {
+	cm, err := connmgr.NewConnManager(
+		cfg.ConnManager.LowWater,
+		cfg.ConnManager.HighWater,
+		connmgr.WithGracePeriod(cfg.ConnManager.GracePeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	var protected []string
+	protected = append(protected, cfg.Bootnodes...)
+	protected = append(protected, cfg.RelayClient.RelayNodes...)
+	protected = append(protected, cfg.StaticPeers...)
+	protected = append(protected, cfg.ConnManager.ProtectedPeers...)
+	for _, addr := range protected {
+		info, err := peer.AddrInfoFromString(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid protected peer: %s: %w", addr, err)
+		}
+		cm.Protect(info.ID, protectedConnTag)
+	}
+
+	return libp2p.ConnectionManager(cm), nil
+}
+
+func resourceManagerOpt(cfg *config.P2P) (libp2p.Option, error) {
+	scaling := rcmgr.DefaultLimits
+	libp2p.SetDefaultServiceLimits(&scaling)
+
+	// zero values are treated as `rcmgr.DefaultLimit`
+	limits := rcmgr.PartialLimitConfig{
+		System: rcmgr.ResourceLimits{
+			Memory:  rcmgr.LimitVal64(cfg.ResourceManager.MaxMemory),
+			Conns:   rcmgr.LimitVal(cfg.ResourceManager.MaxConnections),
+			Streams: rcmgr.LimitVal(cfg.ResourceManager.MaxStreams),
+		},
+		Protocol: map[protocol.ID]rcmgr.ResourceLimits{},
+	}
+	for _, pid := range verifierProtocols {
+		limits.Protocol[pid] = rcmgr.ResourceLimits{
+			Memory:  rcmgr.LimitVal64(cfg.ResourceManager.ProtocolMemory),
+			Streams: rcmgr.LimitVal(cfg.ResourceManager.ProtocolStreams),
+		}
+	}
+
+	rm, err := rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(limits.Build(scaling.AutoScale())))
+	if err != nil {
+		return nil, err
+	}
+	return libp2p.ResourceManager(rm), nil
+}
+
+type connManagerStatus struct {
+	LowWater    int    `json:"low_water"`
+	HighWater   int    `json:"high_water"`
+	GracePeriod string `json:"grace_period"`
+	LastTrim    string `json:"last_trim"`
+	Connections int    `json:"connections"`
+}
+
+func newConnManagerStatus(h host.Host) *connManagerStatus {
+	cm, ok := h.ConnManager().(*connmgr.BasicConnMgr)
+	if !ok {
+		return nil
+	}
+
+	info := cm.GetInfo()
+	s := &connManagerStatus{
+		LowWater:    info.LowWater,
+		HighWater:   info.HighWater,
+		GracePeriod: info.GracePeriod.String(),
+		Connections: info.ConnCount,
+	}
+	if !info.LastTrim.IsZero() {
+		s.LastTrim = info.LastTrim.UTC().Format(time.RFC3339)
+	}
+	return s
+}
+
+// Current usage and effective limits of a resource scope.
+type resourceStatus struct {
+	Scope        string `json:"scope"`
+	Memory       int64  `json:"memory"`
+	MemoryLimit  int64  `json:"memory_limit"`
+	Conns        int    `json:"conns"`
+	ConnsLimit   int    `json:"conns_limit"`
+	Streams      int    `json:"streams"`
+	StreamsLimit int    `json:"streams_limit"`
+	FD           int    `json:"fd"`
+	FDLimit      int    `json:"fd_limit"`
+}
+
+func newResourceStatus(name string, scope network.ResourceScope) *resourceStatus {
+	stat := scope.Stat()
+	s := &resourceStatus{
+		Scope:   name,
+		Memory:  stat.Memory,
+		Conns:   stat.NumConnsInbound + stat.NumConnsOutbound,
+		Streams: stat.NumStreamsInbound + stat.NumStreamsOutbound,
+		FD:      stat.NumFD,
+	}
+	if l, ok := scope.(rcmgr.ResourceScopeLimiter); ok {
+		limit := l.Limit()
+		s.MemoryLimit = limit.GetMemoryLimit()
+		s.ConnsLimit = limit.GetConnTotalLimit()
+		s.StreamsLimit = limit.GetStreamTotalLimit()
+		s.FDLimit = limit.GetFDLimit()
+	}
+	return s
+}
+
+// Returns the resource status of the whole node and each verifier protocol.
+func newResourceStatuses(h host.Host) (statuses []*resourceStatus) {
+	rm := h.Network().ResourceManager()
+
+	rm.ViewSystem(func(scope network.ResourceScope) error {
+		statuses = append(statuses, newResourceStatus("system", scope))
+		return nil
+	})
+
+	var protos []*resourceStatus
+	for _, pid := range verifierProtocols {
+		rm.ViewProtocol(pid, func(scope network.ProtocolScope) error {
+			protos = append(protos, newResourceStatus(string(pid), scope))
+			return nil
+		})
+	}
+	sort.Slice(protos, func(i, j int) bool { return protos[i].Scope < protos[j].Scope })
+
+	return append(statuses, protos...)
+}
@@ -0,0 +1,232 @@
+package p2p
+
+import (
+	"errors"
+	"math"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/time/rate"
+)
+
+type misbehaviour int
+
+const (
+	misbehaviourInvalidSignature misbehaviour = iota
+	misbehaviourUnknownMessage
+	misbehaviourTimeout
+)
+
+// Scores added to the peer for each misbehaviour.
+var misbehaviourWeights = map[misbehaviour]float64{
+	misbehaviourInvalidSignature: 50,
+	misbehaviourUnknownMessage:   20,
+	misbehaviourTimeout:          5,
+}
+
+func (m misbehaviour) String() string {
+	switch m {
+	case misbehaviourInvalidSignature:
+		return "invalid-signature"
+	case misbehaviourUnknownMessage:
+		return "unknown-message"
+	case misbehaviourTimeout:
+		return "timeout"
+	default:
+		return "unknown"
+	}
+}
+
+// Blocklisted peer and the time until which the connections are refused.
+type BlockedPeer struct {
+	PeerID peer.ID   `json:"peer_id"`
+	Until  time.Time `json:"until"`
+}
+
+// Peers temporarily refused by the connection gater due to misbehaviour.
+type Blocklist struct {
+	mu    sync.Mutex
+	peers map[peer.ID]time.Time
+}
+
+func NewBlocklist() *Blocklist {
+	return &Blocklist{peers: map[peer.ID]time.Time{}}
+}
+
+func (b *Blocklist) Block(id peer.ID, d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.peers[id] = time.Now().Add(d)
+}
+
+func (b *Blocklist) IsBlocked(id peer.ID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	until, ok := b.peers[id]
+	if ok && time.Now().After(until) {
+		delete(b.peers, id)
+		return false
+	}
+	return ok
+}
+
+// Returns the blocklisted peers in order of the expiration.
+func (b *Blocklist) List() []*BlockedPeer {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	peers := []*BlockedPeer{}
+	for id, until := range b.peers {
+		if now.After(until) {
+			delete(b.peers, id)
+			continue
+		}
+		peers = append(peers, &BlockedPeer{PeerID: id, Until: until})
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].Until.Before(peers[j].Until) })
+	return peers
+}
+
+// Misbehaviour scores of the peers, halved every `decay`.
+type peerScores struct {
+	mu     sync.Mutex
+	decay  time.Duration
+	scores map[peer.ID]*peerScore
+}
+
+type peerScore struct {
+	value   float64
+	updated time.Time
+}
+
+func newPeerScores(decay time.Duration) *peerScores {
+	return &peerScores{decay: decay, scores: map[peer.ID]*peerScore{}}
+}
+
+func (p *peerScores) decayed(s *peerScore, now time.Time) float64 {
+	if p.decay <= 0 {
+		return s.value
+	}
+	return s.value * math.Pow(0.5, float64(now.Sub(s.updated))/float64(p.decay))
+}
+
+// Add the weight to the score of the peer and returns the new score.
+func (p *peerScores) add(id peer.ID, weight float64, now time.Time) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s, ok := p.scores[id]
+	if !ok {
+		s = &peerScore{}
+		p.scores[id] = s
+	}
+	s.value, s.updated = p.decayed(s, now)+weight, now
+	return s.value
+}
+
+func (p *peerScores) get(id peer.ID, now time.Time) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if s, ok := p.scores[id]; ok {
+		return p.decayed(s, now)
+	}
+	return 0
+}
+
+func (p *peerScores) delete(id peer.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.scores, id)
+}
+
+// Forget the peers whose score has almost decayed.
+func (p *peerScores) prune(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, s := range p.scores {
+		if p.decayed(s, now) < 1 {
+			delete(p.scores, id)
+		}
+	}
+}
+
+// Token buckets limiting the number of signatures sent to each peer.
+type peerThrottles struct {
+	mu       sync.Mutex
+	limit    int
+	limiters map[peer.ID]*rate.Limiter
+}
+
+func newPeerThrottles(limit int) *peerThrottles {
+	return &peerThrottles{limit: limit, limiters: map[peer.ID]*rate.Limiter{}}
+}
+
+func (p *peerThrottles) get(id peer.ID) *rate.Limiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	l, ok := p.limiters[id]
+	if !ok {
+		if p.limit > 0 {
+			l = rate.NewLimiter(rate.Limit(p.limit), p.limit)
+		} else {
+			l = rate.NewLimiter(rate.Inf, 0)
+		}
+		p.limiters[id] = l
+	}
+	return l
+}
+
+func (p *peerThrottles) delete(id peer.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.limiters, id)
+}
+
+// Add the score of the misbehaviour to the peer, and blocklist
+// the peer for a while if the score exceeds the threshold.
+func (w *Node) penalize(id peer.ID, m misbehaviour) {
+	threshold := w.cfg.Misbehaviour.Threshold
+	if threshold <= 0 {
+		return
+	}
+
+	score := w.peerScores.add(id, misbehaviourWeights[m], time.Now())
+	w.log.Debug("Penalized peer", "peer", id, "misbehaviour", m, "score", score)
+	if score < threshold {
+		return
+	}
+
+	w.peerScores.delete(id)
+	w.blocklist.Block(id, w.cfg.Misbehaviour.BlockDuration)
+	w.h.Network().ClosePeer(id)
+	w.meterPeersBlocked.Incr()
+	w.log.Warn("Blocklisted misbehaving peer", "peer", id,
+		"misbehaviour", m, "score", score, "duration", w.cfg.Misbehaviour.BlockDuration)
+}
+
+// Count the unknown message and penalize the sender.
+func (w *Node) receivedUnknownMessage(id peer.ID) {
+	w.meterStreamUnknownMsg.Incr()
+	w.penalize(id, misbehaviourUnknownMessage)
+}
+
+// Returns the blocklisted peers.
+func (w *Node) BlockedPeers() []*BlockedPeer {
+	return w.blocklist.List()
+}
+
+func isTimeout(err error) bool {
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Timeout()
+}
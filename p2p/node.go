@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -18,23 +19,26 @@ import (
 	"github.com/libp2p/go-libp2p/core/metrics"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/libp2p/go-libp2p/core/routing"
 	msgio "github.com/libp2p/go-msgio"
 	"github.com/oasysgames/oasys-optimism-verifier/config"
 	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
 	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/equivocation"
 	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
 	meter "github.com/oasysgames/oasys-optimism-verifier/metrics"
 	pb "github.com/oasysgames/oasys-optimism-verifier/proto/p2p/v1/gen"
 	"github.com/oasysgames/oasys-optimism-verifier/util"
 	"github.com/oasysgames/oasys-optimism-verifier/verse"
-	"github.com/oklog/ulid/v2"
 	"golang.org/x/sync/semaphore"
 	"golang.org/x/time/rate"
 	"google.golang.org/protobuf/proto"
 )
 
 const (
+	catchUpProtocol = "/oasys-optimism-verifier/catchup/1.0.0"
+	// Deprecated: Shared by all verses, superseded by the per-verse topics.
 	pubsubTopic = "/oasys-optimism-verifier/pubsub/1.0.0"
 	// Deprecated:
 	streamProtocol = "/oasys-optimism-verifier/stream/1.0.0"
@@ -48,6 +52,7 @@ var (
 )
 
 type Node struct {
+	ctx             atomic.Pointer[context.Context] // lifetime of the node, set at `Start`
 	cfg             *config.P2P
 	db              *database.Database
 	h               host.Host
@@ -58,16 +63,41 @@ type Node struct {
 	ignoreSigners   map[common.Address]int
 	stakemanager    *stakemanager.Cache
 	versepool       verse.VersePool
-
-	topic *ps.Topic
-	sub   *ps.Subscription
-	log   log.Logger
+	detector        *equivocation.Detector
+	peerSigners     *peerSigners
+	sigRequests     sync.Map // in progress signature requests per contract
+	signer          Signer   // nil if the verifier key is not available
+	verifiedPeers   *verifiedPeers
+
+	// whether to subscribe to the signatures, i.e. the submitter is enabled
+	enableSubscriber bool
+
+	// misbehaving peers refused by the connection gater
+	blocklist  *Blocklist
+	peerScores *peerScores
+
+	pubsub    *ps.PubSub
+	topicsMu  sync.Mutex
+	topics    map[string]*ps.Topic
+	verseSubs map[uint64]*ps.Subscription // per chain id
+	topic     *ps.Topic                   // legacy topic
+	sub       *ps.Subscription            // legacy topic
+	log       log.Logger
 
 	outboundSem, inboundSem     *semaphore.Weighted
 	outboundThrot, inboundThrot *rate.Limiter
 
+	// inbound limits shared by the peers not verified as validators
+	unverifiedSem   *semaphore.Weighted
+	unverifiedThrot *rate.Limiter
+
+	// inbound throttling per peer
+	peerThrots *peerThrottles
+
 	meterPubsubSubscribed,
 	meterPubsubUnknownMsg,
+	meterPubsubRejected,
+	meterPubsubIgnored,
 	meterStreamOpend,
 	meterStreamHandled,
 	meterStreamClosed,
@@ -78,11 +108,14 @@ type Node struct {
 	meterHolePunchErrs,
 	meterStreamOpenErrs,
 	meterStreamReadErrs,
-	meterStreamWriteErrs meter.Counter
+	meterStreamWriteErrs,
+	meterPeersBlocked meter.Counter
 
 	meterPeers,
 	meterTCPConnections,
 	meterUDPConnections,
+	meterWebSocketConnections,
+	meterWebTransportConnections,
 	meterRelayConnections,
 	meterRelayHopStreams,
 	meterRelayStopStreams,
@@ -100,12 +133,10 @@ func NewNode(
 	ignoreSigners []common.Address,
 	stakemanager *stakemanager.Cache,
 	versepool verse.VersePool,
+	detector *equivocation.Detector,
+	signer Signer,
+	blocklist *Blocklist,
 ) (*Node, error) {
-	_, topic, sub, err := setupPubSub(context.Background(), host, pubsubTopic)
-	if err != nil {
-		return nil, err
-	}
-
 	worker := &Node{
 		cfg:             cfg,
 		db:              db,
@@ -117,8 +148,15 @@ func NewNode(
 		ignoreSigners:   map[common.Address]int{},
 		stakemanager:    stakemanager,
 		versepool:       versepool,
-		topic:           topic,
-		sub:             sub,
+		detector:        detector,
+		peerSigners:     newPeerSigners(),
+		signer:          signer,
+		verifiedPeers:   newVerifiedPeers(),
+		blocklist:       blocklist,
+		peerScores:      newPeerScores(cfg.Misbehaviour.ScoreDecay),
+		peerThrots:      newPeerThrottles(cfg.InboundLimits.PeerThrottling),
+		topics:          map[string]*ps.Topic{},
+		verseSubs:       map[uint64]*ps.Subscription{},
 		log:             log.New("worker", "p2p"),
 
 		outboundSem: semaphore.NewWeighted(int64(cfg.OutboundLimits.Concurrency)),
@@ -127,9 +165,14 @@ func NewNode(
 			rate.Limit(cfg.OutboundLimits.Throttling), cfg.OutboundLimits.Throttling),
 		inboundThrot: rate.NewLimiter(
 			rate.Limit(cfg.InboundLimits.Throttling), cfg.InboundLimits.Throttling),
+		unverifiedSem: semaphore.NewWeighted(int64(max(1, cfg.InboundLimits.Concurrency/2))),
+		unverifiedThrot: rate.NewLimiter(
+			rate.Limit(max(1, cfg.InboundLimits.Throttling/2)), max(1, cfg.InboundLimits.Throttling/2)),
 
 		meterPubsubSubscribed: meter.GetOrRegisterCounter([]string{"p2p", "pubsub", "subscribed"}, ""),
 		meterPubsubUnknownMsg: meter.GetOrRegisterCounter([]string{"p2p", "pubsub", "unknown", "messages"}, ""),
+		meterPubsubRejected:   meter.GetOrRegisterCounter([]string{"p2p", "pubsub", "rejected"}, ""),
+		meterPubsubIgnored:    meter.GetOrRegisterCounter([]string{"p2p", "pubsub", "ignored"}, ""),
 		meterStreamOpend:      meter.GetOrRegisterCounter([]string{"p2p", "stream", "opened"}, ""),
 		meterStreamHandled:    meter.GetOrRegisterCounter([]string{"p2p", "stream", "handled"}, ""),
 		meterStreamClosed:     meter.GetOrRegisterCounter([]string{"p2p", "stream", "closed"}, ""),
@@ -141,9 +184,14 @@ func NewNode(
 		meterStreamOpenErrs:   meter.GetOrRegisterCounter([]string{"p2p", "stream", "open", "errors"}, ""),
 		meterStreamReadErrs:   meter.GetOrRegisterCounter([]string{"p2p", "stream", "read", "errors"}, ""),
 		meterStreamWriteErrs:  meter.GetOrRegisterCounter([]string{"p2p", "stream", "write", "errors"}, ""),
+		meterPeersBlocked:     meter.GetOrRegisterCounter([]string{"p2p", "peers", "blocked"}, ""),
 		meterPeers:            meter.GetOrRegisterGauge([]string{"p2p", "peers"}, ""),
 		meterTCPConnections:   meter.GetOrRegisterGauge([]string{"p2p", "tcp", "connections"}, ""),
 		meterUDPConnections:   meter.GetOrRegisterGauge([]string{"p2p", "udp", "connections"}, ""),
+		meterWebSocketConnections: meter.GetOrRegisterGauge(
+			[]string{"p2p", "websocket", "connections"}, ""),
+		meterWebTransportConnections: meter.GetOrRegisterGauge(
+			[]string{"p2p", "webtransport", "connections"}, ""),
 		meterRelayConnections: meter.GetOrRegisterGauge([]string{"p2p", "relay", "connections"}, ""),
 		meterRelayHopStreams:  meter.GetOrRegisterGauge([]string{"p2p", "relayhop", "streams"}, ""),
 		meterRelayStopStreams: meter.GetOrRegisterGauge([]string{"p2p", "relaystop", "streams"}, ""),
@@ -154,29 +202,68 @@ func NewNode(
 		worker.ignoreSigners[addr] = 1
 	}
 
+	var err error
+	if worker.pubsub, err = setupPubSub(context.Background(), host, cfg); err != nil {
+		return nil, err
+	}
+
+	// For backward compatibility, join the topic shared by all verses.
+	if cfg.LegacyTopic {
+		if worker.topic, err = worker.joinTopic(pubsubTopic); err != nil {
+			return nil, err
+		}
+		if worker.sub, err = worker.topic.Subscribe(); err != nil {
+			return nil, fmt.Errorf("failed to subscribe the topic: %w", err)
+		}
+	}
+
 	return worker, nil
 }
 
 func (w *Node) Start(ctx context.Context, enableSubscriber bool) {
 	defer w.h.Close()
-	defer w.topic.Close()
-	defer w.sub.Cancel()
+	defer w.closeTopics()
+
+	w.ctx.Store(&ctx)
+	w.enableSubscriber = enableSubscriber
 
 	// For backward compatibility(older than v1.1.0), we support the stream protocol.
 	w.h.SetStreamHandler(streamProtocol, w.newStreamHandler(ctx))
+	w.h.SetStreamHandler(catchUpProtocol, w.newCatchUpHandler(ctx))
+	w.h.SetStreamHandler(handshakeProtocol, w.newHandshakeHandler())
+	w.h.SetStreamHandler(nodeInfoProtocol, w.newNodeInfoHandler())
 
 	var (
 		wg          sync.WaitGroup
 		meterTicker = time.NewTicker(time.Second * 60)
+		topicTicker = time.NewTicker(verseTopicSyncInterval)
 	)
 	defer meterTicker.Stop()
+	defer topicTicker.Stop()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.handshakeLoop(ctx)
+	}()
 
 	if enableSubscriber {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			w.subscribeLoop(ctx)
-		}()
+		if w.sub != nil {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				w.subscribeLoop(ctx, w.sub)
+			}()
+		}
+		w.syncVerseSubscriptions(ctx, &wg)
+
+		if w.cfg.CatchUp.Enable {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				w.catchUpLoop(ctx)
+			}()
+		}
 	}
 
 	w.showBootstrapLog()
@@ -187,15 +274,22 @@ func (w *Node) Start(ctx context.Context, enableSubscriber bool) {
 			w.log.Info("P2P node stopping...")
 			wg.Wait()
 			return
+		case <-topicTicker.C:
+			if enableSubscriber {
+				w.syncVerseSubscriptions(ctx, &wg)
+			}
 		case <-meterTicker.C:
 			nwstat := newNetworkStatus(w.h)
 			w.meterTCPConnections.Set(float64(nwstat.connections.tcp))
 			w.meterUDPConnections.Set(float64(nwstat.connections.udp))
+			w.meterWebSocketConnections.Set(float64(nwstat.connections.websocket))
+			w.meterWebTransportConnections.Set(float64(nwstat.connections.webtransport))
 			w.meterRelayConnections.Set(float64(nwstat.connections.relay))
 			w.meterRelayHopStreams.Set(float64(nwstat.streams.hop))
 			w.meterRelayStopStreams.Set(float64(nwstat.streams.stop))
 			w.meterVerifierStreams.Set(float64(nwstat.streams.verifier))
 			w.meterPeers.Set(float64(w.h.Peerstore().Peers().Len()))
+			w.peerScores.prune(time.Now())
 		}
 	}
 }
@@ -205,32 +299,35 @@ func (w *Node) Host() host.Host                  { return w.h }
 func (w *Node) Routing() routing.Routing         { return w.dht }
 func (w *Node) HolePunchHelper() HolePunchHelper { return w.hpHelper }
 
-func (w *Node) subscribeLoop(ctx context.Context) {
-	w.log.Info("Start subscribing to pubsub messages")
+func (w *Node) subscribeLoop(ctx context.Context, sub *ps.Subscription) {
+	w.log.Info("Start subscribing to pubsub messages", "topic", sub.Topic())
 
 	for {
 		var msg pb.PubSub
-		peer, err := subscribe(ctx, w.sub, w.h.ID(), &msg)
-		if errors.Is(err, context.Canceled) {
-			// worker stopped
+		recv, err := subscribe(ctx, sub, w.h.ID(), &msg)
+		if errors.Is(err, context.Canceled) || errors.Is(err, ps.ErrSubscriptionCancelled) {
+			// worker stopped or unsubscribed
 			return
 		} else if errors.Is(err, errSelfMessage) {
 			continue
 		} else if err != nil {
-			w.log.Error("Failed to subscribe", "peer", peer, "err", err)
+			w.log.Error("Failed to subscribe", "err", err)
 			continue
 		}
 		w.meterPubsubSubscribed.Incr()
 
-		t := msg.GetOptimismSignatureExchange()
-		if t == nil {
+		peer := recv.GetFrom()
+		if msg.GetOptimismSignatureExchange() == nil {
 			w.log.Warn("Unsupported pubsub message", "peer", peer, "err", err)
 			w.meterPubsubUnknownMsg.Incr()
 			continue
 		}
 
-		for _, remote := range t.Latests {
-			w.handleOptimismSignatureExchangeFromPubSub(ctx, peer, remote)
+		// the signatures decoded by the topic validator
+		valids, _ := recv.ValidatorData.([]*pb.OptimismSignature)
+		for _, remote := range valids {
+			w.peerSigners.add(peer, common.BytesToAddress(remote.Signer))
+			w.handleRemoteSignature(ctx, peer, remote)
 		}
 	}
 }
@@ -265,7 +362,7 @@ func (w *Node) newStreamHandler(ctx context.Context) network.StreamHandler {
 				return
 			default:
 				w.log.Warn("Received an unknown message", "peer", peer)
-				w.meterStreamUnknownMsg.Incr()
+				w.receivedUnknownMessage(peer)
 				return
 			}
 
@@ -276,7 +373,7 @@ func (w *Node) newStreamHandler(ctx context.Context) network.StreamHandler {
 	}
 }
 
-func (w *Node) handleOptimismSignatureExchangeFromPubSub(
+func (w *Node) handleRemoteSignature(
 	ctx context.Context,
 	sender peer.ID,
 	remote *pb.OptimismSignature,
@@ -290,10 +387,8 @@ func (w *Node) handleOptimismSignatureExchangeFromPubSub(
 	if verse, ok := w.versepool.Get(contract); !ok || !verse.CanSubmit() {
 		return false
 	}
-	// Receive signatures only from signers with stake >= validator candidate minimum.
-	if w.stakemanager.StakeBySigner(ctx, signer).Cmp(ethutil.TenMillionOAS) == -1 {
-		return false
-	}
+	// Note: The signature and the stake amount of the signer have already
+	// been verified by the topic validator or the catch-up requester.
 
 	logctx := []interface{}{
 		"peer", sender,
@@ -303,11 +398,6 @@ func (w *Node) handleOptimismSignatureExchangeFromPubSub(
 		"remote-latest-index", remote.RollupIndex,
 	}
 
-	if err := verifySignature(w.hubLayerChainID, remote); err != nil {
-		w.log.Error("Invalid signature", append(logctx, "err", err)...)
-		return false
-	}
-
 	if _, err := w.db.OPSignature.FindByID(remote.Id); err == nil {
 		// duplicated
 		w.log.Debug("Duplicated signature", append(logctx, "id", remote.Id)...)
@@ -317,6 +407,17 @@ func (w *Node) handleOptimismSignatureExchangeFromPubSub(
 		return false
 	}
 
+	w.checkEquivocation(ctx, remote)
+
+	// local is newer, e.g. re-signed after a reorg
+	if newer, err := w.isLocalNewer(signer, contract, remote); err != nil {
+		w.log.Error("Failed to find local signature", append(logctx, "err", err)...)
+		return false
+	} else if newer {
+		w.log.Debug("Local signature is newer", logctx...)
+		return false
+	}
+
 	w.log.Info("Received new signature", logctx...)
 
 	// save signature
@@ -336,6 +437,16 @@ func (w *Node) handleOptimismSignatureExchangeFromPubSub(
 	return true
 }
 
+// Returns true if the local signature of the same signer
+// for the same rollup is newer than the remote signature.
+func (w *Node) isLocalNewer(signer, contract common.Address, remote *pb.OptimismSignature) (bool, error) {
+	local, err := w.db.OPSignature.Find(nil, &signer, &contract, &remote.RollupIndex, 1, 0)
+	if err != nil {
+		return false, err
+	}
+	return len(local) > 0 && strings.Compare(local[0].ID, remote.Id) == 1, nil
+}
+
 func (w *Node) handleOptimismSignatureExchangeRequest(
 	ctx context.Context,
 	s network.Stream,
@@ -365,7 +476,8 @@ func (w *Node) handleOptimismSignatureExchangeRequest(
 
 	// By finely acquiring the semaphore, it prevents
 	// other peers from being blocked for a long time.
-	sem := util.NewReleaseGuardSemaphore(w.inboundSem)
+	inboundSem, inboundThrot := w.inboundLimits(ctx, peerID)
+	sem := util.NewReleaseGuardSemaphore(inboundSem)
 	defer sem.ReleaseALL()
 
 	for _, req := range requests {
@@ -400,7 +512,9 @@ func (w *Node) handleOptimismSignatureExchangeRequest(
 			if sigLen == 0 {
 				break // reached the last
 			}
-			w.throttling(w.inboundThrot, sigLen,
+			w.throttling(inboundThrot, sigLen,
+				"in", "handleOptimismSignatureExchangeRequest", "peer", peerID)
+			w.throttling(w.peerThrots.get(peerID), sigLen,
 				"in", "handleOptimismSignatureExchangeRequest", "peer", peerID)
 
 			responses := make([]*pb.OptimismSignature, sigLen)
@@ -447,7 +561,7 @@ func (w *Node) handleOptimismSignatureExchangeResponses(ctx context.Context, s n
 		if body == nil {
 			if m.GetEom() != nil {
 				w.log.Warn("Received an unknown message", logctx...)
-				w.meterStreamUnknownMsg.Incr()
+				w.receivedUnknownMessage(peerID)
 			}
 			return
 		}
@@ -466,13 +580,18 @@ func (w *Node) handleOptimismSignatureExchangeResponses(ctx context.Context, s n
 				"contract", contract,
 				"index", res.RollupIndex)
 
-			if err := verifySignature(w.hubLayerChainID, res); err != nil {
+			switch result, err := verifySignature(w.hubLayerChainID, res); result {
+			case ps.ValidationReject:
 				w.log.Error("Invalid signature", append(logctx, "err", err)...)
+				w.penalize(peerID, misbehaviourInvalidSignature)
 				return
+			case ps.ValidationIgnore:
+				w.log.Debug("Skipped signature", append(logctx, "err", err)...)
+				continue
 			}
 			if _, ok := w.ignoreSigners[signer]; ok {
 				w.log.Info("Ignored", logctx...)
-				return
+				continue
 			}
 
 			// deduplication
@@ -480,11 +599,13 @@ func (w *Node) handleOptimismSignatureExchangeResponses(ctx context.Context, s n
 				continue
 			}
 
+			w.checkEquivocation(ctx, res)
+
 			// local is newer
-			if local, err := w.db.OPSignature.Find(nil, &signer, &contract, &res.RollupIndex, 1, 0); err != nil {
+			if newer, err := w.isLocalNewer(signer, contract, res); err != nil {
 				w.log.Error("Failed to find local signature", append(logctx, "err", err)...)
 				return
-			} else if len(local) > 0 && strings.Compare(local[0].ID, res.Id) == 1 {
+			} else if newer {
 				continue
 			}
 
@@ -661,21 +782,48 @@ func (w *Node) publishLatestSignatures(ctx context.Context) {
 	}
 }
 
+// Publish the signatures to the topic of each verse,
+// and to the legacy topic if enabled.
 func (w *Node) PublishSignatures(ctx context.Context, rows []*database.OptimismSignature) error {
-	sigs := &pb.OptimismSignatureExchange{
-		Latests: make([]*pb.OptimismSignature, len(rows)),
-	}
+	var (
+		all      = make([]*pb.OptimismSignature, len(rows))
+		perChain = map[uint64][]*pb.OptimismSignature{}
+	)
 	for i, row := range rows {
-		sigs.Latests[i] = toProtoBufSig(row)
+		all[i] = toProtoBufSig(row)
+		if item, ok := w.versepool.Get(row.Contract.Address); ok {
+			chainID := item.Verse().ChainID()
+			perChain[chainID] = append(perChain[chainID], all[i])
+		}
 	}
 
-	m := &pb.PubSub{Body: &pb.PubSub_OptimismSignatureExchange{
-		OptimismSignatureExchange: sigs,
+	var errs []error
+	for chainID, sigs := range perChain {
+		topic, err := w.joinTopic(verseTopic(chainID))
+		if err == nil {
+			err = publish(ctx, topic, newSignatureExchange(sigs))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("chain-id: %d: %w", chainID, err))
+		}
+	}
+
+	// For the nodes that have not subscribed to the per-verse topics.
+	if w.topic != nil {
+		if err := publish(ctx, w.topic, newSignatureExchange(all)); err != nil {
+			errs = append(errs, fmt.Errorf("legacy topic: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func newSignatureExchange(sigs []*pb.OptimismSignature) *pb.PubSub {
+	return &pb.PubSub{Body: &pb.PubSub_OptimismSignatureExchange{
+		OptimismSignatureExchange: &pb.OptimismSignatureExchange{Latests: sigs},
 	}}
-	return publish(ctx, w.topic, m)
 }
 
-func (w *Node) openStream(ctx context.Context, peer peer.ID) (network.Stream, error) {
+func (w *Node) openStream(ctx context.Context, peer peer.ID, pid protocol.ID) (network.Stream, error) {
 	// If holepunch is available, attempt a direct connection.
 	if !HasDirectConnection(w.h, peer) && w.hpHelper.Available(w.h) {
 		if err := <-w.hpHelper.HolePunch(ctx, w.h, peer, DefaultHolePunchTimeout); err != nil {
@@ -687,10 +835,15 @@ func (w *Node) openStream(ctx context.Context, peer peer.ID) (network.Stream, er
 		}
 	}
 
-	// Note: `WithUseTransient` is required to open a stream via circuit relay.
-	s, err := w.h.NewStream(network.WithAllowLimitedConn(ctx, streamProtocol), peer, streamProtocol)
+	// Note: `WithAllowLimitedConn` is required to open a stream via circuit relay.
+	// The relays are only used for the handshake, the verified validators
+	// and the legacy peers not supporting the handshake.
+	if w.allowRelay(ctx, peer, pid) {
+		ctx = network.WithAllowLimitedConn(ctx, string(pid))
+	}
+	s, err := w.h.NewStream(ctx, peer, pid)
 	if err != nil {
-		w.log.Error("Failed to open stream", "peer", peer, "err", err)
+		w.log.Error("Failed to open stream", "peer", peer, "protocol", pid, "err", err)
 		w.meterStreamOpenErrs.Incr()
 		return nil, err
 	}
@@ -713,6 +866,9 @@ func (w *Node) writeStream(s network.Stream, m *pb.Stream) error {
 	} else if isRWErr && !isEOM {
 		w.meterStreamWriteErrs.Incr()
 	}
+	if isTimeout(err) {
+		w.penalize(s.Conn().RemotePeer(), misbehaviourTimeout)
+	}
 	return err
 }
 
@@ -729,6 +885,9 @@ func (w *Node) readStream(s network.Stream) (m *pb.Stream, err error) {
 	} else if _, ok := err.(*ReadWriteError); ok {
 		w.meterStreamReadErrs.Incr()
 	}
+	if isTimeout(err) {
+		w.penalize(s.Conn().RemotePeer(), misbehaviourTimeout)
+	}
 	return nil, err
 }
 
@@ -752,7 +911,16 @@ func (w *Node) showBootstrapLog() {
 	if w.cfg.Transports.QUIC {
 		w.log.Info("Enabled QUIC transport")
 	}
+	if w.cfg.Transports.WebSocket.Enable {
+		w.log.Info("Enabled WebSocket transport", "wss", w.cfg.Transports.WebSocket.CertFile != "")
+	}
+	if w.cfg.Transports.WebTransport {
+		w.log.Info("Enabled WebTransport transport")
+	}
 	w.log.Info("Bootnodes: " + strings.Join(w.cfg.Bootnodes, ","))
+	if w.cfg.PrivateNetworkKey != "" {
+		w.log.Info("Enabled private network")
+	}
 	w.log.Info("Enabled NAT Travasal features",
 		"upnp", w.cfg.NAT.UPnP, "autonat", w.cfg.NAT.AutoNAT, "holepunch", w.hpHelper.Enabled())
 	if w.cfg.RelayService.Enable {
@@ -761,6 +929,28 @@ func (w *Node) showBootstrapLog() {
 	if w.cfg.RelayClient.Enable {
 		w.log.Info("Enabled circuit relay client, relay nodes: " + strings.Join(w.cfg.RelayClient.RelayNodes, ","))
 	}
+	if w.cfg.MDNS.Enable {
+		w.log.Info("Enabled mDNS discovery, service name: " + w.cfg.MDNS.ServiceName)
+	}
+	if len(w.cfg.StaticPeers) > 0 {
+		w.log.Info("Static peers: " + strings.Join(w.cfg.StaticPeers, ","))
+	}
+	if w.cfg.MinPeerVersion != "" {
+		w.log.Info("Minimum peer version: " + w.cfg.MinPeerVersion)
+	}
+	if w.cfg.Misbehaviour.Threshold > 0 {
+		w.log.Info("Enabled misbehaviour blocklist",
+			"threshold", w.cfg.Misbehaviour.Threshold,
+			"block-duration", w.cfg.Misbehaviour.BlockDuration,
+			"score-decay", w.cfg.Misbehaviour.ScoreDecay)
+	}
+	if w.cfg.LegacyTopic {
+		w.log.Info("Enabled legacy pubsub topic: " + pubsubTopic)
+	}
+	if w.cfg.CatchUp.Enable {
+		w.log.Info("Enabled signature catch-up",
+			"interval", w.cfg.CatchUp.Interval, "peers", w.cfg.CatchUp.Peers)
+	}
 	if w.cfg.ExperimentalLanDHT.Loopback {
 		w.log.Warn("[Experimental/LanDHT] Enabled loopback")
 	}
@@ -776,6 +966,7 @@ func (w *Node) showBootstrapLog() {
 		"inbound-limits-concurrency", w.cfg.InboundLimits.Concurrency,
 		"inbound-limits-maxsendtime", w.cfg.InboundLimits.MaxSendTime,
 		"inbound-limits-throttling", w.cfg.InboundLimits.Throttling,
+		"inbound-limits-peer-throttling", w.cfg.InboundLimits.PeerThrottling,
 	)
 }
 
@@ -872,52 +1063,41 @@ func subscribe(
 	sub *ps.Subscription,
 	self peer.ID,
 	msg proto.Message,
-) (peer.ID, error) {
+) (*ps.Message, error) {
 	recv, err := sub.Next(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to subscribe pubsub message: %w", err)
+		return nil, fmt.Errorf("failed to subscribe pubsub message: %w", err)
 	}
 
 	if recv.ReceivedFrom == self || recv.GetFrom() == self {
-		return "", errSelfMessage
+		return recv, errSelfMessage
 	}
 
 	data, err := decompress(recv.Data)
 	if err != nil {
-		return "", fmt.Errorf("failed to decompress pubsub message: %w", err)
+		return recv, fmt.Errorf("failed to decompress pubsub message: %w", err)
 	}
 
 	if err = proto.Unmarshal(data, msg); err != nil {
-		return "", fmt.Errorf("failed to unmarshal pubsub message: %w", err)
+		return recv, fmt.Errorf("failed to unmarshal pubsub message: %w", err)
 	}
 
-	return recv.GetFrom(), nil
+	return recv, nil
 }
 
-func verifySignature(hubLayerChainID *big.Int, sig *pb.OptimismSignature) error {
-	// verify ulid
-	if id, err := ulid.ParseStrict(sig.Id); err != nil {
-		return err
-	} else if id.Time() > uint64(time.Now().UnixMilli()) {
-		return fmt.Errorf("future ulid: %s, timestamp: %d", sig.Id, id.Time())
-	}
-
-	signer := common.BytesToAddress(sig.Signer)
-	contract := common.BytesToAddress(sig.Contract)
-	rollupIndex := new(big.Int).SetUint64(sig.RollupIndex)
-	rollupHash := common.BytesToHash(sig.RollupHash)
-
-	msg := ethutil.NewMessage(hubLayerChainID, contract, rollupIndex, rollupHash, sig.Approved)
-	err := msg.VerifySigner(sig.Signature, signer)
-
-	// possibly an old signature with an approved bug
-	if _, ok := err.(*ethutil.SignerMismatchError); ok {
-		msg = ethutil.NewMessageWithApprovedBug(
-			hubLayerChainID, contract, rollupIndex, rollupHash, sig.Approved)
-		err = msg.VerifySigner(sig.Signature, signer)
+// Record the evidence if the signer has signed a different message for the same rollup.
+func (w *Node) checkEquivocation(ctx context.Context, sig *pb.OptimismSignature) {
+	if w.detector == nil {
+		return
 	}
-
-	return err
+	w.detector.CheckSignature(ctx,
+		sig.Id,
+		common.BytesToAddress(sig.Signer),
+		common.BytesToAddress(sig.Contract),
+		sig.RollupIndex,
+		common.BytesToHash(sig.RollupHash),
+		sig.Approved,
+		database.BytesSignature(sig.Signature))
 }
 
 func toProtoBufSig(row *database.OptimismSignature) *pb.OptimismSignature {
@@ -2,26 +2,51 @@ package p2p
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
 	"math/big"
 	"net"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p"
+	kaddht "github.com/libp2p/go-libp2p-kad-dht"
+	ps "github.com/libp2p/go-libp2p-pubsub"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/oasysgames/oasys-optimism-verifier/config"
 	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
 	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/equivocation"
 	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
 	pb "github.com/oasysgames/oasys-optimism-verifier/proto/p2p/v1/gen"
 	"github.com/oasysgames/oasys-optimism-verifier/testhelper"
 	"github.com/oasysgames/oasys-optimism-verifier/testhelper/backend"
 	"github.com/oasysgames/oasys-optimism-verifier/util"
 	"github.com/oasysgames/oasys-optimism-verifier/verse"
+	"github.com/oasysgames/oasys-optimism-verifier/version"
+	"github.com/oklog/ulid/v2"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestNode(t *testing.T) {
@@ -81,10 +106,12 @@ func (s *NodeTestSuite) SetupTest() {
 	s.versepool.Add(verse.NewOPLegacy(s.db, s.b0, 0, "http://rpc.example.com", s.contract0, s.RandAddress()), true)
 
 	// setup libp2p
-	s.bootnode = s.newWorker([]string{})
+	s.bootnode = s.newWorker([]string{}, nil)
 	bootnodes := []string{s.bootnode.cfg.Listens[0] + "/p2p/" + s.bootnode.h.ID().String()}
-	s.node1 = s.newWorker(bootnodes)
-	s.node2 = s.newWorker(bootnodes)
+	s.node1 = s.newWorker(bootnodes, &testSigner{s.b0})
+	s.node2 = s.newWorker(bootnodes, &testSigner{s.b1})
+	s.Require().NoError(s.node2.h.Connect(context.Background(),
+		peer.AddrInfo{ID: s.node1.h.ID(), Addrs: s.node1.h.Addrs()}))
 
 	// create sample records
 	for _, node := range []*Node{s.node1, s.node2} {
@@ -141,23 +168,104 @@ func (s *NodeTestSuite) SetupTest() {
 	}
 }
 
-func (s *NodeTestSuite) TestHandleOptimismSignatureExchangeFromPubSub() {
+func (s *NodeTestSuite) TestHandleRemoteSignature() {
 	// succeed to save signature
 	msg := toProtoBufSig(s.sigs[s.signer0][s.contract0][49])
-	saved := s.node2.handleOptimismSignatureExchangeFromPubSub(context.Background(), s.node1.h.ID(), msg)
+	saved := s.node2.handleRemoteSignature(context.Background(), s.node1.h.ID(), msg)
 	s.True(saved)
 	got, err := s.node2.db.OPSignature.FindByID(msg.Id)
 	s.NoError(err)
 	s.Equal(msg.Id, got.ID)
 
 	// saving duplicate signature
-	saved = s.node2.handleOptimismSignatureExchangeFromPubSub(context.Background(), s.node1.h.ID(), msg)
+	saved = s.node2.handleRemoteSignature(context.Background(), s.node1.h.ID(), msg)
 	s.False(saved)
 
 	// saving too old signature (no pruneRollupIndexDepth)
 	msg = toProtoBufSig(s.sigs[s.signer0][s.contract0][0])
-	saved = s.node2.handleOptimismSignatureExchangeFromPubSub(context.Background(), s.node1.h.ID(), msg)
+	saved = s.node2.handleRemoteSignature(context.Background(), s.node1.h.ID(), msg)
 	s.True(saved)
+
+	// local is newer (e.g. re-signed after a reorg)
+	older := toProtoBufSig(s.sigs[s.signer0][s.contract0][48])
+	local, _ := s.node2.db.OPSignature.Save(nil, nil, s.signer0, s.contract0,
+		older.RollupIndex, s.RandHash(), true, database.RandSignature())
+	saved = s.node2.handleRemoteSignature(context.Background(), s.node1.h.ID(), older)
+	s.False(saved)
+	got, err = s.node2.db.OPSignature.FindByID(local.ID)
+	s.NoError(err)
+	s.Equal(local.RollupHash, got.RollupHash)
+	_, err = s.node2.db.OPSignature.FindByID(older.Id)
+	s.ErrorIs(err, database.ErrNotFound)
+}
+
+func (s *NodeTestSuite) TestValidatePubSub() {
+	ctx := context.Background()
+	newTopicMsg := func(topic string, sigs ...*pb.OptimismSignature) *ps.Message {
+		data, _ := proto.Marshal(&pb.PubSub{Body: &pb.PubSub_OptimismSignatureExchange{
+			OptimismSignatureExchange: &pb.OptimismSignatureExchange{Latests: sigs},
+		}})
+		data, _ = compress(data)
+		return &ps.Message{Message: &pubsub_pb.Message{Data: data, Topic: &topic}}
+	}
+	newMsg := func(sigs ...*pb.OptimismSignature) *ps.Message {
+		return newTopicMsg(pubsubTopic, sigs...)
+	}
+	validIDs := func(m *ps.Message) (ids []string) {
+		for _, sig := range m.ValidatorData.([]*pb.OptimismSignature) {
+			ids = append(ids, sig.Id)
+		}
+		return ids
+	}
+	sender := s.node1.h.ID()
+
+	// valid signatures
+	valid0 := toProtoBufSig(s.sigs[s.signer0][s.contract0][49])
+	valid1 := toProtoBufSig(s.sigs[s.signer1][s.contract1][199])
+	m := newMsg(valid0, valid1)
+	s.Equal(ps.ValidationAccept, s.node2.validatePubSub(ctx, sender, m))
+	s.Equal([]string{valid0.Id, valid1.Id}, validIDs(m))
+
+	// own message
+	s.Equal(ps.ValidationAccept, s.node2.validatePubSub(ctx, s.node2.h.ID(), newMsg()))
+
+	// forged signature
+	forged := toProtoBufSig(s.sigs[s.signer0][s.contract0][49])
+	forged.Approved = !forged.Approved
+	s.Equal(ps.ValidationReject, s.node2.validatePubSub(ctx, sender, newMsg(valid0, forged)))
+
+	// malformed message
+	s.Equal(ps.ValidationReject, s.node2.validatePubSub(ctx, sender,
+		&ps.Message{Message: &pubsub_pb.Message{Data: []byte("invalid")}}))
+
+	// signer without stake
+	msg := ethutil.NewMessage(s.b2.ChainID(), s.contract0, big.NewInt(0),
+		util.BytesToBytes32(valid0.RollupHash), true)
+	sigbin, _ := msg.Signature(s.b2.SignData)
+	noStake := toProtoBufSig(s.sigs[s.signer0][s.contract0][0])
+	noStake.Signer, noStake.Signature = s.signer2[:], sigbin[:]
+	noStake.RollupHash = valid0.RollupHash
+	s.Equal(ps.ValidationIgnore, s.node2.validatePubSub(ctx, sender, newMsg(noStake)))
+
+	// the whole message is ignored as it would be forwarded as is
+	s.Equal(ps.ValidationIgnore, s.node2.validatePubSub(ctx, sender, newMsg(noStake, valid0)))
+
+	// signature with the future ulid
+	future := toProtoBufSig(s.sigs[s.signer0][s.contract0][0])
+	future.Id = ulid.MustNew(ulid.Timestamp(time.Now().Add(time.Hour)), nil).String()
+	s.Equal(ps.ValidationIgnore, s.node2.validatePubSub(ctx, sender, newMsg(valid1, future)))
+
+	// forged signature after the ignored one
+	s.Equal(ps.ValidationReject, s.node2.validatePubSub(ctx, sender, newMsg(future, forged)))
+
+	// the contract of the signature belongs to the verse topic
+	// (the verse of `contract1` is unknown and cannot be checked)
+	m = newTopicMsg(verseTopic(0), valid0, valid1)
+	s.Equal(ps.ValidationAccept, s.node2.validatePubSub(ctx, sender, m))
+	s.Equal([]string{valid0.Id, valid1.Id}, validIDs(m))
+
+	// the contract of the signature belongs to another chain
+	s.Equal(ps.ValidationReject, s.node2.validatePubSub(ctx, sender, newTopicMsg(verseTopic(1), valid0)))
 }
 
 func (s *NodeTestSuite) TestHandleOptimismSignatureExchangeRequests() {
@@ -342,6 +450,506 @@ func (s *NodeTestSuite) TestHandleOptimismSignatureExchangeResponses() {
 	}
 }
 
+func (s *NodeTestSuite) TestVerseTopics() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// node2 subscribes to the topic of the submitting verse
+	var wg sync.WaitGroup
+	s.node2.syncVerseSubscriptions(ctx, &wg)
+	s.NotNil(s.node2.verseSubscription(0))
+
+	// wait for the mesh to be formed
+	topic, err := s.node1.joinTopic(verseTopic(0))
+	s.NoError(err)
+	s.Eventually(func() bool {
+		for _, peer := range topic.ListPeers() {
+			if peer == s.node2.h.ID() {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// publish from node1, received via the verse topic
+	want := s.sigs[s.signer0][s.contract0][49]
+	s.NoError(s.node1.PublishSignatures(ctx, []*database.OptimismSignature{want}))
+	s.Eventually(func() bool {
+		got, err := s.node2.db.OPSignature.FindByID(want.ID)
+		return err == nil && got.ID == want.ID
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// unsubscribe if the verse is no longer submitted
+	s.versepool.Delete(s.contract0)
+	s.node2.syncVerseSubscriptions(ctx, &wg)
+	s.Nil(s.node2.verseSubscription(0))
+	wg.Wait()
+}
+
+func (s *NodeTestSuite) TestSignatureCatchUp() {
+	ctx := context.Background()
+
+	// node1 is chosen as it supports the catch-up protocol
+	s.Eventually(func() bool {
+		for _, peer := range s.node2.catchUpPeers(ctx, 3) {
+			if peer == s.node1.h.ID() {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// request from node2 to node1
+	s.node2.requestSignatureCatchUp(ctx, s.node1.h.ID(), []*pb.SignatureCatchUp_Request{
+		{Contract: s.contract0[:], FromIndex: 40},
+		{Contract: s.contract1[:], FromIndex: 190},
+	})
+
+	// assert only the signatures of the submitting verse are saved
+	for _, signer := range []common.Address{s.signer0, s.signer1} {
+		wants := s.sigs[signer][s.contract0][40:]
+		gots, _ := s.node2.db.OPSignature.Find(nil, &signer, &s.contract0, nil, 1000, 0)
+		s.Len(gots, len(wants))
+		for i, want := range wants {
+			s.Equal(want.ID, gots[i].ID)
+			s.Equal(want.RollupIndex, gots[i].RollupIndex)
+			s.Equal(want.Signature, gots[i].Signature)
+		}
+
+		gots, _ = s.node2.db.OPSignature.Find(nil, &signer, &s.contract1, nil, 1000, 0)
+		s.Len(gots, 0)
+	}
+
+	// limited by the request
+	st, _ := s.node1.h.NewStream(ctx, s.node2.h.ID(), catchUpProtocol)
+	writeStream(st, &pb.Stream{Body: &pb.Stream_SignatureCatchUp{
+		SignatureCatchUp: &pb.SignatureCatchUp{Requests: []*pb.SignatureCatchUp_Request{
+			{Contract: s.contract0[:], FromIndex: 0, Limit: 5},
+		}},
+	}})
+	reads := s.readsStream(st)
+	s.Len(reads, 2)
+	s.Len(reads[0].GetSignatureCatchUp().Responses, 5)
+	s.Equal(uint64(40), reads[0].GetSignatureCatchUp().Responses[0].RollupIndex)
+	s.NotNil(reads[1].GetEom())
+}
+
+func (s *NodeTestSuite) TestSignatureRequest() {
+	ctx := context.Background()
+	s.Eventually(func() bool {
+		return len(s.node2.signatureRequestPeers(ctx, nil, 3)) > 0
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// peers publishing the missing signers come first
+	s.node2.peerSigners.add(s.bootnode.h.ID(), s.signer0)
+	s.node2.peerSigners.add(s.node1.h.ID(), s.signer1)
+	s.Eventually(func() bool {
+		peers := s.node2.signatureRequestPeers(ctx,
+			map[common.Address]struct{}{s.signer0: {}}, 1)
+		return len(peers) == 1 && peers[0] == s.node1.h.ID()
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// request from node2 to node1, excluding signer0
+	want := s.sigs[s.signer1][s.contract0][45]
+	s.node2.requestSignaturesFrom(ctx, s.node1.h.ID(), &pb.SignatureRequest{
+		Contract:    s.contract0[:],
+		RollupIndex: 45,
+		Excludes:    [][]byte{s.signer0[:]},
+	})
+
+	gots, _ := s.node2.db.OPSignature.Find(nil, nil, &s.contract0, &want.RollupIndex, 10, 0)
+	s.Len(gots, 1)
+	s.Equal(want.ID, gots[0].ID)
+	s.Equal(want.Signature, gots[0].Signature)
+}
+
+func (s *NodeTestSuite) TestRequestSignatures() {
+	s.node2.cfg.CatchUp.Peers = 3
+	s.Eventually(func() bool {
+		return len(s.node2.signatureRequestPeers(context.Background(), nil, 3)) > 0
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// the node is not started
+	s.node2.RequestSignatures(s.contract0, 45, []common.Address{s.signer0})
+	_, inProgress := s.node2.sigRequests.Load(s.contract0)
+	s.False(inProgress)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.node2.ctx.Store(&ctx)
+
+	want := s.sigs[s.signer1][s.contract0][45]
+	s.node2.RequestSignatures(s.contract0, 45, []common.Address{s.signer0})
+
+	s.Eventually(func() bool {
+		_, inProgress := s.node2.sigRequests.Load(s.contract0)
+		return !inProgress
+	}, 5*time.Second, 100*time.Millisecond)
+
+	gots, _ := s.node2.db.OPSignature.Find(nil, nil, &s.contract0, &want.RollupIndex, 10, 0)
+	s.Len(gots, 1)
+	s.Equal(want.ID, gots[0].ID)
+}
+
+func (s *NodeTestSuite) TestPeerSigners() {
+	book := newPeerSigners()
+	book.add(s.node1.h.ID(), s.signer0)
+	book.add(s.node1.h.ID(), s.signer1)
+	book.add(s.node1.h.ID(), s.signer0)
+	book.add(s.node2.h.ID(), s.signer1)
+	s.ElementsMatch([]common.Address{s.signer0, s.signer1}, book.get(s.node1.h.ID()))
+	s.ElementsMatch([]common.Address{s.signer1}, book.get(s.node2.h.ID()))
+
+	book.retain([]peer.ID{s.node2.h.ID()})
+	s.Len(book.get(s.node1.h.ID()), 0)
+	s.Len(book.get(s.node2.h.ID()), 1)
+}
+
+func (s *NodeTestSuite) TestHandshake() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// node1 proves that it is run by signer0
+	s.Eventually(func() bool {
+		return s.node2.handshake(ctx, s.node1.h.ID()) == nil
+	}, 5*time.Second, 100*time.Millisecond)
+	signer, ok := s.node2.verifiedSigner(ctx, s.node1.h.ID())
+	s.True(ok)
+	s.Equal(s.signer0, signer)
+	s.True(s.node2.h.ConnManager().IsProtected(s.node1.h.ID(), validatorConnTag))
+
+	// bootnode does not have the verifier key
+	s.Eventually(func() bool {
+		return s.node2.handshake(ctx, s.bootnode.h.ID()) == nil
+	}, 5*time.Second, 100*time.Millisecond)
+	_, ok = s.node2.verifiedSigner(ctx, s.bootnode.h.ID())
+	s.False(ok)
+
+	s.Equal([]*VerifiedPeer{{PeerID: s.node1.h.ID(), Signer: s.signer0}}, s.node2.VerifiedPeers())
+
+	// verified peers take the inbound limits for validators
+	sem, throt := s.node2.inboundLimits(ctx, s.node1.h.ID())
+	s.Same(s.node2.inboundSem, sem)
+	s.Same(s.node2.inboundThrot, throt)
+	sem, throt = s.node2.inboundLimits(ctx, s.bootnode.h.ID())
+	s.Same(s.node2.unverifiedSem, sem)
+	s.Same(s.node2.unverifiedThrot, throt)
+
+	// forget the disconnected peer
+	s.node2.unverifyPeer(s.node1.h.ID())
+	s.Len(s.node2.VerifiedPeers(), 0)
+	s.False(s.node2.h.ConnManager().IsProtected(s.node1.h.ID(), validatorConnTag))
+}
+
+func (s *NodeTestSuite) TestNodeInfo() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s.node2.enableSubscriber = true
+	s.Eventually(func() bool {
+		return s.node2.exchangeNodeInfo(ctx, s.node1.h.ID()) == nil
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// node2 received the node info of node1
+	got, ok := PeerNodeInfo(s.node2.h, s.node1.h.ID())
+	s.True(ok)
+	s.Equal(&NodeInfo{
+		Version:        version.SemVer(),
+		Roles:          []string{RoleVerifier},
+		Signer:         &s.signer0,
+		VerifyChainIDs: []uint64{0},
+		SubmitChainIDs: []uint64{},
+	}, got)
+
+	// node1 received the node info of node2
+	got, ok = PeerNodeInfo(s.node1.h, s.node2.h.ID())
+	s.True(ok)
+	s.Equal(&NodeInfo{
+		Version:        version.SemVer(),
+		Roles:          []string{RoleVerifier, RoleSubmitter},
+		Signer:         &s.signer1,
+		VerifyChainIDs: []uint64{0},
+		SubmitChainIDs: []uint64{0},
+	}, got)
+
+	// exposed in the status
+	status, err := NewHostStatus(s.node2.h)
+	s.NoError(err)
+	var found bool
+	for _, p := range status.Peers {
+		if p.ID == s.node1.h.ID().String() {
+			found = true
+			s.Equal(version.SemVer(), p.NodeInfo.Version)
+		}
+	}
+	s.True(found)
+
+	// invalid version
+	_, err = nodeInfoFromProto(&pb.NodeInfo{Version: "latest"})
+	s.ErrorContains(err, "invalid version")
+
+	// refuse the peer older than the minimum version
+	s.node2.cfg.MinPeerVersion = "99.0.0"
+	err = s.node2.acceptNodeInfo(s.node1.h.ID(), s.node1.NodeInfo().toProto())
+	s.ErrorContains(err, "is older than 99.0.0")
+	s.NotEqual(network.Connected, s.node2.h.Network().Connectedness(s.node1.h.ID()))
+	s.True(s.node2.blocklist.IsBlocked(s.node1.h.ID()))
+}
+
+func (s *NodeTestSuite) TestRefuseLegacyPeer() {
+	ctx := context.Background()
+	evt := event.EvtPeerIdentificationCompleted{
+		Peer:      s.node1.h.ID(),
+		Protocols: []protocol.ID{streamProtocol, catchUpProtocol},
+	}
+
+	// the minimum version is not set
+	s.node2.peerIdentified(ctx, evt)
+	s.False(s.node2.blocklist.IsBlocked(s.node1.h.ID()))
+
+	// not a verifier
+	s.node2.cfg.MinPeerVersion = "1.0.0"
+	s.node2.peerIdentified(ctx, event.EvtPeerIdentificationCompleted{Peer: s.node1.h.ID()})
+	s.False(s.node2.blocklist.IsBlocked(s.node1.h.ID()))
+
+	// the verifier not supporting the node info exchange
+	s.node2.peerIdentified(ctx, evt)
+	s.True(s.node2.blocklist.IsBlocked(s.node1.h.ID()))
+	s.NotEqual(network.Connected, s.node2.h.Network().Connectedness(s.node1.h.ID()))
+}
+
+func (s *NodeTestSuite) TestMisbehaviour() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// the sender of an unknown message is penalized
+	s.Eventually(func() bool {
+		st, err := s.node1.openStream(ctx, s.node2.h.ID(), streamProtocol)
+		if err != nil {
+			return false
+		}
+		defer st.Close()
+		return s.node1.writeStream(st, &pb.Stream{Body: &pb.Stream_Misc{Misc: []byte("hello")}}) == nil
+	}, 5*time.Second, 100*time.Millisecond)
+	s.Eventually(func() bool {
+		return s.node2.peerScores.get(s.node1.h.ID(), time.Now()) > 0
+	}, 3*time.Second, 50*time.Millisecond)
+	s.InDelta(misbehaviourWeights[misbehaviourUnknownMessage],
+		s.node2.peerScores.get(s.node1.h.ID(), time.Now()), 1)
+
+	// blocklist the peer exceeding the threshold
+	s.node2.penalize(s.node1.h.ID(), misbehaviourInvalidSignature)
+	s.Empty(s.node2.BlockedPeers())
+	s.node2.penalize(s.node1.h.ID(), misbehaviourInvalidSignature)
+
+	blocked := s.node2.BlockedPeers()
+	s.Len(blocked, 1)
+	s.Equal(s.node1.h.ID(), blocked[0].PeerID)
+	s.WithinDuration(time.Now().Add(time.Minute), blocked[0].Until, time.Second)
+	s.Equal(0.0, s.node2.peerScores.get(s.node1.h.ID(), time.Now()))
+
+	// the connection gater refuses the blocklisted peer
+	s.NotEqual(network.Connected, s.node2.h.Network().Connectedness(s.node1.h.ID()))
+	s.Error(s.node2.h.Connect(ctx, peer.AddrInfo{ID: s.node1.h.ID(), Addrs: s.node1.h.Addrs()}))
+
+	// disabled
+	s.node2.cfg.Misbehaviour.Threshold = 0
+	s.node2.penalize(s.node2.h.ID(), misbehaviourInvalidSignature)
+	s.Equal(0.0, s.node2.peerScores.get(s.node2.h.ID(), time.Now()))
+
+	s.True(isTimeout(&ReadWriteError{os.ErrDeadlineExceeded}))
+	s.False(isTimeout(&ReadWriteError{io.EOF}))
+}
+
+func (s *NodeTestSuite) TestPeerScores() {
+	now := time.Now()
+	id := s.node1.h.ID()
+	scores := newPeerScores(time.Minute)
+
+	s.Equal(40.0, scores.add(id, 40, now))
+	s.InDelta(20.0, scores.get(id, now.Add(time.Minute)), 0.001)
+	s.InDelta(30.0, scores.add(id, 10, now.Add(time.Minute)), 0.001)
+
+	// forget the decayed scores
+	scores.prune(now.Add(5 * time.Minute))
+	s.Len(scores.scores, 1)
+	scores.prune(now.Add(10 * time.Minute))
+	s.Len(scores.scores, 0)
+
+	// unlimited if zero
+	s.Equal(rate.Inf, newPeerThrottles(0).get(id).Limit())
+	s.Equal(rate.Limit(10), newPeerThrottles(10).get(id).Limit())
+}
+
+func (s *NodeTestSuite) TestVerifyHandshake() {
+	ctx := context.Background()
+	nonce := s.RandHash().Bytes()
+	sign := func(b *backend.SignableBackend, id peer.ID) *pb.Handshake {
+		sig, err := newHandshakeMessage(s.b0.ChainID(), id, nonce).Signature(b.SignData)
+		s.NoError(err)
+		signer := b.Signer()
+		return &pb.Handshake{Signer: signer[:], Signature: sig[:]}
+	}
+
+	// valid
+	got, err := s.node2.verifyHandshake(ctx, s.node1.h.ID(), nonce, sign(s.b0, s.node1.h.ID()))
+	s.NoError(err)
+	s.Equal(s.signer0, got)
+
+	// signed for another peer
+	_, err = s.node2.verifyHandshake(ctx, s.node1.h.ID(), nonce, sign(s.b0, s.node2.h.ID()))
+	s.Error(err)
+
+	// replayed with another nonce
+	_, err = s.node2.verifyHandshake(ctx, s.node1.h.ID(), s.RandHash().Bytes(), sign(s.b0, s.node1.h.ID()))
+	s.Error(err)
+
+	// forged signer
+	forged := sign(s.b0, s.node1.h.ID())
+	forged.Signer = s.signer1[:]
+	_, err = s.node2.verifyHandshake(ctx, s.node1.h.ID(), nonce, forged)
+	s.Error(err)
+
+	// stake amount shortage
+	_, err = s.node2.verifyHandshake(ctx, s.node1.h.ID(), nonce, sign(s.b2, s.node1.h.ID()))
+	s.ErrorContains(err, "stake amount shortage")
+}
+
+func (s *NodeTestSuite) TestRecentPeers() {
+	ctx := context.Background()
+	recents := newRecentPeers(dssync.MutexWrap(ds.NewMapDatastore()), 2)
+
+	infos := []peer.AddrInfo{
+		{ID: s.bootnode.h.ID(), Addrs: s.bootnode.h.Addrs()},
+		{ID: s.node1.h.ID(), Addrs: s.node1.h.Addrs()},
+		{ID: s.node2.h.ID(), Addrs: s.node2.h.Addrs()},
+	}
+	for _, info := range infos {
+		s.NoError(recents.add(ctx, info))
+		time.Sleep(time.Millisecond)
+	}
+
+	// the most recent peers are kept
+	got, err := recents.list(ctx)
+	s.NoError(err)
+	s.Len(got, 2)
+	s.Equal(s.node2.h.ID(), got[0].ID)
+	s.Equal(s.node1.h.ID(), got[1].ID)
+	s.ElementsMatch(s.node1.h.Addrs(), got[1].Addrs)
+
+	// the oldest peer has been forgotten
+	recents.max = 10
+	got, _ = recents.list(ctx)
+	s.Len(got, 2)
+
+	// reconnect
+	s.NoError(recents.store.Delete(ctx, ds.NewKey(s.node2.h.ID().String())))
+	s.Equal(1, reconnectRecentPeers(ctx, s.node2.h, recents))
+	s.Equal(network.Connected, s.node2.h.Network().Connectedness(s.node1.h.ID()))
+}
+
+func (s *NodeTestSuite) TestHostStatus() {
+	st, err := NewHostStatus(s.node1.h)
+	s.NoError(err)
+
+	s.Equal(100, st.ConnManager.LowWater)
+	s.Equal(400, st.ConnManager.HighWater)
+	s.Equal("1m0s", st.ConnManager.GracePeriod)
+	s.True(s.node1.h.ConnManager().IsProtected(s.bootnode.h.ID(), protectedConnTag))
+
+	s.Len(st.Resources, 1+len(verifierProtocols))
+	s.Equal("system", st.Resources[0].Scope)
+	s.Greater(st.Resources[0].MemoryLimit, int64(0))
+	s.Greater(st.Resources[0].ConnsLimit, 0)
+	for _, res := range st.Resources[1:] {
+		s.Contains(verifierProtocols, protocol.ID(res.Scope))
+		s.Equal(64, res.StreamsLimit)
+	}
+}
+
+func (s *NodeTestSuite) TestStaticPeers() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func(d time.Duration) { staticPeerCheckInterval = d }(staticPeerCheckInterval)
+	staticPeerCheckInterval = 50 * time.Millisecond
+
+	h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	s.NoError(err)
+	defer h.Close()
+
+	cfg := &config.P2P{StaticPeers: []string{s.node1.cfg.Listens[0] + "/p2p/" + s.node1.h.ID().String()}}
+	s.NoError(startStaticPeers(ctx, cfg, h))
+
+	connected := func() bool { return h.Network().Connectedness(s.node1.h.ID()) == network.Connected }
+	s.Eventually(connected, 5*time.Second, 50*time.Millisecond)
+
+	// redial after disconnection
+	s.NoError(h.Network().ClosePeer(s.node1.h.ID()))
+	s.Eventually(connected, 5*time.Second, 50*time.Millisecond)
+
+	// invalid address
+	cfg.StaticPeers = []string{"/ip4/127.0.0.1/tcp/4101"}
+	s.Error(startStaticPeers(ctx, cfg, h))
+}
+
+func (s *NodeTestSuite) TestPrivateNetwork() {
+	ctx := context.Background()
+	newConfig := func(key string) *config.P2P {
+		cfg := &config.P2P{
+			Listens:           []string{"/ip4/127.0.0.1/tcp/0"},
+			Bootnodes:         []string{"/ip4/127.0.0.1/tcp/4101/p2p/12D3KooWCNqRgVdwAhGrurCc8XE4RsWB8S2T83yMZR9R7Gdtf899"},
+			PrivateNetworkKey: key,
+		}
+		cfg.Transports.TCP = true
+		return cfg
+	}
+	newHost := func(key string) host.Host {
+		opt, err := privateNetworkOpt(newConfig(key))
+		s.NoError(err)
+		h, err := libp2p.New(opt, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+		s.NoError(err)
+		return h
+	}
+
+	key0, key1 := s.RandHash().Hex()[2:], s.RandHash().Hex()[2:]
+	h0, h1, h2 := newHost(key0), newHost(key0), newHost(key1)
+	defer h0.Close()
+	defer h1.Close()
+	defer h2.Close()
+
+	// only the nodes sharing the same key can connect
+	s.NoError(h0.Connect(ctx, peer.AddrInfo{ID: h1.ID(), Addrs: h1.Addrs()}))
+	s.Error(h0.Connect(ctx, peer.AddrInfo{ID: h2.ID(), Addrs: h2.Addrs()}))
+
+	// not configured
+	opt, err := privateNetworkOpt(newConfig(""))
+	s.NoError(err)
+	s.Nil(opt)
+
+	// invalid configurations
+	cases := map[string]func(cfg *config.P2P){
+		"private network key must be 32 bytes": func(cfg *config.P2P) { cfg.PrivateNetworkKey = key0[:32] },
+		"p2p.transports.quic must be disabled": func(cfg *config.P2P) { cfg.Transports.QUIC = true },
+		"p2p.transports.webtransport must be disabled": func(cfg *config.P2P) {
+			cfg.Transports.WebTransport = true
+		},
+		"p2p.legacy_topic must be disabled": func(cfg *config.P2P) { cfg.LegacyTopic = true },
+		"p2p.bootnodes must be TCP addresses": func(cfg *config.P2P) {
+			cfg.Bootnodes = []string{"/ip4/127.0.0.1/udp/4101/quic-v1/p2p/12D3KooWCNqRgVdwAhGrurCc8XE4RsWB8S2T83yMZR9R7Gdtf899"}
+		},
+		"p2p.listens must be TCP addresses": func(cfg *config.P2P) {
+			cfg.Listens = append(cfg.Listens, "/ip4/127.0.0.1/udp/0/quic-v1")
+		},
+	}
+	for want, modify := range cases {
+		cfg := newConfig(key0)
+		modify(cfg)
+		_, err := privateNetworkOpt(cfg)
+		s.ErrorContains(err, want)
+	}
+}
+
 func (s *NodeTestSuite) TestHandleFindCommonOptimismSignature() {
 	want := s.sigs[s.signer0][s.contract0][0]
 
@@ -378,8 +986,10 @@ func (s *NodeTestSuite) TestHandleFindCommonOptimismSignature() {
 }
 
 func (s *NodeTestSuite) TestPublishLatestSignatures() {
-	// Wait for pubsub to be ready
-	time.Sleep(50 * time.Millisecond)
+	// Wait for the mesh to be formed
+	s.Eventually(func() bool {
+		return slices.Contains(s.node1.topic.ListPeers(), s.node2.h.ID())
+	}, 5*time.Second, 100*time.Millisecond)
 
 	var got struct {
 		peer peer.ID
@@ -390,11 +1000,12 @@ func (s *NodeTestSuite) TestPublishLatestSignatures() {
 		defer cancel()
 
 		var m pb.PubSub
-		peerID, err := subscribe(ctx, s.node2.sub, s.node2.h.ID(), &m)
+		recv, err := subscribe(ctx, s.node2.sub, s.node2.h.ID(), &m)
 		if err != nil {
 			s.Fail(err.Error())
+			return
 		}
-		got.peer = peerID
+		got.peer = recv.GetFrom()
 		got.sigs = m.GetOptimismSignatureExchange().Latests
 	}()
 
@@ -406,7 +1017,7 @@ func (s *NodeTestSuite) TestPublishLatestSignatures() {
 
 	// assert
 	s.Equal(s.node1.h.ID(), got.peer)
-	s.Len(got.sigs, 2)
+	s.Require().Len(got.sigs, 2)
 
 	s.Equal(s.sigs[s.signer0][s.contract1][99].ID, got.sigs[0].Id)
 	s.Equal(s.sigs[s.signer1][s.contract1][199].ID, got.sigs[1].Id)
@@ -421,7 +1032,136 @@ func (s *NodeTestSuite) TestPublishLatestSignatures() {
 	s.Equal(uint64(199), got.sigs[1].RollupIndex)
 }
 
-func (s *NodeTestSuite) newWorker(bootnodes []string) *Node {
+func (s *NodeTestSuite) TestBootnodeHost() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	newConfig := func() *config.P2P {
+		cfg := &config.P2P{Listens: []string{"/ip4/127.0.0.1/tcp/0"}}
+		cfg.Transports.TCP = true
+		cfg.ConnManager.LowWater = 100
+		cfg.ConnManager.HighWater = 400
+		return cfg
+	}
+	hasWanDHT := func(h host.Host) bool {
+		return slices.Contains(h.Mux().Protocols(), kaddht.ProtocolDHT)
+	}
+
+	// the bootnode answers the DHT queries without waiting for the public reachability
+	priv, _, _, _ := GenerateKeyPair()
+	bootnode, err := NewBootnodeHost(ctx, newConfig(), priv, nil)
+	s.NoError(err)
+	defer bootnode.Close()
+	s.True(hasWanDHT(bootnode))
+
+	priv, _, _, _ = GenerateKeyPair()
+	h, _, _, _, err := NewHost(ctx, newConfig(), priv, nil, nil)
+	s.NoError(err)
+	defer h.Close()
+	s.False(hasWanDHT(h))
+}
+
+func (s *NodeTestSuite) TestTransports() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	newHost := func(listens []string, setup func(cfg *config.P2P)) host.Host {
+		cfg := &config.P2P{Listens: listens}
+		cfg.ConnManager.LowWater = 100
+		cfg.ConnManager.HighWater = 400
+		setup(cfg)
+
+		priv, _, _, _ := GenerateKeyPair()
+		h, _, _, _, err := NewHost(ctx, cfg, priv, nil, nil)
+		s.Require().NoError(err)
+		return h
+	}
+	enableAll := func(cfg *config.P2P) {
+		cfg.Transports.WebSocket.Enable = true
+		cfg.Transports.WebTransport = true
+	}
+
+	listens := []string{
+		"/ip4/127.0.0.1/tcp/0/ws",
+		"/ip4/127.0.0.1/udp/0/quic-v1/webtransport",
+	}
+	h0, h1 := newHost(listens, enableAll), newHost(listens, enableAll)
+	defer h0.Close()
+	defer h1.Close()
+
+	// announce the certificate hashes of the WebTransport
+	var wsAddr, wtAddr ma.Multiaddr
+	for _, addr := range h1.Addrs() {
+		if CheckAddressesProtocols([]ma.Multiaddr{addr}, []int{ma.P_WS}, nil) {
+			wsAddr = addr
+		} else if CheckAddressesProtocols([]ma.Multiaddr{addr}, []int{ma.P_WEBTRANSPORT, ma.P_CERTHASH}, nil) {
+			wtAddr = addr
+		}
+	}
+	s.Require().NotNil(wsAddr)
+	s.Require().NotNil(wtAddr)
+
+	s.NoError(h0.Connect(ctx, peer.AddrInfo{ID: h1.ID(), Addrs: []ma.Multiaddr{wsAddr}}))
+	nwstat := newNetworkStatus(h0)
+	s.Equal(1, nwstat.connections.tcp)
+	s.Equal(1, nwstat.connections.websocket)
+	s.Equal(0, nwstat.connections.webtransport)
+
+	h0.Network().ClosePeer(h1.ID())
+	s.NoError(h0.Connect(ctx, peer.AddrInfo{ID: h1.ID(), Addrs: []ma.Multiaddr{wtAddr}}))
+	s.Eventually(func() bool {
+		nwstat = newNetworkStatus(h0)
+		return nwstat.connections.websocket == 0
+	}, 3*time.Second, 50*time.Millisecond)
+	s.Equal(1, nwstat.connections.udp)
+	s.Equal(1, nwstat.connections.webtransport)
+
+	// listen on the secure WebSocket
+	certFile, keyFile := s.writeCertificate()
+	h2 := newHost([]string{"/ip4/127.0.0.1/tcp/0/tls/ws"}, func(cfg *config.P2P) {
+		cfg.Transports.WebSocket.Enable = true
+		cfg.Transports.WebSocket.CertFile = certFile
+		cfg.Transports.WebSocket.KeyFile = keyFile
+	})
+	defer h2.Close()
+	s.True(CheckAddressesProtocols(h2.Addrs(), []int{ma.P_TLS, ma.P_WS}, nil))
+
+	// missing certificate
+	cfg := &config.P2P{Listens: []string{"/ip4/127.0.0.1/tcp/0/tls/ws"}}
+	cfg.Transports.WebSocket.Enable = true
+	cfg.Transports.WebSocket.CertFile = certFile + ".missing"
+	cfg.Transports.WebSocket.KeyFile = keyFile
+	_, err := webSocketTransportOpt(cfg)
+	s.ErrorContains(err, "failed to load websocket certificate")
+}
+
+// Write a self-signed certificate and the private key in PEM format.
+func (s *NodeTestSuite) writeCertificate() (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	s.Require().NoError(err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	s.Require().NoError(err)
+
+	dir := s.T().TempDir()
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	s.Require().NoError(os.WriteFile(certFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	s.Require().NoError(os.WriteFile(keyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
+
+func (s *NodeTestSuite) newWorker(bootnodes []string, signer Signer) *Node {
 	// Setup database.
 	s.db, _ = database.NewDatabase(&config.Database{Path: ":memory:"})
 
@@ -431,6 +1171,7 @@ func (s *NodeTestSuite) newWorker(bootnodes []string) *Node {
 		Listens:         []string{"/ip4/127.0.0.1/tcp/" + s.findPort(5)},
 		PublishInterval: 0,
 		StreamTimeout:   3 * time.Second,
+		LegacyTopic:     true,
 		ExperimentalLanDHT: struct {
 			Loopback  bool
 			Bootnodes []string
@@ -441,16 +1182,36 @@ func (s *NodeTestSuite) newWorker(bootnodes []string) *Node {
 	cfg.InboundLimits.Concurrency = 10
 	cfg.InboundLimits.Throttling = 1000
 	cfg.InboundLimits.MaxSendTime = time.Second * 5
-	host, dht, bwm, hpHelper, _ := NewHost(context.Background(), cfg, priv)
+	cfg.CatchUp.MaxSignatures = 1000
+	cfg.ConnManager.LowWater = 100
+	cfg.ConnManager.HighWater = 400
+	cfg.ConnManager.GracePeriod = time.Minute
+	cfg.ConnManager.ProtectedPeers = bootnodes
+	cfg.ResourceManager.ProtocolStreams = 64
+	cfg.InboundLimits.PeerThrottling = 500
+	cfg.Misbehaviour.Threshold = 100
+	cfg.Misbehaviour.BlockDuration = time.Minute
+	cfg.Misbehaviour.ScoreDecay = time.Minute
+	blocklist := NewBlocklist()
+	host, dht, bwm, hpHelper, _ := NewHost(context.Background(), cfg, priv, nil, blocklist)
 
 	worker, _ := NewNode(cfg, s.db, host, dht, bwm, hpHelper,
-		s.b0.ChainID().Uint64(), []common.Address{}, s.stakemanager, s.versepool)
+		s.b0.ChainID().Uint64(), []common.Address{}, s.stakemanager, s.versepool,
+		equivocation.NewDetector(s.db, s.stakemanager, s.versepool), signer, blocklist)
 	host.SetStreamHandler(streamProtocol,
 		worker.newStreamHandler(context.Background()))
+	host.SetStreamHandler(catchUpProtocol,
+		worker.newCatchUpHandler(context.Background()))
+	host.SetStreamHandler(handshakeProtocol, worker.newHandshakeHandler())
+	host.SetStreamHandler(nodeInfoProtocol, worker.newNodeInfoHandler())
 
 	return worker
 }
 
+type testSigner struct{ *backend.SignableBackend }
+
+func (t *testSigner) From() common.Address { return t.Signer() }
+
 func (s *NodeTestSuite) findPort(maxAttempts int) string {
 	for i := 0; i < maxAttempts; i++ {
 		addr, err := net.ResolveTCPAddr("tcp", net.JoinHostPort("127.0.0.1", "0"))
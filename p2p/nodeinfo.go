@@ -0,0 +1,234 @@
+package p2p
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	pb "github.com/oasysgames/oasys-optimism-verifier/proto/p2p/v1/gen"
+	"github.com/oasysgames/oasys-optimism-verifier/verse"
+	"github.com/oasysgames/oasys-optimism-verifier/version"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	nodeInfoProtocol = "/oasys-optimism-verifier/nodeinfo/1.0.0"
+
+	// key of the peerstore metadata to save the node info
+	nodeInfoPeerstoreKey = "oasys-optimism-verifier/nodeinfo"
+
+	RoleVerifier  = "verifier"
+	RoleSubmitter = "submitter"
+)
+
+// Software version, roles and served verses of the node. Note that the signer is
+// self-reported, use the handshake to verify that the peer owns the signer.
+type NodeInfo struct {
+	Version        string          `json:"version"`
+	Roles          []string        `json:"roles"`
+	Signer         *common.Address `json:"signer,omitempty"`
+	VerifyChainIDs []uint64        `json:"verify_chain_ids"`
+	SubmitChainIDs []uint64        `json:"submit_chain_ids"`
+}
+
+func init() {
+	// the persistent peerstore encodes the metadata with gob
+	gob.Register(&NodeInfo{})
+}
+
+func newNodeInfo(signer Signer, enableSubmitter bool, versepool verse.VersePool) *NodeInfo {
+	info := &NodeInfo{
+		Version:        version.SemVer(),
+		Roles:          []string{},
+		VerifyChainIDs: []uint64{},
+		SubmitChainIDs: []uint64{},
+	}
+	if signer != nil {
+		addr := signer.From()
+		info.Signer = &addr
+		info.Roles = append(info.Roles, RoleVerifier)
+	}
+	if enableSubmitter {
+		info.Roles = append(info.Roles, RoleSubmitter)
+	}
+
+	versepool.Range(func(item *verse.VersePoolItem) bool {
+		if signer != nil {
+			info.VerifyChainIDs = append(info.VerifyChainIDs, item.Verse().ChainID())
+		}
+		if enableSubmitter && item.CanSubmit() {
+			info.SubmitChainIDs = append(info.SubmitChainIDs, item.Verse().ChainID())
+		}
+		return true
+	})
+	slices.Sort(info.VerifyChainIDs)
+	slices.Sort(info.SubmitChainIDs)
+	info.VerifyChainIDs = slices.Compact(info.VerifyChainIDs)
+	info.SubmitChainIDs = slices.Compact(info.SubmitChainIDs)
+
+	return info
+}
+
+func (info *NodeInfo) toProto() *pb.NodeInfo {
+	m := &pb.NodeInfo{
+		Version:        info.Version,
+		Roles:          info.Roles,
+		VerifyChainIds: info.VerifyChainIDs,
+		SubmitChainIds: info.SubmitChainIDs,
+	}
+	if info.Signer != nil {
+		m.Signer = info.Signer[:]
+	}
+	return m
+}
+
+func nodeInfoFromProto(m *pb.NodeInfo) (*NodeInfo, error) {
+	if !semver.IsValid("v" + m.Version) {
+		return nil, fmt.Errorf("invalid version: %s", m.Version)
+	}
+
+	info := &NodeInfo{
+		Version:        m.Version,
+		Roles:          m.Roles,
+		VerifyChainIDs: m.VerifyChainIds,
+		SubmitChainIDs: m.SubmitChainIds,
+	}
+	if len(m.Signer) > 0 {
+		if len(m.Signer) != common.AddressLength {
+			return nil, errors.New("malformed signer")
+		}
+		signer := common.BytesToAddress(m.Signer)
+		info.Signer = &signer
+	}
+	if info.Roles == nil {
+		info.Roles = []string{}
+	}
+	if info.VerifyChainIDs == nil {
+		info.VerifyChainIDs = []uint64{}
+	}
+	if info.SubmitChainIDs == nil {
+		info.SubmitChainIDs = []uint64{}
+	}
+	return info, nil
+}
+
+// Returns the node info of the peer saved in the peerstore.
+func PeerNodeInfo(h host.Host, id peer.ID) (*NodeInfo, bool) {
+	val, err := h.Peerstore().Get(id, nodeInfoPeerstoreKey)
+	if err != nil {
+		return nil, false
+	}
+	info, ok := val.(*NodeInfo)
+	return info, ok
+}
+
+// Returns the node info of oneself.
+func (w *Node) NodeInfo() *NodeInfo {
+	return newNodeInfo(w.signer, w.enableSubscriber, w.versepool)
+}
+
+// Send own node info to the peer and receive the node info of the peer.
+func (w *Node) exchangeNodeInfo(ctx context.Context, id peer.ID) error {
+	s, err := w.openStream(ctx, id, nodeInfoProtocol)
+	if err != nil {
+		return err
+	}
+	defer w.closeStream(s)
+
+	req := &pb.Stream{Body: &pb.Stream_NodeInfo{NodeInfo: w.NodeInfo().toProto()}}
+	if err := w.writeStream(s, req); err != nil {
+		return err
+	}
+
+	res, err := w.readStream(s)
+	if err != nil {
+		return err
+	}
+	t := res.GetNodeInfo()
+	if t == nil {
+		return errors.New("unexpected response")
+	}
+	return w.acceptNodeInfo(id, t)
+}
+
+func (w *Node) newNodeInfoHandler() network.StreamHandler {
+	return func(s network.Stream) {
+		defer w.closeStream(s)
+
+		w.meterStreamHandled.Incr()
+
+		peer := s.Conn().RemotePeer()
+		m, err := w.readStream(s)
+		if err != nil {
+			w.log.Debug("Failed to read stream message", "peer", peer, "err", err)
+			return
+		}
+		t := m.GetNodeInfo()
+		if t == nil {
+			w.log.Warn("Received an unknown message", "peer", peer)
+			w.receivedUnknownMessage(peer)
+			return
+		}
+
+		// reply before disconnecting the peer of an old version,
+		// so that the peer can tell the reason from the version
+		res := &pb.Stream{Body: &pb.Stream_NodeInfo{NodeInfo: w.NodeInfo().toProto()}}
+		if err := w.writeStream(s, res); err != nil {
+			w.log.Error("Failed to send node info", "peer", peer, "err", err)
+		}
+
+		if err := w.acceptNodeInfo(peer, t); err != nil {
+			w.log.Debug("Refused node info", "peer", peer, "err", err)
+		}
+	}
+}
+
+// Save the node info of the peer, and refuse the
+// peer if it is older than the minimum version.
+func (w *Node) acceptNodeInfo(id peer.ID, m *pb.NodeInfo) error {
+	info, err := nodeInfoFromProto(m)
+	if err != nil {
+		w.log.Warn("Invalid node info", "peer", id, "err", err)
+		return err
+	}
+
+	if err := w.refusePeer(id, info.Version); err != nil {
+		return err
+	}
+
+	if err := w.h.Peerstore().Put(id, nodeInfoPeerstoreKey, info); err != nil {
+		return err
+	}
+	w.log.Debug("Received node info", "peer", id,
+		"version", info.Version, "roles", info.Roles)
+	return nil
+}
+
+// Disconnect and blocklist the peer for a while if the
+// version is older than the minimum version.
+func (w *Node) refusePeer(id peer.ID, version string) error {
+	min := w.cfg.MinPeerVersion
+	if min == "" || semver.Compare("v"+version, "v"+min) != -1 {
+		return nil
+	}
+
+	w.log.Warn("Refusing the peer running an old version", "peer", id,
+		"version", version, "min-version", min, "duration", w.cfg.Misbehaviour.BlockDuration)
+	w.blocklist.Block(id, w.cfg.Misbehaviour.BlockDuration)
+	w.h.Network().ClosePeer(id)
+	return fmt.Errorf("version %s is older than %s", version, min)
+}
+
+// Returns true if the peer supports any protocol of the verifier.
+func isVerifierPeer(protocols []protocol.ID) bool {
+	return slices.ContainsFunc(protocols, func(p protocol.ID) bool {
+		return p == streamProtocol || p == catchUpProtocol || p == handshakeProtocol
+	})
+}
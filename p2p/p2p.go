@@ -23,21 +23,39 @@ import (
 	"github.com/oasysgames/oasys-optimism-verifier/config"
 
 	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
 	dssync "github.com/ipfs/go-datastore/sync"
 	kaddht "github.com/libp2p/go-libp2p-kad-dht"
 	ps "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoreds"
 	rhost "github.com/libp2p/go-libp2p/p2p/host/routed"
 	"github.com/libp2p/go-libp2p/p2p/protocol/holepunch"
 	"github.com/libp2p/go-libp2p/p2p/protocol/identify"
 	quic "github.com/libp2p/go-libp2p/p2p/transport/quic"
 	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	libp2pwebtransport "github.com/libp2p/go-libp2p/p2p/transport/webtransport"
 )
 
-// Create libp2p host.
+// Create libp2p host. If `store` is not nil, the peerstore and
+// the DHT records are persisted in it, otherwise kept in memory.
+// The connections from the peers in the `blocklist` are refused.
 func NewHost(
 	ctx context.Context,
 	cfg *config.P2P,
 	priv crypto.PrivKey,
+	store ds.Batching,
+	blocklist *Blocklist,
+) (host.Host, routing.Routing, *metrics.BandwidthCounter, HolePunchHelper, error) {
+	return newHost(ctx, cfg, priv, store, blocklist, kaddht.ModeAuto)
+}
+
+func newHost(
+	ctx context.Context,
+	cfg *config.P2P,
+	priv crypto.PrivKey,
+	store ds.Batching,
+	blocklist *Blocklist,
+	dhtMode kaddht.ModeOpt,
 ) (host.Host, routing.Routing, *metrics.BandwidthCounter, HolePunchHelper, error) {
 	// Construct libp2p host.
 	bwm := metrics.NewBandwidthCounter()
@@ -48,13 +66,24 @@ func NewHost(
 		libp2p.BandwidthReporter(bwm),
 	}
 
-	appends, hpHelper, err := userOptions(cfg)
+	appends, hpHelper, err := userOptions(cfg, blocklist)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	} else {
 		opts = append(opts, appends...)
 	}
 
+	var recents *recentPeers
+	if store != nil {
+		pstore, err := pstoreds.NewPeerstore(ctx,
+			namespace.Wrap(store, ds.NewKey(peerstoreNamespace)), pstoreds.DefaultOpts())
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to construct peerstore: %w", err)
+		}
+		opts = append(opts, libp2p.Peerstore(pstore))
+		recents = newRecentPeers(store, cfg.PeerStore.ReconnectPeers)
+	}
+
 	h, err := libp2p.New(opts...)
 	if err != nil {
 		return nil, nil, nil, nil, err
@@ -64,6 +93,14 @@ func NewHost(
 	h.Network().Notify(&network.NotifyBundle{
 		ConnectedF: func(n network.Network, c network.Conn) {
 			log.Info("Connected new peer", "peer", maToP2P(c.RemoteMultiaddr(), c.RemotePeer()))
+
+			// Remember the peers dialed by oneself, as their addresses are reachable.
+			if recents != nil && c.Stat().Direction == network.DirOutbound {
+				info := peer.AddrInfo{ID: c.RemotePeer(), Addrs: []multiaddr.Multiaddr{c.RemoteMultiaddr()}}
+				if err := recents.add(ctx, info); err != nil {
+					log.Error("Failed to save recent peer", "peer", c.RemotePeer(), "err", err)
+				}
+			}
 		},
 		DisconnectedF: func(n network.Network, c network.Conn) {
 			log.Info("Disconnected peer", "peer", maToP2P(c.RemoteMultiaddr(), c.RemotePeer()))
@@ -71,11 +108,26 @@ func NewHost(
 	})
 
 	// Construct libp2p DHT.
-	dht, err := newRouting(ctx, cfg, h)
+	dht, err := newRouting(ctx, cfg, h, store, dhtMode)
 	if err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("failed to construct DHT: %w", err)
 	}
 
+	// Discover the peers in the local network and keep connecting to the static peers.
+	if cfg.MDNS.Enable {
+		if err := startMDNS(ctx, cfg, h); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to start mDNS: %w", err)
+		}
+	}
+	if err := startStaticPeers(ctx, cfg, h); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// Reconnect to the recent peers first so as not to depend on the bootnodes.
+	if recents != nil {
+		reconnectRecentPeers(ctx, h, recents)
+	}
+
 	// Bootstrap the DHT. In the default configuration, this spawns a Background
 	// thread that will refresh the peer table every five minutes.
 	if err = dht.Bootstrap(ctx); err != nil {
@@ -85,13 +137,15 @@ func NewHost(
 	return rhost.Wrap(h, dht), dht, bwm, hpHelper, nil
 }
 
-func userOptions(cfg *config.P2P) (opts []libp2p.Option, hpHelper HolePunchHelper, err error) {
+func userOptions(cfg *config.P2P, blocklist *Blocklist) (opts []libp2p.Option, hpHelper HolePunchHelper, err error) {
 	// Construct listening addresses.
 	listens := cfg.Listens
 	if cfg.Listen != "" {
 		s := strings.Split(cfg.Listen, ":")
 		listens = append(listens, fmt.Sprintf("/ip4/%s/tcp/%s", s[0], s[1]))
-		listens = append(listens, fmt.Sprintf("/ip4/%s/udp/%s/quic", s[0], s[1]))
+		if cfg.PrivateNetworkKey == "" {
+			listens = append(listens, fmt.Sprintf("/ip4/%s/udp/%s/quic", s[0], s[1]))
+		}
 	}
 	if len(listens) == 0 {
 		return nil, nil, errors.New("no listening address")
@@ -101,6 +155,13 @@ func userOptions(cfg *config.P2P) (opts []libp2p.Option, hpHelper HolePunchHelpe
 		opts = append(opts, libp2p.ListenAddrs(listenAddrs...))
 	}
 
+	// Join the private network.
+	if opt, err := privateNetworkOpt(cfg); err != nil {
+		return nil, nil, err
+	} else if opt != nil {
+		opts = append(opts, opt)
+	}
+
 	// Construct address factory.
 	opt, err := AddrsFactoryOpt(cfg.AppendAnnounce, cfg.NoAnnounce)
 	if err != nil {
@@ -108,15 +169,27 @@ func userOptions(cfg *config.P2P) (opts []libp2p.Option, hpHelper HolePunchHelpe
 	}
 	opts = append(opts, opt)
 
-	// Construct connection filter.
-	if len(cfg.ConnectionFilter) > 0 {
-		opt, err := ConnectionFilterOpt(cfg.ConnectionFilter)
+	// Construct connection filter and blocklist.
+	if len(cfg.ConnectionFilter) > 0 || blocklist != nil {
+		opt, err := ConnectionGaterOpt(cfg.ConnectionFilter, blocklist)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to construct connection filter: %w", err)
 		}
 		opts = append(opts, opt)
 	}
 
+	// Construct connection manager and resource manager.
+	if opt, err := connManagerOpt(cfg); err != nil {
+		return nil, nil, fmt.Errorf("failed to construct connection manager: %w", err)
+	} else {
+		opts = append(opts, opt)
+	}
+	if opt, err := resourceManagerOpt(cfg); err != nil {
+		return nil, nil, fmt.Errorf("failed to construct resource manager: %w", err)
+	} else {
+		opts = append(opts, opt)
+	}
+
 	// Enable transport protocols.
 	if cfg.Transports.TCP {
 		opts = append(opts, libp2p.Transport(tcp.NewTCPTransport))
@@ -124,6 +197,16 @@ func userOptions(cfg *config.P2P) (opts []libp2p.Option, hpHelper HolePunchHelpe
 	if cfg.Transports.QUIC {
 		opts = append(opts, libp2p.Transport(quic.NewTransport))
 	}
+	if cfg.Transports.WebSocket.Enable {
+		if opt, err := webSocketTransportOpt(cfg); err != nil {
+			return nil, nil, err
+		} else {
+			opts = append(opts, opt)
+		}
+	}
+	if cfg.Transports.WebTransport {
+		opts = append(opts, libp2p.Transport(libp2pwebtransport.New))
+	}
 
 	// Enable NAT traversal using UPnP.
 	if cfg.NAT.UPnP {
@@ -164,9 +247,18 @@ func newRouting(
 	ctx context.Context,
 	cfg *config.P2P,
 	h host.Host,
+	store ds.Batching,
+	mode kaddht.ModeOpt,
 ) (routing.Routing, error) {
+	var dstore ds.Batching
+	if store != nil {
+		dstore = namespace.Wrap(store, ds.NewKey(dhtNamespace))
+	} else {
+		dstore = dssync.MutexWrap(ds.NewMapDatastore())
+	}
 	opts := []kaddht.Option{
-		kaddht.Datastore(dssync.MutexWrap(ds.NewMapDatastore())),
+		kaddht.Datastore(dstore),
+		kaddht.Mode(mode),
 	}
 
 	// options for the WanDHT
@@ -294,30 +386,18 @@ LOOP:
 	return false
 }
 
-func setupPubSub(
-	ctx context.Context,
-	h host.Host,
-	topic_ string,
-) (*ps.PubSub, *ps.Topic, *ps.Subscription, error) {
-	// Create pubsub object.
-	ps, err := ps.NewGossipSub(ctx, h)
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to create pubsub object: %w", err)
+func setupPubSub(ctx context.Context, h host.Host, cfg *config.P2P) (*ps.PubSub, error) {
+	var opts []ps.Option
+	if cfg.PeerScore.Enable {
+		opts = append(opts, ps.WithPeerScore(newPeerScoreParams(cfg)))
 	}
 
-	// Join to pubsub topic.
-	topic, err := ps.Join(topic_)
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to join the topic: %w", err)
-	}
-
-	// Subscribe to new message.
-	sub, err := topic.Subscribe()
+	// Create pubsub object.
+	ps, err := ps.NewGossipSub(ctx, h, opts...)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to subscribe the topic: %w", err)
+		return nil, fmt.Errorf("failed to create pubsub object: %w", err)
 	}
-
-	return ps, topic, sub, nil
+	return ps, nil
 }
 
 func compress(data []byte) ([]byte, error) {
@@ -0,0 +1,135 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+	leveldb "github.com/ipfs/go-ds-leveldb"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// namespaces in the datastore
+	peerstoreNamespace   = "/peerstore"
+	dhtNamespace         = "/dht"
+	recentPeersNamespace = "/recent-peers"
+
+	reconnectTimeout = 10 * time.Second
+)
+
+// Open the on-disk datastore to persist the peerstore and the DHT records.
+func OpenDatastore(path string) (ds.Batching, error) {
+	store, err := leveldb.NewDatastore(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+type recentPeer struct {
+	Info     peer.AddrInfo `json:"info"`
+	LastSeen time.Time     `json:"last_seen"`
+}
+
+// Peers that oneself connected to recently, used to reconnect after restarts.
+type recentPeers struct {
+	store ds.Datastore
+	max   int
+}
+
+func newRecentPeers(store ds.Datastore, max int) *recentPeers {
+	return &recentPeers{
+		store: namespace.Wrap(store, ds.NewKey(recentPeersNamespace)),
+		max:   max,
+	}
+}
+
+func (r *recentPeers) add(ctx context.Context, info peer.AddrInfo) error {
+	data, err := json.Marshal(&recentPeer{Info: info, LastSeen: time.Now()})
+	if err != nil {
+		return err
+	}
+	return r.store.Put(ctx, ds.NewKey(info.ID.String()), data)
+}
+
+// Returns the peers in order of the most recently connected,
+// and forgets the peers exceeding the maximum number.
+func (r *recentPeers) list(ctx context.Context) ([]peer.AddrInfo, error) {
+	res, err := r.store.Query(ctx, query.Query{})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return nil, err
+	}
+
+	var peers []*recentPeer
+	for _, entry := range entries {
+		var p recentPeer
+		if err := json.Unmarshal(entry.Value, &p); err != nil {
+			log.Warn("Failed to decode recent peer", "key", entry.Key, "err", err)
+			r.store.Delete(ctx, ds.NewKey(entry.Key))
+			continue
+		}
+		peers = append(peers, &p)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].LastSeen.After(peers[j].LastSeen) })
+
+	if len(peers) > r.max {
+		for _, p := range peers[r.max:] {
+			if err := r.store.Delete(ctx, ds.NewKey(p.Info.ID.String())); err != nil {
+				return nil, err
+			}
+		}
+		peers = peers[:r.max]
+	}
+
+	infos := make([]peer.AddrInfo, len(peers))
+	for i, p := range peers {
+		infos[i] = p.Info
+	}
+	return infos, nil
+}
+
+// Connect to the recently connected peers concurrently.
+// Returns the number of peers successfully connected.
+func reconnectRecentPeers(ctx context.Context, h host.Host, recents *recentPeers) int {
+	infos, err := recents.list(ctx)
+	if err != nil {
+		log.Error("Failed to load recent peers", "err", err)
+		return 0
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconnectTimeout)
+	defer cancel()
+
+	var (
+		wg        sync.WaitGroup
+		connected atomic.Int32
+	)
+	for _, info := range infos {
+		wg.Add(1)
+		go func(info peer.AddrInfo) {
+			defer wg.Done()
+			if err := h.Connect(ctx, info); err != nil {
+				log.Debug("Failed to reconnect recent peer", "peer", info.ID, "err", err)
+			} else {
+				connected.Add(1)
+			}
+		}(info)
+	}
+	wg.Wait()
+
+	log.Info("Reconnected to recent peers", "peers", len(infos), "connected", connected.Load())
+	return int(connected.Load())
+}
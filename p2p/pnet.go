@@ -0,0 +1,71 @@
+package p2p
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/pnet"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+)
+
+// Returns the option to join the private network if the pre-shared key is configured.
+// Only the nodes sharing the same key can connect to each other.
+func privateNetworkOpt(cfg *config.P2P) (libp2p.Option, error) {
+	if cfg.PrivateNetworkKey == "" {
+		return nil, nil
+	}
+
+	psk, err := hex.DecodeString(cfg.PrivateNetworkKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private network key: %w", err)
+	} else if len(psk) != 32 {
+		return nil, fmt.Errorf("private network key must be 32 bytes, got %d bytes", len(psk))
+	}
+
+	if err := validatePrivateNetwork(cfg); err != nil {
+		return nil, err
+	}
+	return libp2p.PrivateNetwork(pnet.PSK(psk)), nil
+}
+
+// Check that the configuration is consistent with the private network. The libp2p
+// private network protects the raw connections, which is not possible for QUIC
+// since it has its own encryption, so QUIC cannot be used in the private network.
+func validatePrivateNetwork(cfg *config.P2P) error {
+	if cfg.Transports.QUIC {
+		return errors.New("p2p.transports.quic must be disabled in private network mode " +
+			"since QUIC does not support the pre-shared key")
+	}
+	if cfg.Transports.WebTransport {
+		return errors.New("p2p.transports.webtransport must be disabled in private network mode " +
+			"since WebTransport does not support the pre-shared key")
+	}
+
+	// The topic is shared with the nodes of older versions in the
+	// public network, which cannot join the private network.
+	if cfg.LegacyTopic {
+		return errors.New("p2p.legacy_topic must be disabled in private network mode")
+	}
+
+	for name, addrs := range map[string][]string{
+		"p2p.listens":                        cfg.Listens,
+		"p2p.bootnodes":                      cfg.Bootnodes,
+		"p2p.relay_client.relay_nodes":       cfg.RelayClient.RelayNodes,
+		"p2p.static_peers":                   cfg.StaticPeers,
+		"p2p.experimental_lan_dht.bootnodes": cfg.ExperimentalLanDHT.Bootnodes,
+	} {
+		for _, addr := range addrs {
+			maddr, err := multiaddr.NewMultiaddr(addr)
+			if err != nil {
+				return fmt.Errorf("invalid address in %s: %s: %w", name, addr, err)
+			}
+			if !CheckAddressesProtocols([]multiaddr.Multiaddr{maddr}, []int{multiaddr.P_TCP}, nil) {
+				return fmt.Errorf("%s must be TCP addresses in private network mode: %s", name, addr)
+			}
+		}
+	}
+	return nil
+}
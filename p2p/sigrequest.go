@@ -0,0 +1,197 @@
+package p2p
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	pb "github.com/oasysgames/oasys-optimism-verifier/proto/p2p/v1/gen"
+)
+
+const signatureRequestTimeout = time.Minute
+
+// Request the signatures of the rollup index from the connected peers in the background.
+// The peers publishing the signatures of the signers not in `excludes` take precedence.
+// Does nothing if the node is not started or a request for the same contract is in progress.
+// The request is bounded by the lifetime of the node, since the caller
+// usually gives up the rollup index right after calling this.
+func (w *Node) RequestSignatures(
+	contract common.Address,
+	rollupIndex uint64,
+	excludes []common.Address,
+) {
+	lifetime := w.ctx.Load()
+	if lifetime == nil {
+		return
+	} else if _, loaded := w.sigRequests.LoadOrStore(contract, rollupIndex); loaded {
+		return
+	}
+
+	go func() {
+		defer w.sigRequests.Delete(contract)
+
+		ctx, cancel := context.WithTimeout(*lifetime, signatureRequestTimeout)
+		defer cancel()
+		w.requestSignatures(ctx, contract, rollupIndex, excludes)
+	}()
+}
+
+func (w *Node) requestSignatures(
+	ctx context.Context,
+	contract common.Address,
+	rollupIndex uint64,
+	excludes []common.Address,
+) {
+	req := &pb.SignatureRequest{
+		Contract:    contract[:],
+		RollupIndex: rollupIndex,
+		Excludes:    make([][]byte, len(excludes)),
+	}
+	excludeMap := map[common.Address]struct{}{}
+	for i, signer := range excludes {
+		req.Excludes[i] = signer[:]
+		excludeMap[signer] = struct{}{}
+	}
+
+	peers := w.signatureRequestPeers(ctx, excludeMap, w.cfg.CatchUp.Peers)
+	if len(peers) == 0 {
+		w.log.Debug("No peers to request signatures", "contract", contract, "index", rollupIndex)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range peers {
+		wg.Add(1)
+		go func(id peer.ID) {
+			defer wg.Done()
+			w.requestSignaturesFrom(ctx, id, req)
+		}(id)
+	}
+	wg.Wait()
+}
+
+// Returns up to `n` connected peers supporting the catch-up protocol, ordered by the total
+// stake of the signers they published that the requester does not have.
+func (w *Node) signatureRequestPeers(
+	ctx context.Context,
+	excludes map[common.Address]struct{},
+	n int,
+) []peer.ID {
+	peers := w.catchUpSupportedPeers()
+	weights := map[peer.ID]float64{}
+	for _, id := range peers {
+		weights[id] = w.peerWeight(ctx, id, excludes)
+	}
+
+	sort.SliceStable(peers, func(i, j int) bool { return weights[peers[i]] > weights[peers[j]] })
+	if len(peers) > n {
+		peers = peers[:n]
+	}
+	return peers
+}
+
+func (w *Node) requestSignaturesFrom(ctx context.Context, peer peer.ID, req *pb.SignatureRequest) {
+	logctx := []interface{}{"peer", peer,
+		"contract", common.BytesToAddress(req.Contract), "index", req.RollupIndex}
+
+	s, err := w.openStream(ctx, peer, catchUpProtocol)
+	if err != nil {
+		return
+	}
+	defer w.closeStream(s)
+
+	if err := w.writeStream(s, &pb.Stream{Body: &pb.Stream_SignatureRequest{SignatureRequest: req}}); err != nil {
+		w.log.Error("Failed to send signature request", append(logctx, "err", err)...)
+		return
+	}
+
+	var receives, saves int
+	for {
+		m, err := w.readStream(s)
+		if err != nil {
+			w.log.Debug("Failed to read stream message", append(logctx, "err", err)...)
+			break
+		}
+
+		body := m.GetSignatureRequest()
+		if body == nil {
+			if m.GetEom() == nil {
+				w.log.Warn("Received an unknown message", logctx...)
+				w.receivedUnknownMessage(peer)
+			}
+			break
+		}
+
+		receives += len(body.Responses)
+		n, err := w.receiveSignatures(ctx, peer, body.Responses, "requestSignaturesFrom")
+		if saves += n; err != nil {
+			break
+		}
+	}
+	w.log.Info("Received requested signatures", append(logctx, "receives", receives, "saves", saves)...)
+}
+
+func (w *Node) handleSignatureRequest(
+	ctx context.Context,
+	s network.Stream,
+	req *pb.SignatureRequest,
+) {
+	peerID := s.Conn().RemotePeer()
+	if len(req.Contract) != common.AddressLength {
+		w.log.Warn("Malformed signature request", "peer", peerID)
+		return
+	}
+	contract := common.BytesToAddress(req.Contract)
+	logctx := []interface{}{"peer", peerID, "contract", contract, "index", req.RollupIndex}
+	w.log.Info("Received signature request", logctx...)
+
+	excludes := map[common.Address]struct{}{}
+	for _, signer := range req.Excludes {
+		excludes[common.BytesToAddress(signer)] = struct{}{}
+	}
+
+	inboundSem, inboundThrot := w.inboundLimits(ctx, peerID)
+	if err := inboundSem.Acquire(ctx, 1); err != nil {
+		w.log.Error("Failed to acquire inbound semaphore", append(logctx, "err", err)...)
+		return
+	}
+	sigs, err := w.db.OPSignature.Find(nil, nil, &contract, &req.RollupIndex, 1000, 0)
+	inboundSem.Release(1)
+	if err != nil {
+		w.log.Error("Failed to find requested signatures", append(logctx, "err", err)...)
+		return
+	}
+
+	var responses []*pb.OptimismSignature
+	for _, sig := range sigs {
+		if _, ok := excludes[sig.Signer.Address]; ok {
+			continue
+		}
+		if w.stakemanager.StakeBySigner(ctx, sig.Signer.Address).Cmp(ethutil.TenMillionOAS) >= 0 {
+			responses = append(responses, toProtoBufSig(sig))
+		}
+	}
+	if len(responses) == 0 {
+		return
+	}
+	w.throttling(inboundThrot, len(responses), "in", "handleSignatureRequest", "peer", peerID)
+	w.throttling(w.peerThrots.get(peerID), len(responses), "in", "handleSignatureRequest", "peer", peerID)
+
+	m := &pb.Stream{Body: &pb.Stream_SignatureRequest{
+		SignatureRequest: &pb.SignatureRequest{
+			Contract:    req.Contract,
+			RollupIndex: req.RollupIndex,
+			Responses:   responses,
+		},
+	}}
+	if err := w.writeStream(s, m); err != nil {
+		w.log.Error("Failed to send signatures", append(logctx, "err", err)...)
+		return
+	}
+	w.log.Info("Sent signatures", append(logctx, "sents", len(responses))...)
+}
@@ -4,6 +4,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	relayproto "github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/proto"
@@ -28,15 +29,18 @@ type peerConn struct {
 }
 
 type peerStatus struct {
-	ID        string   `json:"id"`
-	Addresses []string `json:"addresses"`
-	Protocols []string `json:"protocols"`
+	ID        string    `json:"id"`
+	Addresses []string  `json:"addresses"`
+	Protocols []string  `json:"protocols"`
+	NodeInfo  *NodeInfo `json:"node_info,omitempty"`
 }
 
 type HostStatus struct {
 	*peerStatus
-	Connections []*peerConn   `json:"connections"`
-	Peers       []*peerStatus `json:"peers"`
+	Connections []*peerConn        `json:"connections"`
+	Peers       []*peerStatus      `json:"peers"`
+	ConnManager *connManagerStatus `json:"conn_manager"`
+	Resources   []*resourceStatus  `json:"resources"`
 }
 
 func NewHostStatus(h host.Host) (*HostStatus, error) {
@@ -100,6 +104,7 @@ func NewHostStatus(h host.Host) (*HostStatus, error) {
 			continue
 		}
 		pinfo := &peerStatus{ID: id.String()}
+		pinfo.NodeInfo, _ = PeerNodeInfo(h, id)
 		s.Peers = append(s.Peers, pinfo)
 
 		// set `Peers[].Addresses`
@@ -124,12 +129,18 @@ func NewHostStatus(h host.Host) (*HostStatus, error) {
 		return strings.Compare(s.Peers[i].ID, s.Peers[j].ID) == -1
 	})
 
+	// set `ConnManager` and `Resources`
+	s.ConnManager = newConnManagerStatus(h)
+	s.Resources = newResourceStatuses(h)
+
 	return s, nil
 }
 
 type networkStat struct {
+	// Note: The WebSocket and the WebTransport connections are
+	// also counted in `tcp` and `udp` respectively.
 	connections struct {
-		tcp, udp, relay int
+		tcp, udp, websocket, webtransport, relay int
 	}
 	streams struct {
 		hop, stop, verifier int
@@ -145,6 +156,12 @@ func newNetworkStatus(h host.Host) *networkStat {
 		} else if CheckAddressesProtocols(local, []int{ma.P_UDP}, nil) {
 			s.connections.udp++
 		}
+		if CheckAddressesProtocols(local, []int{ma.P_WS}, nil) ||
+			CheckAddressesProtocols(local, []int{ma.P_WSS}, nil) {
+			s.connections.websocket++
+		} else if CheckAddressesProtocols(local, []int{ma.P_WEBTRANSPORT}, nil) {
+			s.connections.webtransport++
+		}
 
 		remote := []ma.Multiaddr{conn.RemoteMultiaddr()}
 		if CheckAddressesProtocols(remote, []int{ma.P_CIRCUIT}, nil) {
@@ -157,10 +174,46 @@ func newNetworkStatus(h host.Host) *networkStat {
 				s.streams.hop++
 			case relayproto.ProtoIDv2Stop:
 				s.streams.stop++
-			case streamProtocol:
+			case streamProtocol, catchUpProtocol, handshakeProtocol, nodeInfoProtocol:
 				s.streams.verifier++
 			}
 		}
 	}
 	return &s
 }
+
+// Summary of the connected peer.
+type PeerSummary struct {
+	ID            string          `json:"id"`
+	Direction     string          `json:"direction"`
+	RemoteAddress string          `json:"remote_address"`
+	Signer        *common.Address `json:"signer"` // nil if the peer is not verified
+	NodeInfo      *NodeInfo       `json:"node_info,omitempty"`
+}
+
+// Returns the summaries of the connected peers, the verified peers
+// are used to fill the signer running on the peer.
+func NewPeerSummaries(h host.Host, verifiedPeers []*VerifiedPeer) []*PeerSummary {
+	signers := map[peer.ID]common.Address{}
+	for _, vp := range verifiedPeers {
+		signers[vp.PeerID] = vp.Signer
+	}
+
+	summaries := []*PeerSummary{}
+	for _, id := range h.Network().Peers() {
+		s := &PeerSummary{ID: id.String()}
+		if conns := h.Network().ConnsToPeer(id); len(conns) > 0 {
+			s.Direction = conns[0].Stat().Direction.String()
+			s.RemoteAddress = conns[0].RemoteMultiaddr().String()
+		}
+		if signer, ok := signers[id]; ok {
+			s.Signer = &signer
+		}
+		s.NodeInfo, _ = PeerNodeInfo(h, id)
+		summaries = append(summaries, s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return strings.Compare(summaries[i].ID, summaries[j].ID) == -1
+	})
+	return summaries
+}
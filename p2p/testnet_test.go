@@ -0,0 +1,336 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/equivocation"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	pb "github.com/oasysgames/oasys-optimism-verifier/proto/p2p/v1/gen"
+	"github.com/oasysgames/oasys-optimism-verifier/testhelper"
+	"github.com/oasysgames/oasys-optimism-verifier/testhelper/backend"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+	"github.com/oasysgames/oasys-optimism-verifier/verse"
+	"github.com/stretchr/testify/require"
+)
+
+const testNetworkChainID = 12345
+
+// In-memory network of the nodes connected via libp2p mocknet, used to
+// test the propagation of the signatures across many nodes. Each node
+// has its own database, and the verifiers are staked validators.
+type testNetwork struct {
+	tb       testing.TB
+	mn       mocknet.Mocknet
+	backend  *backend.SignableBackend
+	contract common.Address
+
+	verifiers  []*testNetworkNode
+	submitters []*testNetworkNode
+
+	stakemanager *stakemanager.Cache
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+type testNetworkNode struct {
+	*Node
+	backend *backend.SignableBackend // nil if not a verifier
+}
+
+// Create the network of `verifiers` and `submitters` nodes connected to each
+// other, and wait for the submitters to join the mesh of the verse topic.
+// The `configure` can be used to change the p2p config of all nodes.
+func newTestNetwork(tb testing.TB, verifiers, submitters int, configure func(*config.P2P)) *testNetwork {
+	n := &testNetwork{
+		tb:       tb,
+		mn:       mocknet.New(),
+		backend:  backend.NewSignableBackend(nil, nil),
+		contract: common.BytesToAddress(crypto.Keccak256([]byte("testNetwork"))[:20]),
+	}
+
+	// verifiers have enough stakes
+	sm := &testhelper.StakeManagerMock{}
+	n.stakemanager = stakemanager.NewCache(sm, time.Hour)
+	backends := make([]*backend.SignableBackend, verifiers)
+	for i := range backends {
+		backends[i] = n.backend.WithNewAccount()
+		sm.Owners = append(sm.Owners, common.Address{})
+		sm.Operators = append(sm.Operators, backends[i].Signer())
+		sm.Stakes = append(sm.Stakes, ethutil.TenMillionOAS)
+		sm.Candidates = append(sm.Candidates, true)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n.cancel = cancel
+	tb.Cleanup(n.close)
+
+	for _, b := range backends {
+		n.verifiers = append(n.verifiers, n.newNode(ctx, b, configure))
+	}
+	for i := 0; i < submitters; i++ {
+		n.submitters = append(n.submitters, n.newNode(ctx, nil, configure))
+	}
+
+	require.NoError(tb, n.mn.LinkAll())
+	require.NoError(tb, n.mn.ConnectAllButSelf())
+	n.waitForMesh()
+	return n
+}
+
+func (n *testNetwork) newNode(
+	ctx context.Context,
+	b *backend.SignableBackend,
+	configure func(*config.P2P),
+) *testNetworkNode {
+	db, err := database.NewDatabase(&config.Database{Path: ":memory:"})
+	require.NoError(n.tb, err)
+
+	h, err := n.mn.GenPeer()
+	require.NoError(n.tb, err)
+
+	cfg := &config.P2P{
+		StreamTimeout: 3 * time.Second,
+		LegacyTopic:   true,
+	}
+	cfg.OutboundLimits.Concurrency = 10
+	cfg.OutboundLimits.Throttling = 500
+	cfg.InboundLimits.Concurrency = 10
+	cfg.InboundLimits.Throttling = 1000
+	cfg.InboundLimits.MaxSendTime = 5 * time.Second
+	cfg.InboundLimits.PeerThrottling = 500
+	cfg.CatchUp.MaxSignatures = 1000
+	cfg.CatchUp.Peers = 3
+	cfg.Misbehaviour.Threshold = 100
+	cfg.Misbehaviour.BlockDuration = time.Minute
+	cfg.Misbehaviour.ScoreDecay = time.Minute
+	if configure != nil {
+		configure(cfg)
+	}
+
+	// only the submitters receive the signatures of the verse
+	var (
+		signer        Signer
+		ignoreSigners []common.Address
+	)
+	if b != nil {
+		signer = &testSigner{b}
+		ignoreSigners = append(ignoreSigners, b.Signer())
+	}
+	versepool := verse.NewVersePool(n.backend)
+	versepool.Add(verse.NewOPLegacy(db, n.backend, testNetworkChainID,
+		"http://rpc.example.com", n.contract, common.Address{}), b == nil)
+
+	node, err := NewNode(cfg, db, h, nil, nil, NewHolePunchHelper(false),
+		n.backend.ChainID().Uint64(), ignoreSigners, n.stakemanager, versepool,
+		equivocation.NewDetector(db, n.stakemanager, versepool), signer, NewBlocklist())
+	require.NoError(n.tb, err)
+
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		node.Start(ctx, b == nil)
+	}()
+	return &testNetworkNode{Node: node, backend: b}
+}
+
+func (n *testNetwork) close() {
+	n.cancel()
+	n.wg.Wait()
+	n.mn.Close()
+}
+
+func (n *testNetwork) nodes() []*testNetworkNode {
+	return append(append([]*testNetworkNode{}, n.verifiers...), n.submitters...)
+}
+
+// Wait until the pubsub of each node follows the connections, that is, it knows
+// all connected submitters subscribing to the verse topic and no others.
+func (n *testNetwork) waitForMesh() {
+	for _, x := range n.nodes() {
+		topic, err := x.joinTopic(verseTopic(testNetworkChainID))
+		require.NoError(n.tb, err)
+
+		require.Eventually(n.tb, func() bool {
+			peers := map[peer.ID]bool{}
+			for _, id := range topic.ListPeers() {
+				peers[id] = true
+			}
+			for _, y := range n.submitters {
+				if y == x {
+					continue
+				}
+				connected := x.h.Network().Connectedness(y.h.ID()) == network.Connected
+				if peers[y.h.ID()] != connected {
+					return false
+				}
+			}
+			return true
+		}, 10*time.Second, 50*time.Millisecond)
+	}
+}
+
+// Cut the links between the two groups of the nodes.
+func (n *testNetwork) partition(a, b []*testNetworkNode) {
+	for _, x := range a {
+		for _, y := range b {
+			require.NoError(n.tb, n.mn.UnlinkPeers(x.h.ID(), y.h.ID()))
+			require.NoError(n.tb, n.mn.DisconnectPeers(x.h.ID(), y.h.ID()))
+		}
+	}
+}
+
+// Restore all links and connections, and wait for the mesh to be formed again.
+func (n *testNetwork) heal() {
+	nodes := n.nodes()
+	for i, x := range nodes {
+		for _, y := range nodes[i+1:] {
+			if len(n.mn.LinksBetweenPeers(x.h.ID(), y.h.ID())) == 0 {
+				_, err := n.mn.LinkPeers(x.h.ID(), y.h.ID())
+				require.NoError(n.tb, err)
+			}
+		}
+	}
+	require.NoError(n.tb, n.mn.ConnectAllButSelf())
+	n.waitForMesh()
+}
+
+// Create the signatures of the rollup indexes [from, to) by the verifier.
+func (n *testNetwork) sign(v *testNetworkNode, from, to uint64) []*database.OptimismSignature {
+	sigs := make([]*database.OptimismSignature, 0, to-from)
+	for index := from; index < to; index++ {
+		rollupIndex := new(big.Int).SetUint64(index)
+		rollupHash := crypto.Keccak256Hash(n.contract[:], rollupIndex.Bytes())
+
+		msg := ethutil.NewMessage(n.backend.ChainID(), n.contract,
+			rollupIndex, util.BytesToBytes32(rollupHash[:]), true)
+		sigbin, err := msg.Signature(v.backend.SignData)
+		require.NoError(n.tb, err)
+
+		sig, err := v.db.OPSignature.Save(nil, nil, v.backend.Signer(),
+			n.contract, index, rollupHash, true, sigbin)
+		require.NoError(n.tb, err)
+		sigs = append(sigs, sig)
+	}
+	return sigs
+}
+
+// Sign and publish the signatures of the rollup indexes [from, to) by all verifiers.
+func (n *testNetwork) publish(ctx context.Context, from, to uint64) []*database.OptimismSignature {
+	var all []*database.OptimismSignature
+	for _, v := range n.verifiers {
+		sigs := n.sign(v, from, to)
+		require.NoError(n.tb, v.PublishSignatures(ctx, sigs))
+		all = append(all, sigs...)
+	}
+	return all
+}
+
+// Request the signatures of the verse from all connected peers,
+// in the same way as the periodic catch-up of the submitter.
+func (n *testNetwork) catchUp(ctx context.Context, s *testNetworkNode, fromIndex uint64) {
+	for _, id := range s.catchUpSupportedPeers() {
+		s.requestSignatureCatchUp(ctx, id, []*pb.SignatureCatchUp_Request{
+			{Contract: n.contract[:], FromIndex: fromIndex},
+		})
+	}
+}
+
+// Returns the number of the signatures saved in the database of the node.
+func (n *testNetwork) countSignatures(node *testNetworkNode, sigs []*database.OptimismSignature) (count int) {
+	for _, sig := range sigs {
+		if _, err := node.db.OPSignature.FindByID(sig.ID); err == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// Assert that all submitters receive the signatures within the timeout.
+func (n *testNetwork) requireConverged(
+	submitters []*testNetworkNode,
+	sigs []*database.OptimismSignature,
+	timeout time.Duration,
+) {
+	for _, s := range submitters {
+		require.Eventually(n.tb, func() bool {
+			return n.countSignatures(s, sigs) == len(sigs)
+		}, timeout, 50*time.Millisecond, "submitter %s has not converged", s.h.ID())
+	}
+}
+
+func TestSignaturePropagation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	n := newTestNetwork(t, 3, 3, nil)
+	s0, s1, s2 := n.submitters[0], n.submitters[1], n.submitters[2]
+
+	// gossip to all submitters
+	sigs := n.publish(ctx, 0, 10)
+	n.requireConverged(n.submitters, sigs, 10*time.Second)
+	for _, v := range n.verifiers {
+		require.Equal(t, 10, n.countSignatures(v, sigs))
+	}
+
+	// relayed by the other submitters
+	n.partition(n.verifiers, []*testNetworkNode{s2})
+	n.waitForMesh()
+	sigs = n.publish(ctx, 10, 20)
+	n.requireConverged(n.submitters, sigs, 10*time.Second)
+
+	// isolated submitter misses the signatures
+	n.partition(n.nodes()[:len(n.nodes())-1], []*testNetworkNode{s2})
+	n.waitForMesh()
+	missed := n.publish(ctx, 20, 30)
+	n.requireConverged([]*testNetworkNode{s0, s1}, missed, 10*time.Second)
+	require.Never(t, func() bool {
+		return n.countSignatures(s2, missed) > 0
+	}, time.Second, 100*time.Millisecond)
+
+	// the isolated submitter catches up the missed signatures after healing
+	n.heal()
+	sigs = n.publish(ctx, 30, 40)
+	n.requireConverged([]*testNetworkNode{s0, s1}, sigs, 10*time.Second)
+	n.catchUp(ctx, s2, 20)
+	n.requireConverged(n.submitters, append(missed, sigs...), 10*time.Second)
+}
+
+// Measures the throughput of the signature catch-up under the inbound limits.
+func BenchmarkSignatureCatchUp(b *testing.B) {
+	for _, throttling := range []int{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("throttling=%d", throttling), func(b *testing.B) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			n := newTestNetwork(b, 1, 1, func(cfg *config.P2P) {
+				cfg.OutboundLimits.Throttling = throttling
+				cfg.InboundLimits.Throttling = throttling
+				cfg.InboundLimits.PeerThrottling = throttling
+				cfg.InboundLimits.MaxSendTime = time.Hour
+				cfg.CatchUp.MaxSignatures = b.N
+			})
+			v, s := n.verifiers[0], n.submitters[0]
+			sigs := n.sign(v, 0, uint64(b.N))
+
+			b.ResetTimer()
+			n.catchUp(ctx, s, 0)
+			b.StopTimer()
+
+			require.Equal(b, b.N, n.countSignatures(s, sigs))
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "sigs/s")
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	ps "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/oasysgames/oasys-optimism-verifier/verse"
+)
+
+// Interval to follow the changes of the verses submitted by oneself.
+var verseTopicSyncInterval = 15 * time.Second
+
+// Returns the pubsub topic of the verse.
+func verseTopic(chainID uint64) string {
+	return fmt.Sprintf("/oasys-optimism-verifier/pubsub/verse/%d/1.0.0", chainID)
+}
+
+// Returns the chain id of the verse topic, or false if not a verse topic.
+func parseVerseTopic(name string) (chainID uint64, ok bool) {
+	n, err := fmt.Sscanf(name, "/oasys-optimism-verifier/pubsub/verse/%d/1.0.0", &chainID)
+	return chainID, err == nil && n == 1 && name == verseTopic(chainID)
+}
+
+// Join the topic. The validator and the peer score parameters are set on the first join.
+func (w *Node) joinTopic(name string) (*ps.Topic, error) {
+	w.topicsMu.Lock()
+	defer w.topicsMu.Unlock()
+
+	if topic, ok := w.topics[name]; ok {
+		return topic, nil
+	}
+
+	// Validate messages before they are propagated.
+	if err := w.pubsub.RegisterTopicValidator(name, w.validatePubSub); err != nil {
+		return nil, fmt.Errorf("failed to register topic validator: %w", err)
+	}
+
+	topic, err := w.pubsub.Join(name)
+	if err != nil {
+		w.pubsub.UnregisterTopicValidator(name)
+		return nil, fmt.Errorf("failed to join the topic: %w", err)
+	}
+
+	if w.cfg.PeerScore.Enable {
+		if err := topic.SetScoreParams(newTopicScoreParams(w.cfg)); err != nil {
+			topic.Close()
+			w.pubsub.UnregisterTopicValidator(name)
+			return nil, fmt.Errorf("failed to set topic score params: %w", err)
+		}
+	}
+
+	w.topics[name] = topic
+	return topic, nil
+}
+
+// Subscribe to the topics of the verses submitted by oneself,
+// and unsubscribe from the topics of the verses no longer submitted.
+func (w *Node) syncVerseSubscriptions(ctx context.Context, wg *sync.WaitGroup) {
+	targets := map[uint64]bool{}
+	w.versepool.Range(func(item *verse.VersePoolItem) bool {
+		if item.CanSubmit() {
+			targets[item.Verse().ChainID()] = true
+		}
+		return true
+	})
+
+	for chainID := range targets {
+		if w.verseSubscription(chainID) != nil {
+			continue
+		}
+
+		topic, err := w.joinTopic(verseTopic(chainID))
+		if err != nil {
+			w.log.Error("Failed to join the verse topic", "chain-id", chainID, "err", err)
+			continue
+		}
+		sub, err := topic.Subscribe()
+		if err != nil {
+			w.log.Error("Failed to subscribe the verse topic", "chain-id", chainID, "err", err)
+			continue
+		}
+
+		w.topicsMu.Lock()
+		w.verseSubs[chainID] = sub
+		w.topicsMu.Unlock()
+		w.log.Info("Subscribed to the verse topic", "chain-id", chainID)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.subscribeLoop(ctx, sub)
+		}()
+	}
+
+	w.topicsMu.Lock()
+	defer w.topicsMu.Unlock()
+	for chainID, sub := range w.verseSubs {
+		if !targets[chainID] {
+			sub.Cancel()
+			delete(w.verseSubs, chainID)
+			w.log.Info("Unsubscribed from the verse topic", "chain-id", chainID)
+		}
+	}
+}
+
+func (w *Node) verseSubscription(chainID uint64) *ps.Subscription {
+	w.topicsMu.Lock()
+	defer w.topicsMu.Unlock()
+	return w.verseSubs[chainID]
+}
+
+// Cancel all subscriptions and leave all topics.
+func (w *Node) closeTopics() {
+	w.topicsMu.Lock()
+	defer w.topicsMu.Unlock()
+
+	for chainID, sub := range w.verseSubs {
+		sub.Cancel()
+		delete(w.verseSubs, chainID)
+	}
+	if w.sub != nil {
+		w.sub.Cancel()
+	}
+	for name, topic := range w.topics {
+		topic.Close()
+		delete(w.topics, name)
+	}
+}
@@ -0,0 +1,28 @@
+package p2p
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/p2p/transport/websocket"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+)
+
+// Returns the option to enable the WebSocket transport. If the certificate
+// is configured, the `/tls/ws` addresses accept the secure WebSocket(WSS).
+func webSocketTransportOpt(cfg *config.P2P) (libp2p.Option, error) {
+	ws := cfg.Transports.WebSocket
+	if ws.CertFile == "" && ws.KeyFile == "" {
+		return libp2p.Transport(websocket.New), nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(ws.CertFile, ws.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load websocket certificate: %w", err)
+	}
+	return libp2p.Transport(websocket.New, websocket.WithTLSConfig(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	})), nil
+}
@@ -0,0 +1,187 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ps "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	pb "github.com/oasysgames/oasys-optimism-verifier/proto/p2p/v1/gen"
+	"github.com/oklog/ulid/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// Validate pubsub messages before they are delivered to the subscriber and
+// forwarded to other peers. Messages containing a forged or malformed signature
+// are rejected so that the sender is penalized by the peer scoring. Messages
+// containing a signature that may be valid from another point of view (e.g. stake
+// amount or clock) are ignored as a whole, since gossipsub forwards the original
+// message. The decoded signatures are passed to the subscriber as the validator data.
+func (w *Node) validatePubSub(ctx context.Context, sender peer.ID, msg *ps.Message) ps.ValidationResult {
+	if sender == w.h.ID() {
+		return ps.ValidationAccept
+	}
+
+	data, err := decompress(msg.Data)
+	if err != nil {
+		w.log.Debug("Failed to decompress pubsub message", "peer", sender, "err", err)
+		w.meterPubsubRejected.Incr()
+		return ps.ValidationReject
+	}
+
+	var m pb.PubSub
+	if err := proto.Unmarshal(data, &m); err != nil {
+		w.log.Debug("Failed to unmarshal pubsub message", "peer", sender, "err", err)
+		w.meterPubsubRejected.Incr()
+		return ps.ValidationReject
+	}
+
+	t := m.GetOptimismSignatureExchange()
+	if t == nil {
+		// may be a message of the newer version
+		w.meterPubsubIgnored.Incr()
+		return ps.ValidationIgnore
+	}
+
+	chainID, isVerseTopic := parseVerseTopic(msg.GetTopic())
+
+	var ignored bool
+	for _, sig := range t.Latests {
+		res, err := validateSignature(ctx, w.hubLayerChainID, w.stakemanager, sig)
+		if res == ps.ValidationAccept && isVerseTopic {
+			res, err = w.validateSignatureChain(chainID, sig)
+		}
+
+		switch res {
+		case ps.ValidationReject:
+			w.log.Warn("Rejected pubsub message", "peer", sender,
+				"signer", common.BytesToAddress(sig.Signer), "id", sig.Id, "err", err)
+			w.meterPubsubRejected.Incr()
+			return ps.ValidationReject
+		case ps.ValidationIgnore:
+			w.log.Debug("Ignored pubsub signature", "peer", sender,
+				"signer", common.BytesToAddress(sig.Signer), "id", sig.Id, "err", err)
+			ignored = true
+		}
+	}
+	if ignored || len(t.Latests) == 0 {
+		w.meterPubsubIgnored.Incr()
+		return ps.ValidationIgnore
+	}
+
+	msg.ValidatorData = t.Latests
+	return ps.ValidationAccept
+}
+
+// Reject the signature whose rollup contract belongs to another chain than the topic.
+func (w *Node) validateSignatureChain(chainID uint64, sig *pb.OptimismSignature) (ps.ValidationResult, error) {
+	item, ok := w.versepool.Get(common.BytesToAddress(sig.Contract))
+	if !ok {
+		// unknown verse, cannot be checked
+		return ps.ValidationAccept, nil
+	} else if item.Verse().ChainID() != chainID {
+		return ps.ValidationReject, fmt.Errorf("chain id mismatch, topic: %d, contract: %d",
+			chainID, item.Verse().ChainID())
+	}
+	return ps.ValidationAccept, nil
+}
+
+type stakeBySigner interface {
+	StakeBySigner(ctx context.Context, signer common.Address) *big.Int
+}
+
+func validateSignature(
+	ctx context.Context,
+	hubLayerChainID *big.Int,
+	stakemanager stakeBySigner,
+	sig *pb.OptimismSignature,
+) (ps.ValidationResult, error) {
+	if res, err := verifySignature(hubLayerChainID, sig); res != ps.ValidationAccept {
+		return res, err
+	}
+
+	// Signatures only from signers with stake >= validator candidate minimum.
+	if stakemanager.StakeBySigner(ctx, common.BytesToAddress(sig.Signer)).Cmp(ethutil.TenMillionOAS) == -1 {
+		return ps.ValidationIgnore, fmt.Errorf("stake amount shortage")
+	}
+	return ps.ValidationAccept, nil
+}
+
+// Verify the format, the ulid and the signer of the signature.
+func verifySignature(hubLayerChainID *big.Int, sig *pb.OptimismSignature) (ps.ValidationResult, error) {
+	if len(sig.Signer) != common.AddressLength || len(sig.Contract) != common.AddressLength ||
+		len(sig.RollupHash) != common.HashLength {
+		return ps.ValidationReject, fmt.Errorf("malformed signature")
+	}
+
+	id, err := ulid.ParseStrict(sig.Id)
+	if err != nil {
+		return ps.ValidationReject, err
+	} else if id.Time() > uint64(time.Now().UnixMilli()) {
+		// may be caused by the clock of either side
+		return ps.ValidationIgnore, fmt.Errorf("future ulid: %s, timestamp: %d", sig.Id, id.Time())
+	}
+
+	signer := common.BytesToAddress(sig.Signer)
+	if err := ethutil.VerifySignature(
+		hubLayerChainID,
+		common.BytesToAddress(sig.Contract),
+		new(big.Int).SetUint64(sig.RollupIndex),
+		common.BytesToHash(sig.RollupHash),
+		sig.Approved,
+		sig.Signature,
+		signer,
+	); err != nil {
+		return ps.ValidationReject, err
+	}
+	return ps.ValidationAccept, nil
+}
+
+// Returns the gossipsub peer score parameters. The parameters
+// of each topic are set when joining the topic.
+func newPeerScoreParams(cfg *config.P2P) (*ps.PeerScoreParams, *ps.PeerScoreThresholds) {
+	params := &ps.PeerScoreParams{
+		SkipAtomicValidation: true,
+		Topics:               map[string]*ps.TopicScoreParams{},
+		AppSpecificScore:     func(peer.ID) float64 { return 0 },
+
+		IPColocationFactorWeight:    cfg.PeerScore.IPColocationWeight,
+		IPColocationFactorThreshold: cfg.PeerScore.IPColocationThreshold,
+
+		BehaviourPenaltyWeight: cfg.PeerScore.BehaviourPenaltyWeight,
+		BehaviourPenaltyDecay:  ps.ScoreParameterDecay(cfg.PeerScore.BehaviourPenaltyDecay),
+
+		DecayInterval: ps.DefaultDecayInterval,
+		DecayToZero:   ps.DefaultDecayToZero,
+		RetainScore:   time.Hour,
+	}
+	thresholds := &ps.PeerScoreThresholds{
+		SkipAtomicValidation: true,
+		GossipThreshold:      cfg.PeerScore.GossipThreshold,
+		PublishThreshold:     cfg.PeerScore.PublishThreshold,
+		GraylistThreshold:    cfg.PeerScore.GraylistThreshold,
+	}
+	return params, thresholds
+}
+
+// Returns the gossipsub peer score parameters for a topic.
+func newTopicScoreParams(cfg *config.P2P) *ps.TopicScoreParams {
+	return &ps.TopicScoreParams{
+		SkipAtomicValidation: true,
+		TopicWeight:          1,
+
+		// reward for delivering new signatures first
+		FirstMessageDeliveriesWeight: 1,
+		FirstMessageDeliveriesDecay:  ps.ScoreParameterDecay(time.Hour),
+		FirstMessageDeliveriesCap:    100,
+
+		// penalty for delivering invalid signatures
+		InvalidMessageDeliveriesWeight: cfg.PeerScore.InvalidMessageWeight,
+		InvalidMessageDeliveriesDecay:  ps.ScoreParameterDecay(cfg.PeerScore.InvalidMessageDecay),
+	}
+}
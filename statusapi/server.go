@@ -0,0 +1,229 @@
+package statusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/debug"
+	"github.com/oasysgames/oasys-optimism-verifier/p2p"
+	"github.com/oasysgames/oasys-optimism-verifier/submitter"
+	"github.com/oasysgames/oasys-optimism-verifier/verse"
+)
+
+type signatureJSON struct {
+	ID          string         `json:"id"`
+	Signer      common.Address `json:"signer"`
+	Contract    common.Address `json:"contract"`
+	RollupIndex uint64         `json:"rollup_index"`
+	RollupHash  common.Hash    `json:"rollup_hash"`
+	Approved    bool           `json:"approved"`
+}
+
+type verseJSON struct {
+	ChainID           uint64         `json:"chain_id"`
+	Contract          common.Address `json:"contract"`
+	CanSubmit         bool           `json:"can_submit"`
+	NextIndex         *uint64        `json:"next_index"`
+	LastVerifiedIndex *uint64        `json:"last_verified_index"`
+	LastSigned        *signatureJSON `json:"last_signed"`
+	Error             string         `json:"error,omitempty"`
+}
+
+type submissionJSON struct {
+	TxHash     common.Hash    `json:"tx_hash"`
+	Contract   common.Address `json:"contract"`
+	FirstIndex uint64         `json:"first_index"`
+	LastIndex  uint64         `json:"last_index"`
+	Status     uint64         `json:"status"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type targetJSON struct {
+	*submitter.TargetStatus
+	LastSubmission *submissionJSON `json:"last_submission"`
+}
+
+type peersJSON struct {
+	Connected int                `json:"connected"`
+	Verified  int                `json:"verified"`
+	Blocked   int                `json:"blocked"`
+	Peers     []*p2p.PeerSummary `json:"peers"`
+}
+
+type StatusServer struct {
+	cfg           *config.StatusAPI
+	mux           *http.ServeMux
+	log           log.Logger
+	db            *database.Database
+	versepool     verse.VersePool
+	confirmations func() int
+	verifier      *common.Address                  // nil if the verifier is disabled
+	targets       func() []*submitter.TargetStatus // nil if the submitter is disabled
+	peers         func() []*p2p.PeerSummary
+	blockedPeers  func() []*p2p.BlockedPeer
+}
+
+func NewStatusServer(
+	cfg *config.StatusAPI,
+	db *database.Database,
+	versepool verse.VersePool,
+	confirmations func() int,
+	verifier *common.Address,
+	targets func() []*submitter.TargetStatus,
+	peers func() []*p2p.PeerSummary,
+	blockedPeers func() []*p2p.BlockedPeer,
+) (*StatusServer, *http.Server) {
+	s := &StatusServer{
+		cfg:           cfg,
+		mux:           http.NewServeMux(),
+		log:           log.New("worker", "status-api"),
+		db:            db,
+		versepool:     versepool,
+		confirmations: confirmations,
+		verifier:      verifier,
+		targets:       targets,
+		peers:         peers,
+		blockedPeers:  blockedPeers,
+	}
+
+	auth := debug.WrapBasicAuth(cfg.BasicAuth.Username, cfg.BasicAuth.Password)
+	s.mux.HandleFunc("GET /status/verses", auth(s.handleVerses))
+	s.mux.HandleFunc("GET /status/signers", auth(s.handleSigners))
+	s.mux.HandleFunc("GET /status/submitters", auth(s.handleSubmitters))
+	s.mux.HandleFunc("GET /status/peers", auth(s.handlePeers))
+
+	return s, &http.Server{Addr: cfg.Listen, Handler: s.mux}
+}
+
+func (w *StatusServer) ListenAndServe(ctx context.Context, ssvr *http.Server) error {
+	w.log.Info("Started status api server",
+		"listen", w.cfg.Listen,
+		"username", w.cfg.BasicAuth.Username)
+	return ssvr.ListenAndServe()
+}
+
+func (w *StatusServer) handleVerses(rw http.ResponseWriter, r *http.Request) {
+	verses := []*verseJSON{}
+	w.versepool.Range(func(item *verse.VersePoolItem) bool {
+		verses = append(verses, &verseJSON{
+			ChainID:   item.Verse().ChainID(),
+			Contract:  item.Verse().RollupContract(),
+			CanSubmit: item.CanSubmit(),
+		})
+		return true
+	})
+	sort.Slice(verses, func(i, j int) bool { return verses[i].ChainID < verses[j].ChainID })
+
+	for _, v := range verses {
+		// Note: does not wait for the confirmations to avoid blocking the request
+		nextIndex, err := w.versepool.NextIndex(r.Context(), v.Contract, w.confirmations(), false)
+		if err != nil {
+			v.Error = err.Error()
+		} else {
+			v.NextIndex = &nextIndex
+			if nextIndex > 0 {
+				lastVerified := nextIndex - 1
+				v.LastVerifiedIndex = &lastVerified
+			}
+		}
+
+		if w.verifier == nil {
+			continue
+		}
+		sig, err := w.db.OPSignature.FindLatestBySignerAndContract(*w.verifier, v.Contract)
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		} else if err != nil {
+			w.writeError(rw, err)
+			return
+		}
+		v.LastSigned = newSignatureJSON(sig)
+	}
+
+	w.writeJSON(rw, verses)
+}
+
+func (w *StatusServer) handleSigners(rw http.ResponseWriter, r *http.Request) {
+	latests, err := w.db.OPSignature.FindLatestsPerSigners()
+	if err != nil {
+		w.writeError(rw, err)
+		return
+	}
+
+	sigs := make([]*signatureJSON, len(latests))
+	for i, sig := range latests {
+		sigs[i] = newSignatureJSON(sig)
+	}
+	w.writeJSON(rw, sigs)
+}
+
+func (w *StatusServer) handleSubmitters(rw http.ResponseWriter, r *http.Request) {
+	targets := []*targetJSON{}
+	if w.targets == nil {
+		w.writeJSON(rw, targets)
+		return
+	}
+
+	for _, st := range w.targets() {
+		target := &targetJSON{TargetStatus: st}
+		rows, err := w.db.Submission.Find(&st.ChainID, time.Time{}, 1, 0)
+		if err != nil {
+			w.writeError(rw, err)
+			return
+		} else if len(rows) > 0 {
+			target.LastSubmission = &submissionJSON{
+				TxHash:     rows[0].TxHash,
+				Contract:   rows[0].Contract.Address,
+				FirstIndex: rows[0].FirstIndex,
+				LastIndex:  rows[0].LastIndex,
+				Status:     rows[0].Status,
+				CreatedAt:  rows[0].CreatedAt,
+			}
+		}
+		targets = append(targets, target)
+	}
+	w.writeJSON(rw, targets)
+}
+
+func (w *StatusServer) handlePeers(rw http.ResponseWriter, r *http.Request) {
+	peers := &peersJSON{Peers: w.peers()}
+	peers.Connected = len(peers.Peers)
+	for _, p := range peers.Peers {
+		if p.Signer != nil {
+			peers.Verified++
+		}
+	}
+	peers.Blocked = len(w.blockedPeers())
+	w.writeJSON(rw, peers)
+}
+
+func (w *StatusServer) writeJSON(rw http.ResponseWriter, v any) {
+	rw.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(rw).Encode(v); err != nil {
+		w.log.Warn("Failed to write response", "err", err)
+	}
+}
+
+func (w *StatusServer) writeError(rw http.ResponseWriter, err error) {
+	w.log.Error("Failed to handle the request", "err", err)
+	http.Error(rw, "Internal Server Error", http.StatusInternalServerError)
+}
+
+func newSignatureJSON(sig *database.OptimismSignature) *signatureJSON {
+	return &signatureJSON{
+		ID:          sig.ID,
+		Signer:      sig.Signer.Address,
+		Contract:    sig.Contract.Address,
+		RollupIndex: sig.RollupIndex,
+		RollupHash:  sig.RollupHash,
+		Approved:    sig.Approved,
+	}
+}
@@ -0,0 +1,140 @@
+package statusapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/p2p"
+	"github.com/oasysgames/oasys-optimism-verifier/submitter"
+	"github.com/oasysgames/oasys-optimism-verifier/testhelper/backend"
+	"github.com/oasysgames/oasys-optimism-verifier/verse"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusServerTestSuite struct {
+	backend.BackendSuite
+
+	verifier common.Address
+	cfg      *config.StatusAPI
+	svr      *httptest.Server
+}
+
+func TestStatusServer(t *testing.T) {
+	suite.Run(t, new(StatusServerTestSuite))
+}
+
+func (s *StatusServerTestSuite) SetupTest() {
+	s.BackendSuite.SetupTest()
+
+	versepool := verse.NewVersePool(s.Hub)
+	versepool.Add(verse.NewOPLegacy(s.DB, s.Hub, 1, s.Hub.URL(), s.SCCAddr, s.SCCVAddr), false)
+	versepool.Add(verse.NewOPStack(s.DB, s.Hub, 2, s.Hub.URL(), s.L2OOAddr, s.L2OOVAddr), true)
+
+	s.verifier = s.RandAddress()
+	s.cfg = &config.StatusAPI{}
+	s.cfg.BasicAuth.Username = "username"
+	s.cfg.BasicAuth.Password = "password"
+
+	targets := func() []*submitter.TargetStatus {
+		return []*submitter.TargetStatus{{ChainID: 2, Verses: []*submitter.VerseStatus{}}}
+	}
+	peers := func() []*p2p.PeerSummary {
+		return []*p2p.PeerSummary{{ID: "peer0", Signer: &s.verifier}, {ID: "peer1"}}
+	}
+	blockedPeers := func() []*p2p.BlockedPeer { return []*p2p.BlockedPeer{{}} }
+
+	ss, _ := NewStatusServer(s.cfg, s.DB, versepool, func() int { return 0 }, &s.verifier, targets, peers, blockedPeers)
+	s.svr = httptest.NewServer(ss.mux)
+	s.T().Cleanup(s.svr.Close)
+}
+
+func (s *StatusServerTestSuite) get(path string, v any) int {
+	req, _ := http.NewRequest(http.MethodGet, s.svr.URL+path, nil)
+	req.SetBasicAuth(s.cfg.BasicAuth.Username, s.cfg.BasicAuth.Password)
+	res, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		s.Require().NoError(json.NewDecoder(res.Body).Decode(v))
+	}
+	return res.StatusCode
+}
+
+func (s *StatusServerTestSuite) TestBasicAuth() {
+	res, err := http.Get(s.svr.URL + "/status/verses")
+	s.Require().NoError(err)
+	res.Body.Close()
+	s.Equal(http.StatusUnauthorized, res.StatusCode)
+}
+
+func (s *StatusServerTestSuite) TestVerses() {
+	for i := range s.Range(0, 3) {
+		s.DB.OPSignature.Save(nil, nil, s.verifier, s.SCCAddr,
+			uint64(i), s.ItoHash(i), i != 2, database.RandSignature())
+	}
+
+	var got []*verseJSON
+	s.Equal(http.StatusOK, s.get("/status/verses", &got))
+	s.Len(got, 2)
+
+	s.Equal(uint64(1), got[0].ChainID)
+	s.Equal(s.SCCAddr, got[0].Contract)
+	s.False(got[0].CanSubmit)
+	s.Equal(uint64(0), *got[0].NextIndex)
+	s.Nil(got[0].LastVerifiedIndex)
+	s.Equal(uint64(2), got[0].LastSigned.RollupIndex)
+	s.False(got[0].LastSigned.Approved)
+
+	s.Equal(uint64(2), got[1].ChainID)
+	s.Equal(s.L2OOAddr, got[1].Contract)
+	s.True(got[1].CanSubmit)
+	s.Nil(got[1].LastSigned)
+}
+
+func (s *StatusServerTestSuite) TestSigners() {
+	signers := []common.Address{s.RandAddress(), s.RandAddress()}
+	for i, signer := range signers {
+		for j := range s.Range(0, i+2) {
+			s.DB.OPSignature.Save(nil, nil, signer, s.SCCAddr,
+				uint64(j), s.ItoHash(j), true, database.RandSignature())
+		}
+	}
+
+	var got []*signatureJSON
+	s.Equal(http.StatusOK, s.get("/status/signers", &got))
+	s.Len(got, 2)
+	for _, sig := range got {
+		if sig.Signer == signers[0] {
+			s.Equal(uint64(1), sig.RollupIndex)
+		} else {
+			s.Equal(signers[1], sig.Signer)
+			s.Equal(uint64(2), sig.RollupIndex)
+		}
+	}
+}
+
+func (s *StatusServerTestSuite) TestSubmitters() {
+	var got []*struct {
+		ChainID        uint64          `json:"chain_id"`
+		LastSubmission *submissionJSON `json:"last_submission"`
+	}
+	s.Equal(http.StatusOK, s.get("/status/submitters", &got))
+	s.Len(got, 1)
+	s.Equal(uint64(2), got[0].ChainID)
+	s.Nil(got[0].LastSubmission)
+}
+
+func (s *StatusServerTestSuite) TestPeers() {
+	var got peersJSON
+	s.Equal(http.StatusOK, s.get("/status/peers", &got))
+	s.Equal(2, got.Connected)
+	s.Equal(1, got.Verified)
+	s.Equal(1, got.Blocked)
+	s.Len(got.Peers, 2)
+}
@@ -0,0 +1,234 @@
+package submitter
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/contract/multicall2"
+	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	"github.com/oasysgames/oasys-optimism-verifier/verse"
+	"github.com/oklog/ulid/v2"
+	"golang.org/x/net/context"
+)
+
+var (
+	ErrBundleChainMismatch = errors.New("hub-layer chain id mismatch")
+)
+
+// Signatures and calldata that can be used to verify
+// a rollup without running the submitter.
+type Bundle struct {
+	HubLayerChainID   uint64         `json:"hub_layer_chain_id"`
+	ChainID           uint64         `json:"chain_id"`
+	RollupContract    common.Address `json:"rollup_contract"`
+	VerifyContract    common.Address `json:"verify_contract"`
+	MulticallContract common.Address `json:"multicall_contract"`
+	RollupIndex       uint64         `json:"rollup_index"`
+	RollupHash        common.Hash    `json:"rollup_hash"`
+	Approved          bool           `json:"approved"`
+	SignedStake       string         `json:"signed_stake"`
+	TotalStake        string         `json:"total_stake"`
+
+	// Ordered by signer address, as required by the verify contract.
+	Signatures []*BundleSignature `json:"signatures"`
+
+	// Calldata of `approve` or `reject` for the verify contract.
+	Method   string        `json:"method"`
+	Calldata hexutil.Bytes `json:"calldata"`
+
+	// Calldata of `tryAggregate` for the Multicall2 contract that wraps the above calldata.
+	MulticallCalldata hexutil.Bytes `json:"multicall_calldata"`
+}
+
+type BundleSignature struct {
+	ID         string         `json:"id"`
+	PreviousID string         `json:"previous_id"`
+	Signer     common.Address `json:"signer"`
+	Stake      string         `json:"stake"`
+	Signature  hexutil.Bytes  `json:"signature"`
+}
+
+// Build the bundle for the specified rollup index from the local signatures.
+func NewBundle(
+	ctx context.Context,
+	cfg *config.Submitter,
+	db *database.Database,
+	stakemanager *stakemanager.Cache,
+	hubLayerChainID *big.Int,
+	v verse.Verse,
+	rollupIndex uint64,
+) (*Bundle, error) {
+	iter := &signatureIterator{
+		db:           db,
+		stakemanager: stakemanager,
+		contract:     v.RollupContract(),
+		rollupIndex:  rollupIndex,
+	}
+	rows, err := iter.next(ctx)
+	if err != nil {
+		return nil, err
+	} else if len(rows) == 0 {
+		return nil, ErrNoSignatures
+	}
+
+	bundle := &Bundle{
+		HubLayerChainID:   hubLayerChainID.Uint64(),
+		ChainID:           v.ChainID(),
+		RollupContract:    v.RollupContract(),
+		VerifyContract:    v.VerifyContract(),
+		MulticallContract: common.HexToAddress(cfg.MulticallAddress),
+		RollupIndex:       rollupIndex,
+		RollupHash:        rows[0].RollupHash,
+		Approved:          rows[0].Approved,
+		TotalStake:        stakemanager.TotalStake(ctx).String(),
+		Signatures:        make([]*BundleSignature, len(rows)),
+		Method:            "approve",
+	}
+	if !bundle.Approved {
+		bundle.Method = "reject"
+	}
+
+	signed := new(big.Int)
+	for i, row := range rows {
+		stake := stakemanager.StakeBySigner(ctx, row.Signer.Address)
+		signed.Add(signed, stake)
+		bundle.Signatures[i] = &BundleSignature{
+			ID:         row.ID,
+			PreviousID: row.PreviousID,
+			Signer:     row.Signer.Address,
+			Stake:      stake.String(),
+			Signature:  row.Signature[:],
+		}
+	}
+	bundle.SignedStake = signed.String()
+
+	// The transaction is never signed, so the signer is not needed.
+	task := v.WithTransactable(
+		ethutil.NewSignableClient(hubLayerChainID, v.L1Client(), nil), v.VerifyContract())
+	opts := newCalldataOpts(ctx, common.Address{})
+
+	rawTx, err := task.Transact(opts, rollupIndex, bundle.Approved, extSignatureBytes(rows))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build calldata: %w", err)
+	}
+	bundle.Calldata = rawTx.Data()
+
+	mcallABI, err := multicall2.Multicall2MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	bundle.MulticallCalldata, err = mcallABI.Pack("tryAggregate", true, []multicall2.Multicall2Call{
+		{Target: bundle.VerifyContract, CallData: bundle.Calldata},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build multicall calldata: %w", err)
+	}
+
+	return bundle, nil
+}
+
+// Save the signatures in the bundle to the database after verifying them. The signatures
+// of the signers without enough stake, and those older than the local ones are skipped.
+// Returns the number of newly saved signatures.
+func ImportBundle(
+	ctx context.Context,
+	db *database.Database,
+	stakemanager *stakemanager.Cache,
+	hubLayerChainID *big.Int,
+	bundle *Bundle,
+) (int, error) {
+	if bundle.HubLayerChainID != hubLayerChainID.Uint64() {
+		return 0, fmt.Errorf("%w: want=%d got=%d",
+			ErrBundleChainMismatch, hubLayerChainID, bundle.HubLayerChainID)
+	}
+
+	// verify all signatures before saving
+	rollupIndex := new(big.Int).SetUint64(bundle.RollupIndex)
+	for _, sig := range bundle.Signatures {
+		if id, err := ulid.ParseStrict(sig.ID); err != nil {
+			return 0, fmt.Errorf("invalid id: %s: %w", sig.ID, err)
+		} else if id.Time() > uint64(time.Now().UnixMilli()) {
+			return 0, fmt.Errorf("future ulid: %s, timestamp: %d", sig.ID, id.Time())
+		}
+		if len(sig.Signature) != database.SignatureLength {
+			return 0, fmt.Errorf("invalid signature length, signer: %s", sig.Signer)
+		}
+		if err := ethutil.VerifySignature(hubLayerChainID, bundle.RollupContract, rollupIndex,
+			bundle.RollupHash, bundle.Approved, sig.Signature, sig.Signer); err != nil {
+			return 0, fmt.Errorf("invalid signature, signer: %s: %w", sig.Signer, err)
+		}
+	}
+
+	// Signatures only from signers with stake >= validator candidate minimum.
+	var sigs []*BundleSignature
+	for _, sig := range bundle.Signatures {
+		if stakemanager.StakeBySigner(ctx, sig.Signer).Cmp(ethutil.TenMillionOAS) >= 0 {
+			sigs = append(sigs, sig)
+		}
+	}
+
+	var imported int
+	err := db.Transaction(func(txdb *database.Database) error {
+		for _, sig := range sigs {
+			if local, err := txdb.OPSignature.FindByID(sig.ID); err == nil &&
+				local.PreviousID == sig.PreviousID {
+				continue // duplicated
+			} else if err != nil && !errors.Is(err, database.ErrNotFound) {
+				return err
+			}
+
+			// local is newer, e.g. re-signed after a reorg
+			if locals, err := txdb.OPSignature.Find(nil, &sig.Signer,
+				&bundle.RollupContract, &bundle.RollupIndex, 1, 0); err != nil {
+				return err
+			} else if len(locals) > 0 && strings.Compare(locals[0].ID, sig.ID) == 1 {
+				continue
+			}
+
+			_, err := txdb.OPSignature.Save(
+				&sig.ID, &sig.PreviousID,
+				sig.Signer,
+				bundle.RollupContract,
+				bundle.RollupIndex,
+				bundle.RollupHash,
+				bundle.Approved,
+				database.BytesSignature(sig.Signature),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to save signature, signer: %s: %w", sig.Signer, err)
+			}
+			imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return imported, nil
+}
+
+// Returns the options to build a transaction only for the calldata,
+// preventing any RPC calls for nonce, gas price and gas estimation.
+func newCalldataOpts(ctx context.Context, from common.Address) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		Context:  ctx,
+		NoSend:   true,
+		Nonce:    common.Big1, // prevent `eth_getNonce`
+		GasPrice: common.Big1, // prevent `eth_gasPrice`
+		GasLimit: 21_000,      // prevent `eth_estimateGas`
+		From:     from,
+		Signer: func(a common.Address, rawTx *types.Transaction) (*types.Transaction, error) {
+			return rawTx, nil
+		},
+	}
+}
@@ -0,0 +1,153 @@
+package submitter
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/oasysgames/oasys-optimism-verifier/config"
+	"github.com/oasysgames/oasys-optimism-verifier/contract/multicall2"
+	"github.com/oasysgames/oasys-optimism-verifier/contract/sccverifier"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	"github.com/oasysgames/oasys-optimism-verifier/util"
+)
+
+func (s *SubmitterTestSuite) TestBundle() {
+	ctx := context.Background()
+	hubChainID := s.SignableHub.ChainID()
+
+	// signers with enough stake to exceed 51% of the total stake
+	signers := make([]ethutil.Signer, 3)
+	for i := range signers {
+		key, _ := crypto.GenerateKey()
+		signers[i] = ethutil.NewPrivateKeySigner(key)
+		s.StakeManager.Owners = append(s.StakeManager.Owners, s.RandAddress())
+		s.StakeManager.Operators = append(s.StakeManager.Operators, signers[i].From())
+		s.StakeManager.Stakes = append(s.StakeManager.Stakes,
+			new(big.Int).Mul(ethutil.TenMillionOAS, big.NewInt(10)))
+		s.StakeManager.Candidates = append(s.StakeManager.Candidates, true)
+	}
+
+	_, event := s.EmitStateBatchAppended(0)
+	msg := ethutil.NewMessage(hubChainID, s.SCCAddr, event.BatchIndex, event.BatchRoot, true)
+	for _, signer := range signers {
+		sig, err := msg.Signature(signer.SignData)
+		s.NoError(err)
+		_, err = s.DB.OPSignature.Save(nil, nil, signer.From(), s.SCCAddr,
+			event.BatchIndex.Uint64(), event.BatchRoot, true, sig)
+		s.NoError(err)
+	}
+
+	bundle, err := NewBundle(ctx, s.cfg, s.DB, s.submitter.stakemanager, hubChainID, s.verse, 0)
+	s.NoError(err)
+	s.Equal(hubChainID.Uint64(), bundle.HubLayerChainID)
+	s.Equal(s.verse.ChainID(), bundle.ChainID)
+	s.Equal(s.SCCAddr, bundle.RollupContract)
+	s.Equal(s.SCCVAddr, bundle.VerifyContract)
+	s.Equal(s.MulticallAddr, bundle.MulticallContract)
+	s.Equal(common.Hash(event.BatchRoot), bundle.RollupHash)
+	s.True(bundle.Approved)
+	s.Equal("approve", bundle.Method)
+	s.Len(bundle.Signatures, 3)
+	for _, sig := range bundle.Signatures {
+		s.Equal(new(big.Int).Mul(ethutil.TenMillionOAS, big.NewInt(10)).String(), sig.Stake)
+	}
+
+	// assert calldata
+	vcABI, _ := sccverifier.SccverifierMetaData.GetAbi()
+	s.Equal(vcABI.Methods["approve"].ID, []byte(bundle.Calldata[:4]))
+
+	mcallABI, _ := multicall2.Multicall2MetaData.GetAbi()
+	args, err := mcallABI.Methods["tryAggregate"].Inputs.Unpack(bundle.MulticallCalldata[4:])
+	s.NoError(err)
+	s.True(args[0].(bool))
+
+	// import to another database
+	sm := s.submitter.stakemanager
+	otherDB, _ := database.NewDatabase(&config.Database{Path: ":memory:"})
+
+	imported, err := ImportBundle(ctx, otherDB, sm, hubChainID, bundle)
+	s.NoError(err)
+	s.Equal(3, imported)
+
+	imported, err = ImportBundle(ctx, otherDB, sm, hubChainID, bundle)
+	s.NoError(err)
+	s.Equal(0, imported)
+
+	gots, _ := otherDB.OPSignature.Find(nil, nil, &s.SCCAddr, nil, 10, 0)
+	s.Len(gots, 3)
+
+	// hub-layer chain id mismatch
+	_, err = ImportBundle(ctx, otherDB, sm, big.NewInt(1), bundle)
+	s.ErrorIs(err, ErrBundleChainMismatch)
+
+	// tampered
+	bundle.Approved = false
+	_, err = ImportBundle(ctx, otherDB, sm, hubChainID, bundle)
+	s.ErrorContains(err, "invalid signature")
+	bundle.Approved = true
+}
+
+func (s *SubmitterTestSuite) TestImportBundle() {
+	ctx := context.Background()
+	hubChainID := s.SignableHub.ChainID()
+	sm := s.submitter.stakemanager
+
+	// the last signer has no stake
+	signers := make([]ethutil.Signer, 3)
+	for i := range signers {
+		key, _ := crypto.GenerateKey()
+		signers[i] = ethutil.NewPrivateKeySigner(key)
+		if i < 2 {
+			s.StakeManager.Owners = append(s.StakeManager.Owners, s.RandAddress())
+			s.StakeManager.Operators = append(s.StakeManager.Operators, signers[i].From())
+			s.StakeManager.Stakes = append(s.StakeManager.Stakes, ethutil.TenMillionOAS)
+			s.StakeManager.Candidates = append(s.StakeManager.Candidates, true)
+		}
+	}
+
+	rollupHash := s.RandHash()
+	msg := ethutil.NewMessage(hubChainID, s.SCCAddr, big.NewInt(0), rollupHash, true)
+	bundle := &Bundle{
+		HubLayerChainID: hubChainID.Uint64(),
+		RollupContract:  s.SCCAddr,
+		RollupHash:      rollupHash,
+		Approved:        true,
+	}
+	for _, signer := range signers {
+		sig, err := msg.Signature(signer.SignData)
+		s.NoError(err)
+		bundle.Signatures = append(bundle.Signatures, &BundleSignature{
+			ID:        util.ULID(nil).String(),
+			Signer:    signer.From(),
+			Signature: sig[:],
+		})
+	}
+
+	// the first signer re-signed after the bundle was exported
+	newer, err := s.DB.OPSignature.Save(nil, nil, signers[0].From(), s.SCCAddr,
+		0, s.RandHash(), true, database.RandSignature())
+	s.NoError(err)
+
+	imported, err := ImportBundle(ctx, s.DB, sm, hubChainID, bundle)
+	s.NoError(err)
+	s.Equal(1, imported)
+
+	for i, signer := range signers {
+		address := signer.From()
+		gots, _ := s.DB.OPSignature.Find(nil, &address, &s.SCCAddr, nil, 10, 0)
+		switch i {
+		case 0:
+			s.Len(gots, 1)
+			s.Equal(newer.ID, gots[0].ID)
+			s.Equal(newer.RollupHash, gots[0].RollupHash)
+		case 1:
+			s.Len(gots, 1)
+			s.Equal(bundle.Signatures[1].ID, gots[0].ID)
+		case 2:
+			s.Len(gots, 0)
+		}
+	}
+}
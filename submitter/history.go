@@ -0,0 +1,174 @@
+package submitter
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	"github.com/oasysgames/oasys-optimism-verifier/verse"
+	"golang.org/x/net/context"
+)
+
+// Rollups of a single verse included in a verify transaction.
+type submittedVerse struct {
+	verse   verse.Verse
+	rollups [][]*database.OptimismSignature // signatures of each rollup index
+}
+
+// Save the sent verify transaction to the submission history as pending.
+func (w *Submitter) savePendingSubmission(tx *types.Transaction, verses ...*submittedVerse) {
+	gasLimits := splitGas(tx.Gas(), verses)
+	for i, sv := range verses {
+		if len(sv.rollups) == 0 {
+			continue
+		}
+
+		var (
+			signers []string
+			seen    = map[common.Address]bool{}
+		)
+		for _, rows := range sv.rollups {
+			for _, row := range rows {
+				if !seen[row.Signer.Address] {
+					seen[row.Signer.Address] = true
+					signers = append(signers, row.Signer.Address.Hex())
+				}
+			}
+		}
+
+		row := &database.Submission{
+			TxHash:     tx.Hash(),
+			Nonce:      tx.Nonce(),
+			ChainID:    sv.verse.ChainID(),
+			FirstIndex: sv.rollups[0][0].RollupIndex,
+			LastIndex:  sv.rollups[len(sv.rollups)-1][0].RollupIndex,
+			Signers:    strings.Join(signers, ","),
+			GasLimit:   gasLimits[i],
+			Status:     database.SubmissionPending,
+		}
+		if err := w.db.Submission.Save(sv.verse.RollupContract(), row); err != nil {
+			sv.verse.Logger(w.log).Warn("Failed to save submission",
+				"tx", tx.Hash().Hex(), "err", err)
+		}
+	}
+}
+
+// Update the submission history with the receipt of the verify transaction.
+func (w *Submitter) saveSubmissionReceipt(
+	ctx context.Context,
+	l1Client ethutil.SignableClient,
+	tx *types.Transaction,
+	receipt *types.Receipt,
+	verses ...*submittedVerse,
+) {
+	price := receipt.EffectiveGasPrice
+	if price == nil {
+		price = tx.GasPrice()
+	}
+
+	var reason string
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		reason = revertReason(ctx, l1Client, tx, receipt)
+	}
+
+	gasUsed := splitGas(receipt.GasUsed, verses)
+	for i, sv := range verses {
+		if len(sv.rollups) == 0 {
+			continue
+		}
+		err := w.db.Submission.SaveReceipt(sv.verse.RollupContract(),
+			tx.Hash(), gasUsed[i], price.String(), receipt.Status, reason)
+		if err != nil {
+			sv.verse.Logger(w.log).Warn("Failed to save submission receipt",
+				"tx", tx.Hash().Hex(), "err", err)
+		}
+	}
+}
+
+// Update the submission history when waiting for the receipt of the verify
+// transaction failed, so that it doesn't remain pending forever.
+func (w *Submitter) saveSubmissionUnknown(tx *types.Transaction, cause error, verses ...*submittedVerse) {
+	for _, sv := range verses {
+		if len(sv.rollups) == 0 {
+			continue
+		}
+		err := w.db.Submission.SaveReceipt(sv.verse.RollupContract(),
+			tx.Hash(), 0, "", database.SubmissionUnknown, cause.Error())
+		if err != nil {
+			sv.verse.Logger(w.log).Warn("Failed to save submission status",
+				"tx", tx.Hash().Hex(), "err", err)
+		}
+	}
+}
+
+// Split the gas among the verses by the number of calls.
+// The remainder goes to the last verse so that the sum equals `gas`.
+func splitGas(gas uint64, verses []*submittedVerse) []uint64 {
+	var calls uint64
+	for _, sv := range verses {
+		calls += uint64(len(sv.rollups))
+	}
+
+	gases := make([]uint64, len(verses))
+	if calls == 0 {
+		return gases
+	}
+
+	var (
+		rest = gas
+		last int
+	)
+	for i, sv := range verses {
+		if len(sv.rollups) == 0 {
+			continue
+		}
+		gases[i] = gas * uint64(len(sv.rollups)) / calls
+		rest -= gases[i]
+		last = i
+	}
+	gases[last] += rest
+	return gases
+}
+
+// Replay the reverted transaction with `eth_call` on the parent block to get the revert reason.
+func revertReason(
+	ctx context.Context,
+	l1Client ethutil.SignableClient,
+	tx *types.Transaction,
+	receipt *types.Receipt,
+) string {
+	msg := ethereum.CallMsg{
+		From:  l1Client.Signer(),
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+
+	var blockNumber *big.Int
+	if receipt.BlockNumber != nil && receipt.BlockNumber.Sign() > 0 {
+		blockNumber = new(big.Int).Sub(receipt.BlockNumber, common.Big1)
+	}
+
+	_, err := l1Client.CallContract(ctx, msg, blockNumber)
+	if err == nil {
+		return ""
+	}
+
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if reason, err := abi.UnpackRevert(common.FromHex(data)); err == nil {
+				return reason
+			}
+		}
+	}
+	return err.Error()
+}
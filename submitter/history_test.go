@@ -0,0 +1,90 @@
+package submitter
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+)
+
+func (s *SubmitterTestSuite) TestSaveSubmission() {
+	ctx := context.Background()
+
+	// send a transaction that reverts with a reason
+	opts := s.SignableHub.TransactOpts(ctx)
+	opts.GasLimit = 100_000
+	tx, err := s.TSCC.EmitStateBatchVerified(opts, big.NewInt(5), s.RandHash())
+	s.Require().NoError(err)
+	s.Hub.Commit()
+
+	receipt, err := s.Hub.TransactionReceipt(ctx, tx.Hash())
+	s.Require().NoError(err)
+	s.Equal(types.ReceiptStatusFailed, receipt.Status)
+
+	var rollups [][]*database.OptimismSignature
+	for _, i := range s.Range(3, 5) {
+		sig, _ := s.DB.OPSignature.Save(nil, nil, s.StakeManager.Operators[0], s.SCCAddr,
+			uint64(i), s.RandHash(), true, database.RandSignature())
+		rollups = append(rollups, []*database.OptimismSignature{sig})
+	}
+	submitted := &submittedVerse{verse: s.verse, rollups: rollups}
+
+	// saved as pending when sent
+	s.submitter.savePendingSubmission(tx, submitted)
+
+	rows, _ := s.DB.Submission.Find(nil, time.Time{}, 10, 0)
+	s.Len(rows, 1)
+	s.Equal(tx.Hash(), rows[0].TxHash)
+	s.Equal(s.SCCAddr, rows[0].Contract.Address)
+	s.Equal(uint64(3), rows[0].FirstIndex)
+	s.Equal(uint64(4), rows[0].LastIndex)
+	s.Equal(s.StakeManager.Operators[0].Hex(), rows[0].Signers)
+	s.Equal(tx.Gas(), rows[0].GasLimit)
+	s.Equal(database.SubmissionPending, rows[0].Status)
+	s.Equal("", rows[0].RevertReason)
+
+	// updated when the receipt arrives, with the replayed revert reason
+	s.submitter.saveSubmissionReceipt(ctx, s.SignableHub, tx, receipt, submitted)
+
+	rows, _ = s.DB.Submission.Find(nil, time.Time{}, 10, 0)
+	s.Len(rows, 1)
+	s.Equal(receipt.GasUsed, rows[0].GasUsed)
+	s.Equal(receipt.EffectiveGasPrice.String(), rows[0].EffectivePrice)
+	s.Equal(types.ReceiptStatusFailed, rows[0].Status)
+	s.Equal("Invalid batch index.", rows[0].RevertReason)
+}
+
+func (s *SubmitterTestSuite) TestSaveSubmissionUnknown() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// send a transaction that is never mined
+	tx, err := s.TSCC.EmitStateBatchVerified(s.SignableHub.TransactOpts(ctx), big.NewInt(0), s.RandHash())
+	s.Require().NoError(err)
+
+	sig, _ := s.DB.OPSignature.Save(nil, nil, s.StakeManager.Operators[0], s.SCCAddr,
+		0, s.RandHash(), true, database.RandSignature())
+	submitted := &submittedVerse{verse: s.verse, rollups: [][]*database.OptimismSignature{{sig}}}
+
+	// waiting for the receipt fails
+	cancel()
+	s.ErrorIs(s.submitter.waitForReceipt(ctx, s.SignableHub, tx, submitted), context.Canceled)
+
+	rows, _ := s.DB.Submission.Find(nil, time.Time{}, 10, 0)
+	s.Len(rows, 1)
+	s.Equal(tx.Hash(), rows[0].TxHash)
+	s.Equal(database.SubmissionUnknown, rows[0].Status)
+	s.Equal(context.Canceled.Error(), rows[0].RevertReason)
+}
+
+func (s *SubmitterTestSuite) TestSplitGas() {
+	verses := []*submittedVerse{
+		{rollups: make([][]*database.OptimismSignature, 2)},
+		{rollups: nil},
+		{rollups: make([][]*database.OptimismSignature, 1)},
+	}
+	s.Equal([]uint64{6, 0, 4}, splitGas(10, verses))
+	s.Equal([]uint64{66, 0, 33}, splitGas(99, verses))
+	s.Equal([]uint64{0}, splitGas(10, verses[1:2]))
+}
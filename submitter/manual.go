@@ -0,0 +1,117 @@
+package submitter
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/verse"
+	"golang.org/x/net/context"
+)
+
+// Verify transaction for a single rollup index, signed but not sent yet.
+type PreparedTx struct {
+	Verse       verse.TransactableVerse
+	NextIndex   uint64
+	RollupIndex uint64
+	RollupHash  common.Hash
+	Approved    bool
+	Signatures  []*database.OptimismSignature
+	Stakes      []*big.Int // stake amount of each signer, in the same order as `Signatures`
+	SignedStake *big.Int
+	TotalStake  *big.Int
+	Tx          *types.Transaction
+}
+
+// Build the same verify transaction as the automatic submission for
+// the specified rollup index, without sending it.
+func (w *Submitter) Prepare(
+	ctx context.Context,
+	task verse.TransactableVerse,
+	rollupIndex uint64,
+) (*PreparedTx, error) {
+	nextIndex, err := task.NextIndex(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch next index: %w", err)
+	} else if rollupIndex < nextIndex {
+		return nil, ErrAlreadyVerified
+	} else if rollupIndex > nextIndex {
+		return nil, ErrNotNextIndex
+	}
+
+	iter := &signatureIterator{
+		db:           w.db,
+		stakemanager: w.stakemanager,
+		detector:     w.detector,
+		contract:     task.RollupContract(),
+		rollupIndex:  rollupIndex,
+	}
+	rows, err := iter.next(ctx)
+	if err != nil {
+		return nil, err
+	} else if len(rows) == 0 {
+		return nil, ErrNoSignatures
+	}
+
+	prepared := &PreparedTx{
+		Verse:       task,
+		NextIndex:   nextIndex,
+		RollupIndex: rollupIndex,
+		RollupHash:  rows[0].RollupHash,
+		Approved:    rows[0].Approved,
+		Signatures:  rows,
+		Stakes:      make([]*big.Int, len(rows)),
+		SignedStake: new(big.Int),
+		TotalStake:  w.stakemanager.TotalStake(ctx),
+	}
+	for i, row := range rows {
+		prepared.Stakes[i] = w.stakemanager.StakeBySigner(ctx, row.Signer.Address)
+		prepared.SignedStake.Add(prepared.SignedStake, prepared.Stakes[i])
+	}
+
+	// call estimateGas
+	opts := task.L1Signer().TransactOpts(ctx)
+	opts.NoSend = true
+	tx, err := task.Transact(opts, rollupIndex, prepared.Approved, extSignatureBytes(rows))
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	// rebuild with the gas limit multiplied by the configured multiplier
+	opts.Nonce = new(big.Int).SetUint64(tx.Nonce())
+	opts.GasLimit = w.cfg.Load().MultiplyGas(tx.Gas())
+	if prepared.Tx, err = task.Transact(opts, rollupIndex, prepared.Approved, extSignatureBytes(rows)); err != nil {
+		return nil, fmt.Errorf("failed to build transaction: %w", err)
+	}
+	return prepared, nil
+}
+
+// Send the prepared transaction and wait for the receipt.
+func (w *Submitter) SendPrepared(ctx context.Context, prepared *PreparedTx) error {
+	l1Signer := prepared.Verse.L1Signer()
+	if err := l1Signer.SendTransaction(ctx, prepared.Tx); err != nil {
+		return fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	prepared.Verse.Logger(w.log).Info("Sent transaction",
+		"rollup-index", prepared.RollupIndex, "tx", prepared.Tx.Hash().Hex(),
+		"nonce", prepared.Tx.Nonce(), "gas-limit", prepared.Tx.Gas())
+
+	return w.waitForReceipt(ctx, l1Signer, prepared.Tx, &submittedVerse{
+		verse:   prepared.Verse,
+		rollups: [][]*database.OptimismSignature{prepared.Signatures},
+	})
+}
+
+// Returns the percentage of the signed stake amount to the total stake amount.
+func (p *PreparedTx) Coverage() float64 {
+	if p.TotalStake == nil || p.TotalStake.Sign() == 0 {
+		return 0
+	}
+	ratio, _ := new(big.Rat).SetFrac(
+		new(big.Int).Mul(p.SignedStake, big.NewInt(100)), p.TotalStake).Float64()
+	return ratio
+}
@@ -0,0 +1,81 @@
+package submitter
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+)
+
+func (s *SubmitterTestSuite) TestPrepareAndSend() {
+	ctx := context.Background()
+	nextIndex := 1
+	signers := s.StakeManager.Operators
+
+	// save dummy signatures
+	for i := range s.Range(0, 2) {
+		_, event := s.EmitStateBatchAppended(i)
+		for _, signer := range signers {
+			s.DB.OPSignature.Save(
+				nil, nil,
+				signer,
+				s.SCCAddr,
+				event.BatchIndex.Uint64(),
+				event.BatchRoot,
+				true,
+				database.RandSignature(),
+			)
+		}
+	}
+
+	// set the `SCC.nextIndex`
+	s.TSCC.SetNextIndex(s.SignableHub.TransactOpts(ctx), big.NewInt(int64(nextIndex)))
+	s.Hub.Commit()
+
+	// already verified
+	_, err := s.submitter.Prepare(ctx, s.transactable, 0)
+	s.ErrorIs(err, ErrAlreadyVerified)
+
+	// not the next index
+	_, err = s.submitter.Prepare(ctx, s.transactable, 2)
+	s.ErrorIs(err, ErrNotNextIndex)
+
+	prepared, err := s.submitter.Prepare(ctx, s.transactable, uint64(nextIndex))
+	s.NoError(err)
+	s.Equal(uint64(nextIndex), prepared.NextIndex)
+	s.Equal(uint64(nextIndex), prepared.RollupIndex)
+	s.True(prepared.Approved)
+	s.Len(prepared.Signatures, 6)
+	s.Len(prepared.Stakes, 6)
+	s.Greater(prepared.Coverage(), float64(51))
+	s.Equal(s.SCCVAddr, *prepared.Tx.To())
+
+	signed := new(big.Int)
+	for i, sig := range prepared.Signatures {
+		s.Equal(s.submitter.stakemanager.StakeBySigner(ctx, sig.Signer.Address), prepared.Stakes[i])
+		signed.Add(signed, prepared.Stakes[i])
+	}
+	s.Equal(signed, prepared.SignedStake)
+
+	// send and mine the transaction
+	errc := make(chan error)
+	go func() { errc <- s.submitter.SendPrepared(ctx, prepared) }()
+	time.Sleep(50 * time.Millisecond)
+	s.Hub.Commit()
+	s.NoError(<-errc)
+
+	got, err := s.TSCCV.AssertLogs(&bind.CallOpts{Context: ctx}, big.NewInt(0))
+	s.NoError(err)
+	s.Equal(s.SCCAddr, got.StateCommitmentChain)
+	s.Equal(uint64(nextIndex), got.BatchHeader.BatchIndex.Uint64())
+	s.True(got.Approve)
+	s.Len(got.Signatures, len(prepared.Signatures)*65)
+
+	// no signatures
+	s.TSCC.SetNextIndex(s.SignableHub.TransactOpts(ctx), big.NewInt(2))
+	s.Hub.Commit()
+	_, err = s.submitter.Prepare(ctx, s.transactable, 2)
+	s.ErrorIs(err, ErrNoSignatures)
+}
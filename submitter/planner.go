@@ -0,0 +1,336 @@
+package submitter
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/oasysgames/oasys-optimism-verifier/contract/multicall2"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
+	"golang.org/x/net/context"
+)
+
+// Tasks of verses sharing the same wallet, submitted in one multicall transaction.
+type batchT struct {
+	wallet   common.Address
+	l1Signer ethutil.SignableClient
+	tasks    []*taskT
+	releases []func()
+}
+
+func (b *batchT) release() {
+	for _, release := range b.releases {
+		release()
+	}
+}
+
+// Planning state of the calls for a single verse.
+type plannedTask struct {
+	task      *taskT
+	log       log.Logger
+	nextIndex uint64
+	iter      *signatureIterator
+	rollups   [][]*database.OptimismSignature // signatures of the planned calls
+	done      bool                            // no more calls for this verse
+	err       error                           // reason why there are no calls
+}
+
+func (w *Submitter) workBatch(ctx context.Context, batch *batchT) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
+	defer cancel()
+
+	log := w.log.New("wallet", batch.wallet, "verses", len(batch.tasks))
+
+	planned, tx, err := w.submitBatch(ctx, log, batch)
+	if err == nil {
+		submitted := make([]*submittedVerse, len(planned))
+		for i, pt := range planned {
+			submitted[i] = &submittedVerse{verse: pt.task.verse, rollups: pt.rollups}
+		}
+		err = w.waitForReceipt(ctx, batch.l1Signer, tx, submitted...)
+		if err != nil {
+			err = fmt.Errorf("failed to wait for receipt: %w", err)
+		}
+	}
+
+	for _, pt := range planned {
+		log := pt.log
+		if pt.task.verifiedIndex != nil {
+			log = log.New("verified-index", *pt.task.verifiedIndex)
+		}
+
+		if len(pt.rollups) == 0 {
+			// the calls didn't fit in the batch, or the batch failed before planning them
+			if pt.err == nil && err != nil {
+				pt.err = err
+			} else if pt.err == nil {
+				pt.err = ErrDeferred
+			}
+			w.logTaskResult(log, pt.nextIndex, pt.err)
+			pt.task.setResult(pt.nextIndex, pt.err)
+			continue
+		} else if err != nil {
+			log.Error("Failed to verify the rollup index", "err", err)
+			pt.task.setResult(pt.nextIndex, err)
+			continue
+		}
+
+		// succeeded to verify the corresponding rollup index, So move to the next index
+		nextIndex := pt.nextIndex
+		pt.task.verifiedIndex = &nextIndex
+		log.Info("Successfully verified the rollup index",
+			"next-verified-index", nextIndex, "calls", len(pt.rollups))
+		if err := w.cleanupOldSignatures(pt.task.verse.RollupContract(), nextIndex); err != nil {
+			log.Warn("Failed to delete old signatures", "verified-index", nextIndex, "err", err)
+		}
+		pt.task.setResult(nextIndex, nil)
+	}
+}
+
+// Pack the calls of all verses in the batch into one multicall transaction and send it.
+// Calls are collected in a round-robin manner so that every verse progresses.
+func (w *Submitter) submitBatch(
+	ctx context.Context,
+	log log.Logger,
+	batch *batchT,
+) (planned []*plannedTask, tx *types.Transaction, err error) {
+	for _, task := range batch.tasks {
+		pt := &plannedTask{task: task}
+		planned = append(planned, pt)
+
+		pt.nextIndex, pt.err = w.versepool.NextIndex(ctx,
+			task.verse.RollupContract(), w.cfg.Load().Confirmations, false)
+		if pt.err != nil {
+			pt.log = task.verse.Logger(w.log)
+			pt.err = fmt.Errorf("failed to fetch next index: %w", pt.err)
+			pt.done = true
+			continue
+		}
+		pt.log = task.verse.Logger(w.log).New("next-index", pt.nextIndex)
+
+		if task.verifiedIndex != nil {
+			if *task.verifiedIndex == pt.nextIndex {
+				pt.err = ErrAlreadyVerified
+				pt.done = true
+				continue
+			} else if *task.verifiedIndex > pt.nextIndex {
+				pt.log.Warn("Possible reorged. next index is smaller than the verified index",
+					"verified-index", *task.verifiedIndex, "next-index", pt.nextIndex)
+			}
+		}
+
+		pt.iter = &signatureIterator{
+			db:           w.db,
+			stakemanager: w.stakemanager,
+			detector:     w.detector,
+			p2p:          w.p2p,
+			contract:     task.verse.RollupContract(),
+			rollupIndex:  pt.nextIndex,
+		}
+	}
+
+	mcall, err := multicall2.NewMulticall2(common.HexToAddress(w.cfg.Load().MulticallAddress), batch.l1Signer)
+	if err != nil {
+		log.Error("Failed to construct the multicall contract", "err", err)
+		return planned, nil, err
+	}
+
+	calls, owners, err := w.planCalls(ctx, log, mcall, batch.wallet, planned)
+	if err != nil {
+		return planned, nil, err
+	} else if len(calls) == 0 {
+		return planned, nil, ErrNoSignatures
+	}
+
+	// call estimateGas
+	opts := batch.l1Signer.TransactOpts(ctx)
+	opts.NoSend = true
+	tx, err = mcall.TryAggregate(opts, true, calls)
+	if err != nil {
+		// a reverting verse must not block the others sharing the wallet
+		var dropped bool
+		if calls, owners, dropped = dropRevertedCalls(opts, mcall, calls, owners); !dropped || len(calls) == 0 {
+			log.Error("Failed to estimate gas", "err", err)
+			return planned, nil, err
+		}
+
+		tx, err = mcall.TryAggregate(opts, true, calls)
+		if err != nil {
+			log.Error("Failed to estimate gas", "err", err)
+			return planned, nil, err
+		}
+	}
+
+	// to fit max gas, since the calls are ordered per verse,
+	// trimming from the end keeps the rollup index order of each verse
+	if end := w.fitMaxGas(tx.Gas(), len(calls)); end < len(calls) {
+		for _, owner := range owners[end:] {
+			owner.rollups = owner.rollups[:len(owner.rollups)-1]
+		}
+		calls, owners = calls[:end], owners[:end]
+
+		// re estimateGas
+		tx, err = mcall.TryAggregate(opts, true, calls)
+		if err != nil {
+			log.Error("Failed to re-estimate gas", "err", err)
+			return planned, nil, err
+		}
+	}
+
+	// send transaction
+	opts.NoSend = false
+	opts.GasLimit = w.cfg.Load().MultiplyGas(tx.Gas())
+	tx, err = mcall.TryAggregate(opts, true, calls)
+	if err != nil {
+		log.Error("Failed to send multicall verify transaction", "err", err)
+		return planned, nil, err
+	}
+
+	log.Info(
+		"Sent transaction",
+		"call-size", len(calls),
+		"tx", tx.Hash().Hex(),
+		"nonce", tx.Nonce(),
+		"gas-limit", tx.Gas(),
+		"gas-fee", tx.GasFeeCap(),
+		"gas-tip", tx.GasTipCap(),
+	)
+	return planned, tx, nil
+}
+
+// Collect the calls from the planned tasks in a round-robin manner until
+// reaching the batch size or the maximum transaction size.
+// Returns the calls and the planned task that owns each call.
+func (w *Submitter) planCalls(
+	ctx context.Context,
+	log log.Logger,
+	mcall *multicall2.Multicall2,
+	from common.Address,
+	planned []*plannedTask,
+) (calls []multicall2.Multicall2Call, owners []*plannedTask, err error) {
+	opts := newCalldataOpts(ctx, from)
+	batchSize := w.cfg.Load().BatchSize
+
+	for len(calls) < batchSize {
+		progressed := false
+		for _, pt := range planned {
+			if pt.done || len(calls) >= batchSize {
+				continue
+			}
+
+			rows, err := pt.iter.next(ctx)
+			if err != nil {
+				if !errors.Is(err, &StakeAmountShortage{}) {
+					pt.log.Error("Failed to find signatures", "err", err)
+				}
+				pt.err, pt.done = err, true
+				continue
+			} else if len(rows) == 0 {
+				pt.err, pt.done = ErrNoSignatures, true
+				continue
+			}
+
+			// build transaction (without sending).
+			task := pt.task.verse
+			rawTx, err := task.Transact(opts, rows[0].RollupIndex, rows[0].Approved, extSignatureBytes(rows))
+			if err != nil {
+				pt.log.Error("Failed to create verify transaction", "err", err)
+				pt.err, pt.done = err, true
+				continue
+			}
+
+			call := multicall2.Multicall2Call{
+				Target:   task.VerifyContract(),
+				CallData: rawTx.Data(),
+			}
+			rawTx, err = mcall.TryAggregate(opts, true, append(calls, call))
+			if err != nil {
+				log.Error("Failed to create multicall transaction", "err", err)
+				return nil, nil, err
+			} else if len(rawTx.Data()) > maxTxSize {
+				log.Warn("Oversized", "data-size", len(rawTx.Data()), "call-size", len(calls)+1)
+				return calls, owners, nil
+			}
+
+			calls = append(calls, call)
+			owners = append(owners, pt)
+			pt.rollups = append(pt.rollups, rows)
+			progressed = true
+
+			// if rejected, there is no need to approve any subsequent rollups.
+			if !rows[0].Approved {
+				pt.done = true
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+	return calls, owners, nil
+}
+
+// Returns the number of calls that fit within the maximum gas.
+func (w *Submitter) fitMaxGas(gas uint64, calls int) int {
+	maxGas := w.cfg.Load().MaxGas
+	if gas <= maxGas {
+		return calls
+	}
+	gasPerCall := (gas - minTxGas) / uint64(calls)
+	end := uint64(calls)
+	for ; end > 1 && end*gasPerCall > maxGas; end-- {
+	}
+	return int(end)
+}
+
+// Estimate the calls of each verse separately and drop the calls of the
+// verses that revert. Returns the remaining calls and their owners, and
+// false if the calls belong to a single verse and nothing can be isolated.
+func dropRevertedCalls(
+	opts *bind.TransactOpts,
+	mcall *multicall2.Multicall2,
+	calls []multicall2.Multicall2Call,
+	owners []*plannedTask,
+) ([]multicall2.Multicall2Call, []*plannedTask, bool) {
+	var verses []*plannedTask
+	for _, owner := range owners {
+		if !slices.Contains(verses, owner) {
+			verses = append(verses, owner)
+		}
+	}
+	if len(verses) < 2 {
+		return calls, owners, false
+	}
+
+	reverted := map[*plannedTask]bool{}
+	for _, pt := range verses {
+		var own []multicall2.Multicall2Call
+		for i, owner := range owners {
+			if owner == pt {
+				own = append(own, calls[i])
+			}
+		}
+		if _, err := mcall.TryAggregate(opts, true, own); err != nil {
+			pt.log.Error("Dropped the reverted verify calls from the batch", "err", err)
+			pt.err, pt.done, pt.rollups = fmt.Errorf("failed to estimate gas: %w", err), true, nil
+			reverted[pt] = true
+		}
+	}
+
+	var (
+		remainCalls  []multicall2.Multicall2Call
+		remainOwners []*plannedTask
+	)
+	for i, owner := range owners {
+		if !reverted[owner] {
+			remainCalls = append(remainCalls, calls[i])
+			remainOwners = append(remainOwners, owner)
+		}
+	}
+	return remainCalls, remainOwners, len(reverted) > 0
+}
@@ -0,0 +1,194 @@
+package submitter
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/verse"
+)
+
+func (s *SubmitterTestSuite) TestCrossVerseBatching() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signers := s.StakeManager.Operators
+
+	// add the second verse sharing the same wallet
+	opstack := verse.NewOPStack(s.DB, s.Hub, 23456, s.Verse.URL(), s.L2OOAddr, s.L2OOVAddr)
+	s.versepool.Add(opstack, true)
+	s.cfg.CrossVerseBatching = true
+
+	// save dummy signatures
+	for i := range s.Range(0, 2) {
+		_, event := s.EmitStateBatchAppended(i)
+		for _, signer := range signers {
+			s.DB.OPSignature.Save(nil, nil, signer, s.SCCAddr,
+				event.BatchIndex.Uint64(), event.BatchRoot, true, database.RandSignature())
+		}
+	}
+	_, event := s.EmitOutputProposed(0)
+	for _, signer := range signers {
+		s.DB.OPSignature.Save(nil, nil, signer, s.L2OOAddr,
+			event.L2OutputIndex.Uint64(), event.OutputRoot, true, database.RandSignature())
+	}
+
+	// Confirm blocks
+	for i := 0; i < s.cfg.Confirmations; i++ {
+		s.Hub.Mining()
+	}
+
+	// submitter do the work.
+	go s.submitter.Start(ctx)
+	time.Sleep(s.cfg.Interval * 2)
+	s.Hub.Commit()
+
+	// assert only one multicall transaction was sent for both verses
+	currBlock, _ := s.Hub.Client().BlockByNumber(ctx, nil)
+	s.Len(currBlock.Transactions(), 1)
+	mcallTx := currBlock.Transactions()[0]
+	s.Equal(s.MulticallAddr, *mcallTx.To())
+
+	mcallReceipt, err := s.Hub.TransactionReceipt(ctx, mcallTx.Hash())
+	s.NoError(err)
+	s.Equal(uint64(1), mcallReceipt.Status)
+
+	sccLen, _ := s.TSCCV.SccAssertLogsLen(&bind.CallOpts{Context: ctx})
+	s.Equal(uint64(2), sccLen.Uint64())
+
+	l2ooLen, _ := s.TL2OOV.L2ooAssertLogsLen(&bind.CallOpts{Context: ctx})
+	s.Equal(uint64(1), l2ooLen.Uint64())
+
+	// assert the submission history for each verse
+	var rows []*database.Submission
+	s.Eventually(func() bool {
+		rows, _ = s.DB.Submission.Find(nil, time.Time{}, 10, 0)
+		return len(rows) == 2 && rows[0].Status == 1 && rows[1].Status == 1
+	}, 3*time.Second, 100*time.Millisecond)
+
+	// the gas is split by the number of calls
+	var gasLimit, gasUsed uint64
+	for _, row := range rows {
+		s.Equal(mcallTx.Hash(), row.TxHash)
+		gasLimit += row.GasLimit
+		gasUsed += row.GasUsed
+		if row.ChainID == opstack.ChainID() {
+			s.Equal(s.L2OOAddr, row.Contract.Address)
+			s.Equal(uint64(0), row.LastIndex)
+		} else {
+			s.Equal(s.SCCAddr, row.Contract.Address)
+			s.Equal(uint64(0), row.FirstIndex)
+			s.Equal(uint64(1), row.LastIndex)
+			s.InDelta(mcallReceipt.GasUsed*2/3, row.GasUsed, 2)
+		}
+	}
+	s.Equal(mcallTx.Gas(), gasLimit)
+	s.Equal(mcallReceipt.GasUsed, gasUsed)
+}
+
+func (s *SubmitterTestSuite) TestCrossVerseBatchingWithRevert() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signers := s.StakeManager.Operators
+
+	// add the second verse whose verify calls always revert
+	// because the verify contract is not the L2OO verifier
+	opstack := verse.NewOPStack(s.DB, s.Hub, 23456, s.Verse.URL(), s.L2OOAddr, s.SCCAddr)
+	s.versepool.Add(opstack, true)
+	s.cfg.CrossVerseBatching = true
+
+	// save dummy signatures
+	for i := range s.Range(0, 2) {
+		_, event := s.EmitStateBatchAppended(i)
+		for _, signer := range signers {
+			s.DB.OPSignature.Save(nil, nil, signer, s.SCCAddr,
+				event.BatchIndex.Uint64(), event.BatchRoot, true, database.RandSignature())
+		}
+	}
+	_, event := s.EmitOutputProposed(0)
+	for _, signer := range signers {
+		s.DB.OPSignature.Save(nil, nil, signer, s.L2OOAddr,
+			event.L2OutputIndex.Uint64(), event.OutputRoot, true, database.RandSignature())
+	}
+
+	// Confirm blocks
+	for i := 0; i < s.cfg.Confirmations; i++ {
+		s.Hub.Mining()
+	}
+
+	// submitter do the work.
+	go s.submitter.Start(ctx)
+	time.Sleep(s.cfg.Interval * 2)
+	s.Hub.Commit()
+
+	// assert the calls of the other verse were sent
+	currBlock, _ := s.Hub.Client().BlockByNumber(ctx, nil)
+	s.Len(currBlock.Transactions(), 1)
+	mcallTx := currBlock.Transactions()[0]
+	s.Equal(s.MulticallAddr, *mcallTx.To())
+
+	mcallReceipt, err := s.Hub.TransactionReceipt(ctx, mcallTx.Hash())
+	s.NoError(err)
+	s.Equal(uint64(1), mcallReceipt.Status)
+
+	sccLen, _ := s.TSCCV.SccAssertLogsLen(&bind.CallOpts{Context: ctx})
+	s.Equal(uint64(2), sccLen.Uint64())
+
+	l2ooLen, _ := s.TL2OOV.L2ooAssertLogsLen(&bind.CallOpts{Context: ctx})
+	s.Equal(uint64(0), l2ooLen.Uint64())
+}
+
+func (s *SubmitterTestSuite) TestCrossVerseBatchingDeferred() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signers := s.StakeManager.Operators
+
+	// add the second verse sharing the same wallet
+	opstack := verse.NewOPStack(s.DB, s.Hub, 23456, s.Verse.URL(), s.L2OOAddr, s.L2OOVAddr)
+	s.versepool.Add(opstack, true)
+	s.cfg.CrossVerseBatching = true
+	s.cfg.BatchSize = 1
+
+	// save dummy signatures
+	_, sccEvent := s.EmitStateBatchAppended(0)
+	_, l2ooEvent := s.EmitOutputProposed(0)
+	for _, signer := range signers {
+		s.DB.OPSignature.Save(nil, nil, signer, s.SCCAddr,
+			sccEvent.BatchIndex.Uint64(), sccEvent.BatchRoot, true, database.RandSignature())
+		s.DB.OPSignature.Save(nil, nil, signer, s.L2OOAddr,
+			l2ooEvent.L2OutputIndex.Uint64(), l2ooEvent.OutputRoot, true, database.RandSignature())
+	}
+
+	// Confirm blocks
+	for i := 0; i < s.cfg.Confirmations; i++ {
+		s.Hub.Mining()
+	}
+
+	// submitter do the work.
+	go s.submitter.Start(ctx)
+	time.Sleep(s.cfg.Interval * 2)
+	s.Hub.Commit()
+
+	// assert only the call of one verse fits in the batch
+	sccLen, _ := s.TSCCV.SccAssertLogsLen(&bind.CallOpts{Context: ctx})
+	l2ooLen, _ := s.TL2OOV.L2ooAssertLogsLen(&bind.CallOpts{Context: ctx})
+	s.Equal(uint64(1), sccLen.Uint64()+l2ooLen.Uint64())
+
+	deferred := s.L2OOAddr
+	if l2ooLen.Uint64() == 1 {
+		deferred = s.SCCAddr
+	}
+
+	// assert the other verse is not regarded as verified
+	s.Eventually(func() bool {
+		var result string
+		s.submitter.tasks.Range(func(contract common.Address, task *taskT) bool {
+			if st := task.status.Load(); contract == deferred && st != nil {
+				result = st.Result
+			}
+			return true
+		})
+		return result == ErrDeferred.Error()
+	}, 3*time.Second, 100*time.Millisecond)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
 	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/equivocation"
 	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
 )
 
@@ -16,6 +17,8 @@ type signatureIterator struct {
 	db           *database.Database
 	stakemanager *stakemanager.Cache
 	contract     common.Address
+	detector     *equivocation.Detector
+	p2p          P2P
 	rollupIndex  uint64
 }
 
@@ -25,14 +28,27 @@ func (si *signatureIterator) next(ctx context.Context) ([]*database.OptimismSign
 		return nil, err
 	}
 
-	rows, err = filterSignatures(rows, ethutil.TenMillionOAS, si.stakemanager.TotalStake(ctx),
+	if si.detector != nil {
+		si.detector.CheckVotes(ctx, si.contract, si.rollupIndex, rows)
+	}
+
+	filterd, err := filterSignatures(rows, ethutil.TenMillionOAS, si.stakemanager.TotalStake(ctx),
 		func(signer common.Address) *big.Int { return si.stakemanager.StakeBySigner(ctx, signer) })
+	if _, ok := err.(*StakeAmountShortage); ok && si.p2p != nil {
+		// Ask peers for the missing signatures rather than
+		// waiting for them to be re-published via pubsub.
+		excludes := make([]common.Address, len(rows))
+		for i, row := range rows {
+			excludes[i] = row.Signer.Address
+		}
+		si.p2p.RequestSignatures(si.contract, si.rollupIndex, excludes)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	si.rollupIndex++
-	return rows, nil
+	return filterd, nil
 }
 
 func filterSignatures(
@@ -40,58 +56,31 @@ func filterSignatures(
 	minStake, totalStake *big.Int,
 	stakeBySigner func(signer common.Address) *big.Int,
 ) (filterd []*database.OptimismSignature, err error) {
-	// group by RollupHash and Approved
-	type group struct {
-		stake *big.Int
-		rows  []*database.OptimismSignature
-	}
-	groups := map[string]*group{}
-	signerStakes := map[common.Address]*big.Int{}
-
-	for _, row := range rows {
-		stake := stakeBySigner(row.Signer.Address)
-		if stake.Cmp(minStake) == -1 {
-			continue
-		}
-		signerStakes[row.Signer.Address] = stake
-
-		key := fmt.Sprintf("%s:%v", row.RollupHash, row.Approved)
-		if _, ok := groups[key]; !ok {
-			groups[key] = &group{stake: new(big.Int)}
-		}
-
-		groups[key].stake = new(big.Int).Add(groups[key].stake, stake)
-		groups[key].rows = append(groups[key].rows, row)
-	}
+	groups, signerStakes := equivocation.GroupSignatures(rows, minStake, stakeBySigner)
 	if len(groups) == 0 {
 		return nil, nil
 	}
 
-	// find the highest stake group
-	var highest *group
-	for key := range groups {
-		if highest == nil || groups[key].stake.Cmp(highest.stake) == 1 {
-			highest = groups[key]
-		}
-	}
+	// the highest stake group comes first
+	highest := groups[0]
 
 	// check over half
-	required := new(big.Int).Mul(new(big.Int).Div(totalStake, big.NewInt(100)), big.NewInt(51))
-	if highest.stake.Cmp(required) == -1 {
-		return nil, &StakeAmountShortage{required, highest.stake}
+	required := RequiredStake(totalStake)
+	if highest.Stake.Cmp(required) == -1 {
+		return nil, &StakeAmountShortage{required, highest.Stake}
 	}
 
 	// sort by stake amount
-	sort.Slice(highest.rows, func(i, j int) bool {
-		a := signerStakes[highest.rows[i].Signer.Address]
-		b := signerStakes[highest.rows[j].Signer.Address]
+	sort.Slice(highest.Rows, func(i, j int) bool {
+		a := signerStakes[highest.Rows[i].Signer.Address]
+		b := signerStakes[highest.Rows[j].Signer.Address]
 		return a.Cmp(b) == 1 // order by desc
 	})
 
 	// extract only amounts above the minimum stake
 	exts := []*database.OptimismSignature{}
 	amount := big.NewInt(0)
-	for _, row := range highest.rows {
+	for _, row := range highest.Rows {
 		exts = append(exts, row)
 		amount.Add(amount, signerStakes[row.Signer.Address])
 		if amount.Cmp(required) >= 0 {
@@ -104,6 +93,11 @@ func filterSignatures(
 	return exts, nil
 }
 
+// Returns the stake amount required to verify the rollup, 51% of the total stake.
+func RequiredStake(totalStake *big.Int) *big.Int {
+	return new(big.Int).Mul(new(big.Int).Div(totalStake, big.NewInt(100)), big.NewInt(51))
+}
+
 type StakeAmountShortage struct {
 	required, actual *big.Int
 }
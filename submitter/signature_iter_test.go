@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
 	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/equivocation"
 	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
 	"github.com/oasysgames/oasys-optimism-verifier/testhelper"
 )
@@ -116,6 +117,7 @@ func (s *SubmitterTestSuite) TestSignatureIterator() {
 	iter := &signatureIterator{
 		db:           s.DB,
 		stakemanager: smcache,
+		detector:     equivocation.NewDetector(s.DB, smcache, nil),
 		contract:     s.SCCAddr,
 		rollupIndex:  0,
 	}
@@ -139,12 +141,41 @@ func (s *SubmitterTestSuite) TestSignatureIterator() {
 		s.True(highStakes[gots1[i].Signer.Address])
 	}
 
+	// split votes should be recorded
+	for rollupIndex, c := range sigGroups[:2] {
+		index := uint64(rollupIndex)
+		conflicts, err := s.DB.Conflict.FindVoteConflicts(&s.SCCAddr, &index, 10, 0)
+		s.NoError(err)
+		s.Len(conflicts, len(c))
+	}
+
 	// should return `*StakeAmountShortage`
 	for i := range sm.Operators {
 		sm.Stakes[i] = ethutil.TenMillionOAS
 	}
 	time.Sleep(time.Millisecond) // wait for cache to expire
 
+	p2p := &p2pMock{}
+	iter.p2p = p2p
 	_, err := iter.next(ctx)
 	s.ErrorContains(err, "stake amount shortage")
+
+	// should request the missing signatures from peers
+	s.Equal(s.SCCAddr, p2p.contract)
+	s.Equal(uint64(2), p2p.rollupIndex)
+	s.ElementsMatch(signerGroups[0].signers, p2p.excludes)
+}
+
+type p2pMock struct {
+	contract    common.Address
+	rollupIndex uint64
+	excludes    []common.Address
+}
+
+func (m *p2pMock) RequestSignatures(
+	contract common.Address,
+	rollupIndex uint64,
+	excludes []common.Address,
+) {
+	m.contract, m.rollupIndex, m.excludes = contract, rollupIndex, excludes
 }
@@ -0,0 +1,75 @@
+package submitter
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Status of the submitter for the target chain.
+type TargetStatus struct {
+	ChainID uint64          `json:"chain_id"`
+	Wallet  *common.Address `json:"wallet"` // nil if the wallet was not found
+	Verses  []*VerseStatus  `json:"verses"`
+}
+
+// Result of the last submission task of the verse.
+type VerseStatus struct {
+	Contract      common.Address `json:"contract"`
+	NextIndex     uint64         `json:"next_index"`
+	VerifiedIndex *uint64        `json:"verified_index"`
+	Result        string         `json:"result"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// Returns the status of each target, the verses that have
+// never been worked on are not included.
+func (w *Submitter) TargetStatuses() []*TargetStatus {
+	targets := map[uint64]*TargetStatus{}
+	for _, cfg := range w.cfg.Load().Targets {
+		target := &TargetStatus{ChainID: cfg.ChainID, Verses: []*VerseStatus{}}
+		if l1Signer := w.l1SignerFn(cfg.ChainID); l1Signer != nil {
+			wallet := l1Signer.Signer()
+			target.Wallet = &wallet
+		}
+		targets[cfg.ChainID] = target
+	}
+
+	w.tasks.Range(func(_ common.Address, task *taskT) bool {
+		if target, ok := targets[task.verse.ChainID()]; ok {
+			if st := task.status.Load(); st != nil {
+				target.Verses = append(target.Verses, st)
+			}
+		}
+		return true
+	})
+
+	statuses := make([]*TargetStatus, 0, len(targets))
+	for _, target := range targets {
+		sort.Slice(target.Verses, func(i, j int) bool {
+			return target.Verses[i].Contract.Cmp(target.Verses[j].Contract) < 0
+		})
+		statuses = append(statuses, target)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ChainID < statuses[j].ChainID })
+	return statuses
+}
+
+// Record the result of the task, called after each submission attempt.
+func (t *taskT) setResult(nextIndex uint64, err error) {
+	st := &VerseStatus{
+		Contract:  t.verse.RollupContract(),
+		NextIndex: nextIndex,
+		Result:    "verified",
+		UpdatedAt: time.Now(),
+	}
+	if t.verifiedIndex != nil {
+		verifiedIndex := *t.verifiedIndex
+		st.VerifiedIndex = &verifiedIndex
+	}
+	if err != nil {
+		st.Result = err.Error()
+	}
+	t.status.Store(st)
+}
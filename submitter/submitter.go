@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -15,6 +16,7 @@ import (
 	"github.com/oasysgames/oasys-optimism-verifier/contract/multicall2"
 	"github.com/oasysgames/oasys-optimism-verifier/contract/stakemanager"
 	"github.com/oasysgames/oasys-optimism-verifier/database"
+	"github.com/oasysgames/oasys-optimism-verifier/equivocation"
 	"github.com/oasysgames/oasys-optimism-verifier/ethutil"
 	"github.com/oasysgames/oasys-optimism-verifier/util"
 	"github.com/oasysgames/oasys-optimism-verifier/verse"
@@ -34,15 +36,19 @@ const (
 var (
 	ErrNoSignatures    = errors.New("no signatures")
 	ErrAlreadyVerified = errors.New("already verified")
+	ErrNotNextIndex    = errors.New("not the next index")
+	ErrDeferred        = errors.New("deferred to the next batch")
 )
 
 type Submitter struct {
 	// fields passed during construction
-	cfg          *config.Submitter
+	cfg          atomic.Pointer[config.Submitter]
 	db           *database.Database
 	l1SignerFn   L1SignerFn
 	stakemanager *stakemanager.Cache
 	versepool    verse.VersePool
+	detector     *equivocation.Detector
+	p2p          P2P
 	log          log.Logger
 
 	// internal fields
@@ -51,9 +57,14 @@ type Submitter struct {
 
 type L1SignerFn func(chainID uint64) ethutil.SignableClient
 
+type P2P interface {
+	RequestSignatures(contract common.Address, rollupIndex uint64, excludes []common.Address)
+}
+
 type taskT struct {
 	verse         verse.TransactableVerse
 	verifiedIndex *uint64
+	status        atomic.Pointer[VerseStatus]
 }
 
 func NewSubmitter(
@@ -62,26 +73,45 @@ func NewSubmitter(
 	l1SignerFn L1SignerFn,
 	stakemanager *stakemanager.Cache,
 	versepool verse.VersePool,
+	detector *equivocation.Detector,
+	p2p P2P,
 ) *Submitter {
-	return &Submitter{
-		cfg:          cfg,
+	w := &Submitter{
 		db:           db,
 		l1SignerFn:   l1SignerFn,
 		stakemanager: stakemanager,
 		versepool:    versepool,
+		detector:     detector,
+		p2p:          p2p,
 		log:          log.New("worker", "submitter"),
 	}
+	w.cfg.Store(cfg)
+	return w
+}
+
+// Replace the configuration of the running submitter, the worker
+// limits and the interval are applied from the next tick.
+func (w *Submitter) SetConfig(cfg *config.Submitter) {
+	w.cfg.Store(cfg)
 }
 
 func (w *Submitter) Start(ctx context.Context) {
-	w.log.Info("Submitter started", "config", w.cfg)
+	cfg := w.cfg.Load()
+	w.log.Info("Submitter started", "config", cfg)
 
 	// Create woker pool.
-	wp := util.NewWorkerPool(w.log, w.work, w.cfg.MaxWorkers,
+	wp := util.NewWorkerPool(w.log, w.work, cfg.MaxWorkers,
 		maxIdleWorkerDuration, workerReleaseCheckInterval, workerReleaseCheckTimeout)
 	wp.Start()
 	defer wp.Stop()
 
+	// Pack the calls of the verses sharing the same wallet into one transaction.
+	crossVerse := isCrossVerse(w.log, cfg)
+	bwp := util.NewWorkerPool(w.log, w.workBatch, cfg.MaxWorkers,
+		maxIdleWorkerDuration, workerReleaseCheckInterval, workerReleaseCheckTimeout)
+	bwp.Start()
+	defer bwp.Stop()
+
 	// Manage running tasks to prevent dups.
 	var running util.SyncMap[common.Address, time.Time]
 
@@ -89,7 +119,7 @@ func (w *Submitter) Start(ctx context.Context) {
 	cacheCleanupTick := time.NewTicker(time.Hour)
 	defer cacheCleanupTick.Stop()
 
-	workTick := time.NewTicker(w.cfg.Interval)
+	workTick := time.NewTicker(cfg.Interval)
 	defer workTick.Stop()
 
 	for {
@@ -107,6 +137,20 @@ func (w *Submitter) Start(ctx context.Context) {
 				return true
 			})
 		case <-workTick.C:
+			// Apply the reloaded configuration.
+			if newCfg := w.cfg.Load(); newCfg != cfg {
+				if newCfg.MaxWorkers != cfg.MaxWorkers {
+					wp.SetMaxWorkers(newCfg.MaxWorkers)
+					bwp.SetMaxWorkers(newCfg.MaxWorkers)
+				}
+				if newCfg.Interval != cfg.Interval {
+					workTick.Reset(newCfg.Interval)
+				}
+				cfg, crossVerse = newCfg, isCrossVerse(w.log, newCfg)
+				w.log.Info("Submitter reconfigured", "config", cfg)
+			}
+
+			batches := map[common.Address]*batchT{}
 			w.versepool.Range(func(item *verse.VersePoolItem) bool {
 				if !item.CanSubmit() {
 					return true
@@ -145,17 +189,36 @@ func (w *Submitter) Start(ctx context.Context) {
 					}
 				}
 
-				if task != nil {
-					wp.Work(ctx, task, func(context.Context, *taskT) { release() })
-				} else {
+				if task == nil {
 					release()
+				} else if crossVerse {
+					wallet := task.verse.L1Signer().Signer()
+					batch, ok := batches[wallet]
+					if !ok {
+						batch = &batchT{wallet: wallet, l1Signer: task.verse.L1Signer()}
+						batches[wallet] = batch
+					}
+					batch.tasks = append(batch.tasks, task)
+					batch.releases = append(batch.releases, release)
+				} else {
+					wp.Work(ctx, task, func(context.Context, *taskT) { release() })
 				}
 				return true
 			})
+			for _, batch := range batches {
+				bwp.Work(ctx, batch, func(context.Context, *batchT) { batch.release() })
+			}
 		}
 	}
 }
 
+func isCrossVerse(log log.Logger, cfg *config.Submitter) bool {
+	if cfg.CrossVerseBatching && !cfg.UseMulticall {
+		log.Warn("Cross-verse batching is disabled as multicall is not used")
+	}
+	return cfg.CrossVerseBatching && cfg.UseMulticall
+}
+
 func (w *Submitter) work(ctx context.Context, task *taskT) {
 	nextIndex, err := w.submit(ctx, task)
 
@@ -164,13 +227,33 @@ func (w *Submitter) work(ctx context.Context, task *taskT) {
 		log = log.New("verified-index", *task.verifiedIndex)
 	}
 
-	if errors.Is(err, verse.ErrNotSufficientConfirmations) {
+	if err != nil {
+		w.logTaskResult(log, nextIndex, err)
+	} else {
+		// Finally, succeeded to verify the corresponding rollup index, So move to the next index
+		task.verifiedIndex = &nextIndex
+		log.Info("Successfully verified the rollup index", "next-verified-index", *task.verifiedIndex)
+		if err := w.cleanupOldSignatures(task.verse.RollupContract(), *task.verifiedIndex); err != nil {
+			log.Warn("Failed to delete old signatures", "verified-index", *task.verifiedIndex, "err", err)
+		}
+	}
+	task.setResult(nextIndex, err)
+}
+
+// Log the reason why the task did not verify the rollup index.
+func (w *Submitter) logTaskResult(log log.Logger, nextIndex uint64, err error) {
+	if err == nil {
+		return
+	} else if errors.Is(err, verse.ErrNotSufficientConfirmations) {
 		log.Info("Not enough confirmations")
 	} else if errors.Is(err, ErrNoSignatures) {
 		log.Info("No signatures to submit")
 	} else if errors.Is(err, ErrAlreadyVerified) {
 		// Skip if the nextIndex is already verified
 		log.Info("Already verified the rollup index")
+	} else if errors.Is(err, ErrDeferred) {
+		// No room in the batch shared with the other verses
+		log.Info("Deferred to the next batch")
 	} else if errors.Is(err, &StakeAmountShortage{}) {
 		// Wait until enough signatures are collected
 		var (
@@ -182,15 +265,8 @@ func (w *Submitter) work(ctx context.Context, task *taskT) {
 		}
 		log.Info("Not enough signatures(stake amount shortage)",
 			"nextIndex", nextIndex, "required", required, "actual", actual)
-	} else if err != nil {
-		log.Error("Failed to verify the rollup index", "err", err)
 	} else {
-		// Finally, succeeded to verify the corresponding rollup index, So move to the next index
-		task.verifiedIndex = &nextIndex
-		log.Info("Successfully verified the rollup index", "next-verified-index", *task.verifiedIndex)
-		if err := w.cleanupOldSignatures(task.verse.RollupContract(), *task.verifiedIndex); err != nil {
-			log.Warn("Failed to delete old signatures", "verified-index", *task.verifiedIndex, "err", err)
-		}
+		log.Error("Failed to verify the rollup index", "err", err)
 	}
 }
 
@@ -214,7 +290,7 @@ func (w *Submitter) submit(ctx context.Context, task *taskT) (uint64, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
 	defer cancel()
 
-	nextIndex, err := w.versepool.NextIndex(ctx, task.verse.RollupContract(), w.cfg.Confirmations, false)
+	nextIndex, err := w.versepool.NextIndex(ctx, task.verse.RollupContract(), w.cfg.Load().Confirmations, false)
 	if err != nil {
 		return 0, fmt.Errorf("failed to fetch next index: %w", err)
 	}
@@ -234,22 +310,28 @@ func (w *Submitter) submit(ctx context.Context, task *taskT) (uint64, error) {
 	iter := &signatureIterator{
 		db:           w.db,
 		stakemanager: w.stakemanager,
+		detector:     w.detector,
+		p2p:          w.p2p,
 		contract:     task.verse.RollupContract(),
 		rollupIndex:  nextIndex,
 	}
 
-	var tx *types.Transaction
-	if w.cfg.UseMulticall {
-		tx, err = w.sendMulticallTx(log, ctx, task.verse, iter)
+	var (
+		tx      *types.Transaction
+		rollups [][]*database.OptimismSignature
+	)
+	if w.cfg.Load().UseMulticall {
+		tx, rollups, err = w.sendMulticallTx(log, ctx, task.verse, iter)
 	} else {
-		tx, err = w.sendNormalTx(log, ctx, task.verse, iter)
+		tx, rollups, err = w.sendNormalTx(log, ctx, task.verse, iter)
 	}
 	if err != nil {
 		log.Debug(err.Error())
 		return nextIndex, fmt.Errorf("failed to send transaction: %w", err)
 	}
 
-	if err = w.waitForReceipt(ctx, task.verse.L1Signer(), tx); err != nil {
+	submitted := &submittedVerse{verse: task.verse, rollups: rollups}
+	if err = w.waitForReceipt(ctx, task.verse.L1Signer(), tx, submitted); err != nil {
 		return nextIndex, fmt.Errorf("failed to wait for receipt: %w", err)
 	}
 
@@ -261,14 +343,14 @@ func (w *Submitter) sendNormalTx(
 	ctx context.Context,
 	task verse.TransactableVerse,
 	iter *signatureIterator,
-) (*types.Transaction, error) {
+) (*types.Transaction, [][]*database.OptimismSignature, error) {
 	rows, err := iter.next(ctx)
 	if err != nil {
 		log.Error("Failed to find signatures", "err", err)
-		return nil, err
+		return nil, nil, err
 	} else if len(rows) == 0 {
 		log.Debug("No signatures")
-		return nil, ErrNoSignatures
+		return nil, nil, ErrNoSignatures
 	}
 
 	opts := task.L1Signer().TransactOpts(ctx)
@@ -278,15 +360,15 @@ func (w *Submitter) sendNormalTx(
 	tx, err := task.Transact(opts, rows[0].RollupIndex, rows[0].Approved, extSignatureBytes(rows))
 	if err != nil {
 		log.Error("Failed to estimate gas", "err", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	// send transaction
 	opts.NoSend = false
-	opts.GasLimit = w.cfg.MultiplyGas(tx.Gas())
+	opts.GasLimit = w.cfg.Load().MultiplyGas(tx.Gas())
 	if err := task.L1Signer().SendTransaction(ctx, tx); err != nil {
 		log.Error("Failed to send verify transaction", "err", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	log.Info(
@@ -297,7 +379,7 @@ func (w *Submitter) sendNormalTx(
 		"gas-fee", tx.GasFeeCap(),
 		"gas-tip", tx.GasTipCap(),
 	)
-	return tx, nil
+	return tx, [][]*database.OptimismSignature{rows}, nil
 }
 
 func (w *Submitter) sendMulticallTx(
@@ -305,38 +387,29 @@ func (w *Submitter) sendMulticallTx(
 	ctx context.Context,
 	task verse.TransactableVerse,
 	iter *signatureIterator,
-) (*types.Transaction, error) {
+) (*types.Transaction, [][]*database.OptimismSignature, error) {
 	mcall, err := multicall2.NewMulticall2(
-		common.HexToAddress(w.cfg.MulticallAddress), task.L1Signer())
+		common.HexToAddress(w.cfg.Load().MulticallAddress), task.L1Signer())
 	if err != nil {
 		log.Error("Failed to construct the multicall contract", "err", err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	opts := &bind.TransactOpts{
-		Context:  ctx,
-		NoSend:   true,
-		Nonce:    common.Big1, // prevent `eth_getNonce`
-		GasPrice: common.Big1, // prevent `eth_gasPrice`
-		GasLimit: 21_000,      // prevent `eth_estimateGas`
-		From:     task.L1Signer().Signer(),
-		Signer: func(a common.Address, rawTx *types.Transaction) (*types.Transaction, error) {
-			return rawTx, nil
-		},
-	}
+	opts := newCalldataOpts(ctx, task.L1Signer().Signer())
 
 	var (
 		calls       []multicall2.Multicall2Call
+		rollups     [][]*database.OptimismSignature
 		errShortage error
 	)
-	for i := 0; i < w.cfg.BatchSize; i++ {
+	for i, batchSize := 0, w.cfg.Load().BatchSize; i < batchSize; i++ {
 		rows, err := iter.next(ctx)
 		if _, ok := err.(*StakeAmountShortage); ok {
 			errShortage = err
 			break
 		} else if err != nil {
 			log.Debug("Failed to find signatures", "err", err)
-			return nil, err
+			return nil, nil, err
 		} else if len(rows) == 0 {
 			break
 		}
@@ -345,7 +418,7 @@ func (w *Submitter) sendMulticallTx(
 		rawTx, err := task.Transact(opts, rows[0].RollupIndex, rows[0].Approved, extSignatureBytes(rows))
 		if err != nil {
 			log.Error("Failed to create verify transaction", "err", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		call := multicall2.Multicall2Call{
@@ -355,13 +428,14 @@ func (w *Submitter) sendMulticallTx(
 		rawTx, err = mcall.TryAggregate(opts, true, append(calls, call))
 		if err != nil {
 			log.Error("Failed to create multicall transaction", "err", err)
-			return nil, err
+			return nil, nil, err
 		} else if len(rawTx.Data()) > maxTxSize {
 			log.Warn("Oversized", "data-size", len(rawTx.Data()), "call-size", i+1)
 			break
 		}
 
 		calls = append(calls, call)
+		rollups = append(rollups, rows)
 
 		// if rejected, there is no need to approve any subsequent rollups.
 		if !rows[0].Approved {
@@ -371,10 +445,10 @@ func (w *Submitter) sendMulticallTx(
 	if len(calls) == 0 {
 		if errShortage != nil {
 			log.Debug("No calldata", "err", errShortage)
-			return nil, errShortage
+			return nil, nil, errShortage
 		}
 		log.Debug("No calldata")
-		return nil, ErrNoSignatures
+		return nil, nil, ErrNoSignatures
 	}
 
 	// call estimateGas
@@ -383,32 +457,28 @@ func (w *Submitter) sendMulticallTx(
 	tx, err := mcall.TryAggregate(opts, true, calls)
 	if err != nil {
 		log.Error("Failed to estimate gas", "err", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	// to fit max gas
-	if tx.Gas() > w.cfg.MaxGas {
-		gasPerCall := (tx.Gas() - minTxGas) / uint64(len(calls))
-		end := uint64(len(calls))
-		for ; end > 1 && end*gasPerCall > w.cfg.MaxGas; end-- {
-		}
-		calls = calls[:end]
+	if end := w.fitMaxGas(tx.Gas(), len(calls)); end < len(calls) {
+		calls, rollups = calls[:end], rollups[:end]
 
 		// re estimateGas
 		tx, err = mcall.TryAggregate(opts, true, calls)
 		if err != nil {
 			log.Error("Failed to re-estimate gas", "err", err)
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
 	// send transaction
 	opts.NoSend = false
-	opts.GasLimit = w.cfg.MultiplyGas(tx.Gas())
+	opts.GasLimit = w.cfg.Load().MultiplyGas(tx.Gas())
 	tx, err = mcall.TryAggregate(opts, true, calls)
 	if err != nil {
 		log.Error("Failed to send multicall verify transaction", "err", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	log.Info(
@@ -420,19 +490,24 @@ func (w *Submitter) sendMulticallTx(
 		"gas-fee", tx.GasFeeCap(),
 		"gas-tip", tx.GasTipCap(),
 	)
-	return tx, nil
+	return tx, rollups, nil
 }
 
 func (w *Submitter) waitForReceipt(
 	ctx context.Context,
 	l1Client ethutil.SignableClient,
 	tx *types.Transaction,
+	verses ...*submittedVerse,
 ) error {
+	w.savePendingSubmission(tx, verses...)
+
 	// wait for block to be validated
 	receipt, err := bind.WaitMined(ctx, l1Client, tx)
 	if err != nil {
+		w.saveSubmissionUnknown(tx, err, verses...)
 		return fmt.Errorf("failed to receive receipt. tx: %s, : %w", tx.Hash().Hex(), err)
 	}
+	w.saveSubmissionReceipt(ctx, l1Client, tx, receipt, verses...)
 	if receipt.Status != 1 {
 		return fmt.Errorf("transaction reverted. tx: %s", tx.Hash().Hex())
 	}
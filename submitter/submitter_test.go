@@ -62,7 +62,7 @@ func (s *SubmitterTestSuite) SetupTest() {
 		UseMulticall:     true, // TODO: No single tx testing
 		MulticallAddress: s.MulticallAddr.String(),
 	}
-	s.submitter = NewSubmitter(s.cfg, s.DB, nil, stakemanager.NewCache(s.StakeManager, time.Hour), s.versepool)
+	s.submitter = NewSubmitter(s.cfg, s.DB, nil, stakemanager.NewCache(s.StakeManager, time.Hour), s.versepool, nil, nil)
 	s.submitter.l1SignerFn = func(chainID uint64) ethutil.SignableClient {
 		return s.SignableHub
 	}
@@ -109,7 +109,7 @@ func (s *SubmitterTestSuite) TestSubmit() {
 	s.Hub.Commit()
 
 	// Confirm blocks
-	for i := 0; i < s.submitter.cfg.Confirmations; i++ {
+	for i := 0; i < s.submitter.cfg.Load().Confirmations; i++ {
 		s.Hub.Mining()
 	}
 
@@ -202,7 +202,7 @@ func (s *SubmitterTestSuite) TestStartSubmitter() {
 	s.TSCC.SetNextIndex(s.SignableHub.TransactOpts(ctx), big.NewInt(int64(nextIndex)))
 	s.Hub.Commit()
 	// Confirm blocks
-	for i := 0; i < s.submitter.cfg.Confirmations; i++ {
+	for i := 0; i < s.submitter.cfg.Load().Confirmations; i++ {
 		s.Hub.Mining()
 	}
 
@@ -118,6 +118,14 @@ func (wp *WorkerPool[T]) getMaxIdle() time.Duration {
 	return wp.maxIdleWorkerDuration
 }
 
+// Change the maximum number of goroutines. When decreased, no new workers
+// are created until the running workers fall below the new maximum.
+func (wp *WorkerPool[T]) SetMaxWorkers(maxWorkersCount int) {
+	wp.lock.Lock()
+	defer wp.lock.Unlock()
+	wp.maxWorkersCount = maxWorkersCount
+}
+
 var maxReleaseCheckLimit = time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC)
 
 func (wp *WorkerPool[T]) getWorkerReleaseTimers() (interval *time.Ticker, timeout time.Time) {
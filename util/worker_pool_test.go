@@ -209,3 +209,29 @@ func TestWorkerPool_Cleanup(t *testing.T) {
 	assert.Equal(int32(1), handlerCalled.Load())
 	assert.Equal(int32(3), cleanupCalled.Load())
 }
+
+func TestWorkerPool_SetMaxWorkers(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := func(ctx context.Context, job int) { <-ctx.Done() }
+	wp := NewWorkerPool(log.Root(), handler, 2, time.Minute, 0, 0)
+	wp.Start()
+	defer wp.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.True(wp.Work(ctx, 0, nil))
+	assert.True(wp.Work(ctx, 1, nil))
+	assert.False(wp.Work(ctx, 2, nil))
+
+	// increase
+	wp.SetMaxWorkers(3)
+	assert.True(wp.Work(ctx, 3, nil))
+	assert.False(wp.Work(ctx, 4, nil))
+
+	// decrease, the running workers are kept
+	wp.SetMaxWorkers(1)
+	assert.Equal(3, wp.workersCount)
+	assert.False(wp.Work(ctx, 5, nil))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -26,7 +27,7 @@ const (
 // Worker to verify rollups.
 type Verifier struct {
 	// fields passed during construction
-	cfg        *config.Verifier
+	cfg        atomic.Pointer[config.Verifier]
 	db         *database.Database
 	l1Signer   ethutil.SignableClient
 	l2ClientFn L2ClientFn
@@ -59,8 +60,7 @@ func NewVerifier(
 	l2ClientFn L2ClientFn,
 	versepool verse.VersePool,
 ) *Verifier {
-	return &Verifier{
-		cfg:              cfg,
+	w := &Verifier{
 		db:               db,
 		newSigP2P:        p2p,
 		unverifiedSigP2P: p2p,
@@ -69,6 +69,14 @@ func NewVerifier(
 		versepool:        versepool,
 		log:              log.New("worker", "verifier"),
 	}
+	w.cfg.Store(cfg)
+	return w
+}
+
+// Replace the configuration of the running verifier, the worker
+// limits and the intervals are applied from the next tick.
+func (w *Verifier) SetConfig(cfg *config.Verifier) {
+	w.cfg.Store(cfg)
 }
 
 func (w *Verifier) RemoveTask(contract common.Address) {
@@ -76,21 +84,16 @@ func (w *Verifier) RemoveTask(contract common.Address) {
 }
 
 func (w *Verifier) Start(ctx context.Context) {
-	w.log.Info("Verifier started", "config", w.cfg)
-
-	maxVerificationWorkers := w.cfg.MaxWorkers
-	verificationInterval := w.cfg.Interval
-
-	// Publish workers run expensive database queries, so the number of workers should be small.
-	// P2P publishing is asynchronous and sufficiently fast.
-	_, maxPublishWorkers := util.MinMax(maxVerificationWorkers/5, 2)
-	publishInterval, _ := util.MinMax(verificationInterval*10, time.Minute)
+	w.log.Info("Verifier started", "config", w.cfg.Load())
 
 	// Workers performing verification.
 	go func() {
 		// Manage running tasks to prevent dups.
 		var running util.SyncMap[common.Address, time.Time]
 
+		cfg := w.cfg.Load()
+		maxVerificationWorkers, verificationInterval := cfg.MaxWorkers, cfg.Interval
+
 		// Create woker pool.
 		wp := util.NewWorkerPool(w.log, w.verify, maxVerificationWorkers,
 			maxIdleWorkerDuration, workerReleaseCheckInterval, workerReleaseCheckTimeout)
@@ -127,6 +130,18 @@ func (w *Verifier) Start(ctx context.Context) {
 					return true
 				})
 			case <-workTick.C:
+				// Apply the reloaded configuration.
+				if newCfg := w.cfg.Load(); newCfg != cfg {
+					cfg = newCfg
+					if cfg.MaxWorkers != maxVerificationWorkers || cfg.Interval != verificationInterval {
+						maxVerificationWorkers, verificationInterval = cfg.MaxWorkers, cfg.Interval
+						wp.SetMaxWorkers(maxVerificationWorkers)
+						workTick.Reset(verificationInterval)
+						w.log.Info("Verification workers reconfigured",
+							"max-workers", maxVerificationWorkers, "interval", verificationInterval)
+					}
+				}
+
 				w.versepool.Range(func(item *verse.VersePoolItem) bool {
 					log := item.Verse().Logger(w.log)
 
@@ -178,6 +193,9 @@ func (w *Verifier) Start(ctx context.Context) {
 		// Manage running tasks to prevent dups.
 		var running util.SyncMap[common.Address, time.Time]
 
+		cfg := w.cfg.Load()
+		maxPublishWorkers, publishInterval := publishWorkerParams(cfg)
+
 		// Create woker pool.
 		wp := util.NewWorkerPool(w.log, w.publish, maxPublishWorkers,
 			maxIdleWorkerDuration, workerReleaseCheckInterval, workerReleaseCheckTimeout)
@@ -196,6 +214,18 @@ func (w *Verifier) Start(ctx context.Context) {
 				log.Info("Publish workers stopped")
 				return
 			case <-tick.C:
+				// Apply the reloaded configuration.
+				if newCfg := w.cfg.Load(); newCfg != cfg {
+					cfg = newCfg
+					if workers, interval := publishWorkerParams(cfg); workers != maxPublishWorkers || interval != publishInterval {
+						maxPublishWorkers, publishInterval = workers, interval
+						wp.SetMaxWorkers(maxPublishWorkers)
+						tick.Reset(publishInterval)
+						w.log.Info("Publish workers reconfigured",
+							"max-workers", maxPublishWorkers, "interval", publishInterval)
+					}
+				}
+
 				w.versepool.Range(func(item *verse.VersePoolItem) bool {
 					log := item.Verse().Logger(w.log)
 					cacheKey := item.Verse().RollupContract()
@@ -222,17 +252,26 @@ func (w *Verifier) Start(ctx context.Context) {
 	}()
 
 	<-ctx.Done()
-	w.log.Info("Verifier stopped", "config", w.cfg)
+	w.log.Info("Verifier stopped", "config", w.cfg.Load())
+}
+
+// Publish workers run expensive database queries, so the number of workers should be small.
+// P2P publishing is asynchronous and sufficiently fast.
+func publishWorkerParams(cfg *config.Verifier) (maxWorkers int, interval time.Duration) {
+	_, maxWorkers = util.MinMax(cfg.MaxWorkers/5, 2)
+	interval, _ = util.MinMax(cfg.Interval*10, time.Minute)
+	return maxWorkers, interval
 }
 
 // Fetch and verify rollup events from the Hub-Layer.
 func (w *Verifier) verify(parent context.Context, task *taskT) {
 	log := task.verse.Logger(w.log)
+	cfg := w.cfg.Load()
 
-	l1ctx, l1cancel := context.WithTimeout(parent, w.cfg.StateCollectTimeout)
+	l1ctx, l1cancel := context.WithTimeout(parent, cfg.StateCollectTimeout)
 	defer l1cancel()
 
-	nextIndex, err := w.versepool.NextIndex(l1ctx, task.verse.RollupContract(), w.cfg.Confirmations, true)
+	nextIndex, err := w.versepool.NextIndex(l1ctx, task.verse.RollupContract(), cfg.Confirmations, true)
 	if err != nil {
 		w.log.Error("Failed to fetch next index", "err", err)
 		return
@@ -298,7 +337,7 @@ func (w *Verifier) verify(parent context.Context, task *taskT) {
 			err error
 		)
 		for {
-			l2ctx, l2cancel := context.WithTimeout(parent, w.cfg.StateCollectTimeout*2)
+			l2ctx, l2cancel := context.WithTimeout(parent, cfg.StateCollectTimeout*2)
 			row, err = w.verifyAndSaveLog(l2ctx, &logs[i], task.verse, nextIndex, log)
 			l2cancel()
 
@@ -388,7 +427,7 @@ func (w *Verifier) publish(parent context.Context, task *taskT) {
 	log := task.verse.Logger(w.log)
 
 	contract := task.verse.RollupContract()
-	nextIndex, err := w.versepool.NextIndex(parent, contract, w.cfg.Confirmations, true)
+	nextIndex, err := w.versepool.NextIndex(parent, contract, w.cfg.Load().Confirmations, true)
 	if err != nil {
 		log.Error("Failed to fetch next index", "err", err)
 		return
@@ -438,7 +477,7 @@ func (w *Verifier) verifyAndSaveLog(ctx context.Context, log *types.Log, task ve
 		return nil, nil
 	}
 
-	approved, err := task.Verify(logger, ctx, dbEvent, w.cfg.StateCollectLimit)
+	approved, err := task.Verify(logger, ctx, dbEvent, w.cfg.Load().StateCollectLimit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verification. rollup-index: %d, : %w", dbEvent.GetRollupIndex(), err)
 	}
@@ -489,15 +528,16 @@ func (w *Verifier) retryBackoff() (incr func() (delay, remain time.Duration, att
 	var counter, gauge int
 	incr = func() (time.Duration, time.Duration, int) {
 		// backoff delay: 0.1s, 0.8s, 6.4s, 51.2s, 409.6s(7m), 3276.8s(54m),
+		cfg := w.cfg.Load()
 		delay := 100 << (3 * gauge) * time.Millisecond
-		if delay <= 0 || delay > w.cfg.MaxRetryBackoff { // delay <= 0 is overflow
-			delay = w.cfg.MaxRetryBackoff
+		if delay <= 0 || delay > cfg.MaxRetryBackoff { // delay <= 0 is overflow
+			delay = cfg.MaxRetryBackoff
 		} else {
 			gauge++
 		}
 
 		// The remaining time will not be replenished even if `decr` is done.
-		remain := w.cfg.RetryTimeout - time.Since(started)
+		remain := cfg.RetryTimeout - time.Since(started)
 		if remain < 0 {
 			remain = 0
 		}
@@ -521,7 +561,7 @@ func (w *Verifier) getBlockRangeManager(
 	task verse.Verse,
 	maxRetry int,
 ) (*eventFetchingBlockRangeManager, error) {
-	nextIndex, err := w.versepool.NextIndex(ctx, task.RollupContract(), w.cfg.Confirmations, true)
+	nextIndex, err := w.versepool.NextIndex(ctx, task.RollupContract(), w.cfg.Load().Confirmations, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch next index: %w", err)
 	}
@@ -534,15 +574,15 @@ func (w *Verifier) getBlockRangeManager(
 	log = log.New("next-index", nextIndex)
 
 	// Fetch the L1 block number where the event matching the next index was emitted.
-	tick := time.NewTicker(w.cfg.Interval)
+	tick := time.NewTicker(w.cfg.Load().Interval)
 	defer tick.Stop()
 
 	for attempts := 1; ; attempts++ {
 		emittedBlock, err := w.versepool.EventEmittedBlock(
-			ctx, task.RollupContract(), nextIndex, w.cfg.Confirmations, true)
+			ctx, task.RollupContract(), nextIndex, w.cfg.Load().Confirmations, true)
 		if err == nil {
 			log.Info("Initial block has been determined", "block", emittedBlock, "attempts", attempts)
-			return newEventFetchingBlockRangeManager(w.cfg.MaxLogFetchBlockRange, emittedBlock), nil
+			return newEventFetchingBlockRangeManager(w.cfg.Load().MaxLogFetchBlockRange, emittedBlock), nil
 		}
 		if attempts == maxRetry {
 			break
@@ -574,8 +614,9 @@ func (w *Verifier) determineMaxEnd(
 	// Fetch the L1 block number where the event matching the `nextIndex+w.cfg.MaxIndexDiff`
 	// was emitted. It is to avoid excessively verifying new events, as the Submitter node
 	// might not be subscribed to PubSub.
+	cfg := w.cfg.Load()
 	emittedBlock, err := w.versepool.EventEmittedBlock(
-		ctx, task.RollupContract(), nextIndex+uint64(w.cfg.MaxIndexDiff), w.cfg.Confirmations, true)
+		ctx, task.RollupContract(), nextIndex+uint64(cfg.MaxIndexDiff), cfg.Confirmations, true)
 	if err == nil {
 		return emittedBlock, nil
 	}
@@ -588,8 +629,8 @@ func (w *Verifier) determineMaxEnd(
 	}
 
 	max := header.Number.Uint64()
-	if max > uint64(w.cfg.Confirmations) {
-		max -= uint64(w.cfg.Confirmations)
+	if max > uint64(cfg.Confirmations) {
+		max -= uint64(cfg.Confirmations)
 	}
 	return max, nil
 }
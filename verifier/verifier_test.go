@@ -231,12 +231,10 @@ func (s *VerifierTestSuite) TestStartVerifier() {
 }
 
 func (s *VerifierTestSuite) TestRetryBackoff() {
-	verifier := &Verifier{
-		cfg: &config.Verifier{
-			MaxRetryBackoff: time.Minute,
-			RetryTimeout:    time.Millisecond * 100,
-		},
-	}
+	verifier := NewVerifier(&config.Verifier{
+		MaxRetryBackoff: time.Minute,
+		RetryTimeout:    time.Millisecond * 100,
+	}, nil, nil, nil, nil, nil)
 
 	incr, decr := verifier.retryBackoff()
 
@@ -245,7 +243,7 @@ func (s *VerifierTestSuite) TestRetryBackoff() {
 		delay, remain, attempts := incr()
 
 		s.Equal(i+1, attempts)
-		s.Less(remain, verifier.cfg.RetryTimeout-wait*time.Duration(i))
+		s.Less(remain, verifier.cfg.Load().RetryTimeout-wait*time.Duration(i))
 
 		switch i {
 		case 0:
@@ -72,6 +72,18 @@ func (op *oplegacy) EventEmittedBlock(opts *bind.FilterOpts, rollupIndex uint64)
 	return e.Raw.BlockNumber, nil
 }
 
+func (op *oplegacy) RollupHash(opts *bind.FilterOpts, rollupIndex uint64) (common.Hash, error) {
+	sc, err := newSccContract(op)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	e, err := findStateBatchAppendedEvent(sc, opts, rollupIndex)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return e.BatchRoot, nil
+}
+
 func (op *oplegacy) WithVerifiable(l2Client ethutil.Client) VerifiableVerse {
 	return &verifiableOPLegacy{&verifiableVerse{op, l2Client}}
 }
@@ -69,6 +69,18 @@ func (op *opstack) EventEmittedBlock(opts *bind.FilterOpts, rollupIndex uint64)
 	return e.Raw.BlockNumber, nil
 }
 
+func (op *opstack) RollupHash(opts *bind.FilterOpts, rollupIndex uint64) (common.Hash, error) {
+	lo, err := newL2ooContract(op)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	e, err := findOutputProposed(lo, opts, rollupIndex)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return e.OutputRoot, nil
+}
+
 func (op *opstack) WithVerifiable(l2Client ethutil.Client) VerifiableVerse {
 	return &verifiableOPStack{&verifiableVerse{op, l2Client}}
 }
@@ -35,6 +35,9 @@ type Verse interface {
 	// Returns the block number at which the event with the given rollup index was emitted on the L1.
 	EventEmittedBlock(opts *bind.FilterOpts, rollupIndex uint64) (uint64, error)
 
+	// Returns the rollup hash of the last event with the given rollup index emitted on the L1.
+	RollupHash(opts *bind.FilterOpts, rollupIndex uint64) (common.Hash, error)
+
 	WithVerifiable(l2Client ethutil.Client) VerifiableVerse
 	WithTransactable(l1Signer ethutil.SignableClient, verifyContract common.Address) TransactableVerse
 }
@@ -99,6 +102,9 @@ func (v *verse) NextIndex(opts *bind.CallOpts) (uint64, error) {
 func (v *verse) EventEmittedBlock(opts *bind.FilterOpts, rollupIndex uint64) (uint64, error) {
 	panic("not implemented")
 }
+func (v *verse) RollupHash(opts *bind.FilterOpts, rollupIndex uint64) (common.Hash, error) {
+	panic("not implemented")
+}
 func (v *verse) GetEventEmittedBlock(ctx context.Context, rollupIndex uint64, confirmation int, waits bool) (uint64, error) {
 	panic("not implemented")
 }
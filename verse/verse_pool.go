@@ -68,6 +68,10 @@ type VersePool interface {
 		confirmation int,
 		waits bool,
 	) (uint64, error)
+
+	// Returns the rollup hash of the given rollup index currently recorded on the L1.
+	// Unlike `EventEmittedBlock`, the value is not cached as it may be changed by a reorg.
+	RollupHash(ctx context.Context, contract common.Address, rollupIndex uint64) (common.Hash, error)
 }
 
 type VersePoolItem struct {
@@ -217,5 +221,22 @@ func (pool *versePool) EventEmittedBlock(
 	return emittedBlock, nil
 }
 
+func (pool *versePool) RollupHash(
+	ctx context.Context,
+	contract common.Address,
+	rollupIndex uint64,
+) (common.Hash, error) {
+	item, ok := pool.verses.Load(contract)
+	if !ok {
+		return common.Hash{}, fmt.Errorf("not in the pool: %s", contract)
+	}
+
+	opts := &bind.FilterOpts{Context: ctx}
+	if fsbCache := item.filterStartBlockCache.Load(); fsbCache != nil && rollupIndex >= fsbCache.rollupIndex {
+		opts.Start = fsbCache.startBlock
+	}
+	return item.verse.RollupHash(opts, rollupIndex)
+}
+
 func (item *VersePoolItem) Verse() Verse    { return item.verse }
 func (item *VersePoolItem) CanSubmit() bool { return item.canSubmit }
@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/oasysgames/oasys-optimism-verifier/testhelper/backend"
 	"github.com/stretchr/testify/suite"
 )
@@ -171,3 +172,34 @@ func (s *VersePoolTestSuite) TestEventEmittedBlock() {
 		ctx, s.verse1.RollupContract(), uint64(rollupIndex), confirmation, true)
 	s.Equal(want1, got1)
 }
+
+func (s *VersePoolTestSuite) TestRollupHash() {
+	ctx := context.Background()
+
+	// If it does not exist in the pool, an error should be returned
+	_, err := s.pool.RollupHash(ctx, s.verse1.RollupContract(), 0)
+	s.ErrorContains(err, "not in the pool")
+
+	s.pool.Add(s.verse1, false)
+	s.pool.Add(s.verse2, false)
+
+	// Not emitted yet
+	_, err = s.pool.RollupHash(ctx, s.verse1.RollupContract(), 0)
+	s.ErrorIs(err, ErrEventNotFound)
+
+	_, sccEvent := s.EmitStateBatchAppended(0)
+	got, err := s.pool.RollupHash(ctx, s.verse1.RollupContract(), 0)
+	s.NoError(err)
+	s.Equal(common.Hash(sccEvent.BatchRoot), got)
+
+	_, l2ooEvent := s.EmitOutputProposed(0)
+	got, err = s.pool.RollupHash(ctx, s.verse2.RollupContract(), 0)
+	s.NoError(err)
+	s.Equal(common.Hash(l2ooEvent.OutputRoot), got)
+
+	// The last event is returned if re-emitted, e.g. by a reorg.
+	_, l2ooEvent = s.EmitOutputProposed(0)
+	got, err = s.pool.RollupHash(ctx, s.verse2.RollupContract(), 0)
+	s.NoError(err)
+	s.Equal(common.Hash(l2ooEvent.OutputRoot), got)
+}